@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/configs"
+	"github.com/g-villarinho/nubank-challenge/dependencies"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/services"
+	"github.com/g-villarinho/nubank-challenge/storages"
+	"gorm.io/gorm"
+)
+
+const usage = `usage:
+  apikeys create -name <name> -scopes <scope,scope> -tenant <tenant>
+  apikeys list
+  apikeys revoke -id <id>
+
+scopes: ` + "%s\n"
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintf(os.Stderr, usage, strings.Join(models.Scopes, ", "))
+		os.Exit(2)
+	}
+
+	if err := configs.LoadEnv(); err != nil {
+		log.Fatal("load env: ", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	db, err := storages.NewPostgresStorage(ctx)
+	if err != nil {
+		log.Fatal("connect to database: ", err)
+	}
+
+	di := newDi(db)
+
+	if err := di.Validate(); err != nil {
+		log.Fatal("validate dependencies: ", err)
+	}
+
+	apiKeyService, err := pkgs.Invoke[services.APIKeyService](di)
+	if err != nil {
+		log.Fatal("invoke services.api_key: ", err)
+	}
+
+	switch os.Args[1] {
+	case "create":
+		create(ctx, apiKeyService, os.Args[2:])
+	case "list":
+		list(ctx, apiKeyService)
+	case "revoke":
+		revoke(ctx, apiKeyService, os.Args[2:])
+	default:
+		fmt.Fprintf(os.Stderr, usage, strings.Join(models.Scopes, ", "))
+		os.Exit(2)
+	}
+}
+
+func create(ctx context.Context, apiKeyService services.APIKeyService, args []string) {
+	flags := flag.NewFlagSet("create", flag.ExitOnError)
+	name := flags.String("name", "", "nome de quem vai usar a chave")
+	scopes := flags.String("scopes", "", "escopos separados por vírgula")
+	tenant := flags.String("tenant", "", "tenant ao qual a chave fica vinculada; obrigatório, exceto com o escopo platform")
+	_ = flags.Parse(args)
+
+	created, err := apiKeyService.CreateAPIKey(ctx, models.CreateAPIKeyPayload{
+		Name:     *name,
+		Scopes:   splitScopes(*scopes),
+		TenantID: *tenant,
+	})
+	if err != nil {
+		log.Fatal("create api key: ", err)
+	}
+
+	fmt.Printf("id:     %s\nscopes: %s\ntenant: %s\nkey:    %s\n\n", created.ID, strings.Join(created.Scopes, ","), formatTenant(created.TenantID), created.Key)
+	fmt.Println("store the key now; it cannot be shown again")
+}
+
+func list(ctx context.Context, apiKeyService services.APIKeyService) {
+	keys, err := apiKeyService.GetAPIKeys(ctx)
+	if err != nil {
+		log.Fatal("get api keys: ", err)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tNAME\tPREFIX\tSCOPES\tTENANT\tLAST USED\tREVOKED")
+
+	for _, key := range keys {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			key.ID, key.Name, key.Prefix, strings.Join(key.Scopes, ","), formatTenant(key.TenantID), formatTime(key.LastUsedAt), formatTime(key.RevokedAt))
+	}
+
+	_ = writer.Flush()
+}
+
+func revoke(ctx context.Context, apiKeyService services.APIKeyService, args []string) {
+	flags := flag.NewFlagSet("revoke", flag.ExitOnError)
+	id := flags.String("id", "", "ID da chave")
+	_ = flags.Parse(args)
+
+	if err := apiKeyService.RevokeAPIKey(ctx, *id); err != nil {
+		log.Fatal("revoke api key: ", err)
+	}
+
+	fmt.Printf("api key %s revoked\n", *id)
+}
+
+// newDi monta o container da CLI sobre db com os mesmos providers da API
+func newDi(db *gorm.DB) *pkgs.Di {
+	di := pkgs.NewDi()
+
+	pkgs.Provide(di, func(di *pkgs.Di) (*gorm.DB, error) {
+		return db, nil
+	})
+	dependencies.Provide(di)
+
+	return di
+}
+
+func splitScopes(scopes string) []string {
+	var result []string
+	for scope := range strings.SplitSeq(scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			result = append(result, scope)
+		}
+	}
+
+	return result
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+func formatTenant(tenant string) string {
+	if tenant == "" {
+		return "platform"
+	}
+
+	return tenant
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/g-villarinho/nubank-challenge/configs"
+	"github.com/g-villarinho/nubank-challenge/limiters"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/publishers"
+	"github.com/g-villarinho/nubank-challenge/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestNewDi(t *testing.T) {
+	t.Run("should build every service used by the cli", func(t *testing.T) {
+		previous := configs.Env
+		t.Cleanup(func() { configs.Env = previous })
+
+		configs.Env = models.Environment{
+			Outbox:    models.Outbox{Publisher: publishers.PublisherMemory},
+			RateLimit: models.RateLimit{Store: limiters.StoreMemory},
+		}
+
+		sqlDB, _, err := sqlmock.New()
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = sqlDB.Close() })
+
+		db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		require.NoError(t, err)
+
+		di := newDi(db)
+
+		assert.NoError(t, di.Validate())
+
+		_, err = pkgs.Invoke[services.APIKeyService](di)
+		assert.NoError(t, err)
+	})
+}
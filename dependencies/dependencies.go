@@ -0,0 +1,72 @@
+package dependencies
+
+import (
+	"github.com/g-villarinho/nubank-challenge/handlers"
+	"github.com/g-villarinho/nubank-challenge/limiters"
+	"github.com/g-villarinho/nubank-challenge/metrics"
+	"github.com/g-villarinho/nubank-challenge/middlewares"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/publishers"
+	"github.com/g-villarinho/nubank-challenge/repositories"
+	"github.com/g-villarinho/nubank-challenge/services"
+	"github.com/g-villarinho/nubank-challenge/workers"
+)
+
+// Provide registra os serviços da aplicação, compartilhados pela API e pelos comandos (expurgo e
+// CLI de chaves). O *gorm.DB é registrado por quem chama, para que os testes montem o mesmo grafo
+// sobre um banco falso. Os providers só são construídos quando invocados, então cada comando
+// abre apenas as dependências que usa.
+func Provide(di *pkgs.Di) {
+	// Handlers
+	pkgs.Provide(di, handlers.NewClientHandler)
+	pkgs.Provide(di, handlers.NewContactHandler)
+	pkgs.Provide(di, handlers.NewAuditHandler)
+	pkgs.Provide(di, handlers.NewWebhookHandler)
+	pkgs.Provide(di, handlers.NewHealthCheckHandler)
+
+	// Middlewares
+	pkgs.Provide(di, middlewares.NewIdempotencyMiddleware)
+	pkgs.Provide(di, middlewares.NewRequestContextMiddleware)
+	pkgs.Provide(di, middlewares.NewAuthMiddleware)
+	pkgs.Provide(di, middlewares.NewRateLimitMiddleware)
+	pkgs.Provide(di, middlewares.NewMetricsMiddleware)
+	pkgs.Provide(di, middlewares.NewTracingMiddleware)
+	pkgs.Provide(di, middlewares.NewRequestLoggerMiddleware)
+
+	// Services
+	pkgs.Provide(di, services.NewClientService)
+	pkgs.Provide(di, services.NewContactService)
+	pkgs.Provide(di, services.NewIdempotencyService)
+	pkgs.Provide(di, services.NewAuditService)
+	pkgs.Provide(di, services.NewOutboxService)
+	pkgs.Provide(di, services.NewWebhookService)
+	pkgs.Provide(di, services.NewAPIKeyService)
+	pkgs.Provide(di, services.NewJWTService)
+	pkgs.Provide(di, services.NewHealthcheckService)
+
+	// Repositories
+	pkgs.Provide(di, repositories.NewUnitOfWork)
+	pkgs.Provide(di, repositories.NewClientRepository)
+	pkgs.Provide(di, repositories.NewContactRepository)
+	pkgs.Provide(di, repositories.NewIdempotencyRepository)
+	pkgs.Provide(di, repositories.NewAuditRepository)
+	pkgs.Provide(di, repositories.NewOutboxRepository)
+	pkgs.Provide(di, repositories.NewWebhookRepository)
+	pkgs.Provide(di, repositories.NewAPIKeyRepository)
+	pkgs.Provide(di, repositories.NewRateLimitRepository)
+	pkgs.ProvideNamed(di, models.HealthDependencyPostgres, repositories.NewHealthRepository)
+
+	// Publishers
+	pkgs.Provide(di, publishers.NewPublisher)
+
+	// Limiters
+	pkgs.Provide(di, limiters.NewLimiter)
+
+	// Metrics
+	pkgs.Provide(di, metrics.NewMetrics)
+
+	// Workers
+	pkgs.Provide(di, workers.NewOutboxRelay)
+	pkgs.Provide(di, workers.NewWebhookDispatcher)
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/services"
+	"github.com/labstack/echo/v4"
+)
+
+type AuditHandler interface {
+	GetAuditLogs(ectx echo.Context) error
+	GetClientHistory(ectx echo.Context) error
+}
+
+type auditHandler struct {
+	di *pkgs.Di
+	as services.AuditService
+}
+
+func NewAuditHandler(di *pkgs.Di) (AuditHandler, error) {
+	auditService, err := pkgs.Invoke[services.AuditService](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke services.audit: %w", err)
+	}
+
+	return &auditHandler{
+		di: di,
+		as: auditService,
+	}, nil
+}
+
+// GetAuditLogs godoc
+// @Summary Lista os registros de auditoria
+// @Description Retorna as alterações feitas em clientes e contatos, da mais recente para a mais antiga, paginadas por cursor.
+// @Tags audit
+// @Produce json
+// @Param cursor query string false "Cursor opaco retornado em meta.nextCursor"
+// @Param limit query int false "Quantidade de registros por página (padrão 20, máximo 100)"
+// @Param entityType query string false "Tipo da entidade alterada" Enums(client, contact)
+// @Param entityId query string false "ID da entidade alterada"
+// @Param clientId query string false "ID do cliente afetado"
+// @Param action query string false "Operação realizada" Enums(create, update, delete, restore, transfer)
+// @Param actor query string false "Quem realizou a alteração"
+// @Param requestId query string false "ID da requisição que originou a alteração"
+// @Param from query string false "Data inicial (RFC 3339, inclusiva)"
+// @Param to query string false "Data final (RFC 3339, exclusiva)"
+// @Success 200 {object} models.AuditPageResponse
+// @Header 200 {string} Link "Link para a página seguinte (rel=next)"
+// @Failure 400 {object} models.ProblemDetails "Parâmetros de busca inválidos"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao buscar registros de auditoria"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /audit [get]
+func (a *auditHandler) GetAuditLogs(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "audit"),
+		slog.String("method", "GetAuditLogs"),
+	)
+
+	var query models.AuditQuery
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ectx, &query); err != nil {
+		logger.Warn("error to bind query", "error", err)
+		return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	if err := ectx.Validate(&query); err != nil {
+		logger.Warn("invalid query", "error", err)
+		return err
+	}
+
+	page, err := a.as.GetAuditLogs(ectx.Request().Context(), query)
+	if err != nil {
+		logger.Error("error to get audit logs", "error", err)
+		return err
+	}
+
+	setPaginationLinks(ectx, page.Meta)
+
+	return ectx.JSON(http.StatusOK, page)
+}
+
+// GetClientHistory godoc
+// @Summary Histórico de alterações de um cliente
+// @Description Retorna as alterações feitas no cliente e em seus contatos, da mais recente para a mais antiga.
+// @Description O histórico continua disponível depois que o cliente é removido.
+// @Tags audit
+// @Produce json
+// @Param clientId path string true "ID do cliente"
+// @Param cursor query string false "Cursor opaco retornado em meta.nextCursor"
+// @Param limit query int false "Quantidade de registros por página (padrão 20, máximo 100)"
+// @Param entityType query string false "Tipo da entidade alterada" Enums(client, contact)
+// @Param action query string false "Operação realizada" Enums(create, update, delete, restore, transfer)
+// @Param from query string false "Data inicial (RFC 3339, inclusiva)"
+// @Param to query string false "Data final (RFC 3339, exclusiva)"
+// @Success 200 {object} models.AuditPageResponse
+// @Header 200 {string} Link "Link para a página seguinte (rel=next)"
+// @Failure 400 {object} models.ProblemDetails "ID ou parâmetros de busca inválidos"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário"
+// @Failure 404 {object} models.ProblemDetails "Cliente não encontrado"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao buscar o histórico"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /clients/{clientId}/history [get]
+func (a *auditHandler) GetClientHistory(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "audit"),
+		slog.String("method", "GetClientHistory"),
+	)
+
+	id, err := pathID(ectx, "clientId")
+	if err != nil {
+		return err
+	}
+
+	var query models.AuditQuery
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ectx, &query); err != nil {
+		logger.Warn("error to bind query", "error", err)
+		return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	if err := ectx.Validate(&query); err != nil {
+		logger.Warn("invalid query", "error", err)
+		return err
+	}
+
+	page, err := a.as.GetClientHistory(ectx.Request().Context(), id, query)
+	if err != nil {
+		logger.Error("error to get client history", "error", err)
+		return err
+	}
+
+	setPaginationLinks(ectx, page.Meta)
+
+	return ectx.JSON(http.StatusOK, page)
+}
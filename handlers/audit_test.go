@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/g-villarinho/nubank-challenge/mocks"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditHandler_GetAuditLogs(t *testing.T) {
+	e := echo.New()
+	e.Validator = pkgs.NewValidator()
+	ctx := context.Background()
+
+	t.Run("should bind filters and set the next page link", func(t *testing.T) {
+		auditService := new(mocks.AuditServiceMock)
+		handler := &auditHandler{as: auditService}
+
+		auditService.
+			On("GetAuditLogs", ctx, models.AuditQuery{
+				Limit:      10,
+				EntityType: models.AuditEntityContact,
+				Action:     models.AuditActionDelete,
+				Actor:      "backoffice",
+			}).
+			Return(&models.AuditPageResponse{
+				Data: []models.AuditLogResponse{},
+				Meta: models.PageMeta{Limit: 10, HasNext: true, NextCursor: "next"},
+			}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/audit?limit=10&entityType=contact&action=delete&actor=backoffice", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetRequest(req.WithContext(ctx))
+
+		err := handler.GetAuditLogs(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t,
+			`</audit?action=delete&actor=backoffice&cursor=next&entityType=contact&limit=10>; rel="next"`,
+			rec.Header().Get("Link"))
+		auditService.AssertExpectations(t)
+	})
+
+	t.Run("should return validation error on invalid action", func(t *testing.T) {
+		handler := &auditHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/audit?action=read", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := handler.GetAuditLogs(c)
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+	})
+}
+
+func TestAuditHandler_GetClientHistory(t *testing.T) {
+	e := echo.New()
+	e.Validator = pkgs.NewValidator()
+	ctx := context.Background()
+
+	t.Run("should return the client history", func(t *testing.T) {
+		auditService := new(mocks.AuditServiceMock)
+		handler := &auditHandler{as: auditService}
+
+		auditService.
+			On("GetClientHistory", ctx, "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", models.AuditQuery{}).
+			Return(&models.AuditPageResponse{
+				Data: []models.AuditLogResponse{{ID: "log-1", ClientID: "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f"}},
+				Meta: models.PageMeta{Limit: models.DefaultPageLimit},
+			}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f/history", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+		c.SetRequest(req.WithContext(ctx))
+
+		err := handler.GetClientHistory(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"id":"log-1"`)
+	})
+
+	t.Run("should return 404 if client not found", func(t *testing.T) {
+		auditService := new(mocks.AuditServiceMock)
+		handler := &auditHandler{as: auditService}
+
+		auditService.
+			On("GetClientHistory", ctx, "0e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a", models.AuditQuery{}).
+			Return(nil, models.ErrClientNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/clients/0e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a/history", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("0e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a")
+		c.SetRequest(req.WithContext(ctx))
+
+		err := handler.GetClientHistory(c)
+
+		assert.ErrorIs(t, err, models.ErrClientNotFound)
+	})
+
+	t.Run("should return 400 if clientId is not a uuid", func(t *testing.T) {
+		auditService := new(mocks.AuditServiceMock)
+		handler := &auditHandler{as: auditService}
+
+		req := httptest.NewRequest(http.MethodGet, "/clients/abc/history", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("abc")
+
+		err := handler.GetClientHistory(c)
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+		auditService.AssertNotCalled(t, "GetClientHistory", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 
@@ -16,6 +17,12 @@ type ClientHandler interface {
 	CreateClient(ectx echo.Context) error
 	GetClientsWithContact(ectx echo.Context) error
 	GetClientContactsByID(ectx echo.Context) error
+	GetClient(ectx echo.Context) error
+	UpdateClient(ectx echo.Context) error
+	PatchClient(ectx echo.Context) error
+	DeleteClient(ectx echo.Context) error
+	RestoreClient(ectx echo.Context) error
+	GetDeletedClients(ectx echo.Context) error
 }
 
 type clientHandler struct {
@@ -41,12 +48,22 @@ func NewClientHandler(di *pkgs.Di) (ClientHandler, error) {
 // @Accept json
 // @Produce json
 // @Param payload body models.CreateClientPayload true "Dados do cliente"
+// @Param Idempotency-Key header string false "Chave para repetir a requisição com segurança; repetições com o mesmo corpo reproduzem a resposta original"
+// @Param X-Tenant-ID header string false "Tenant da requisição, obrigatório para credenciais com o escopo platform"
 // @Success 201 {object} models.ClientResponse
-// @Failure 400 {object} nil
-// @Failure 500 {object} nil
+// @Header 201 {string} ETag "Versão do cliente criado"
+// @Failure 400 {object} models.ProblemDetails "Erro de validação ou payload inválido"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário ou sem acesso ao tenant"
+// @Failure 409 {object} models.ProblemDetails "Requisição com a mesma Idempotency-Key ainda em andamento"
+// @Failure 422 {object} models.ProblemDetails "Idempotency-Key reutilizada com outro corpo"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao criar cliente"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /clients [post]
 func (c *clientHandler) CreateClient(ectx echo.Context) error {
-	logger := slog.With(
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
 		slog.String("handler", "client"),
 		slog.String("method", "CreateClient"),
 	)
@@ -54,7 +71,12 @@ func (c *clientHandler) CreateClient(ectx echo.Context) error {
 	var payload models.CreateClientPayload
 	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
 		logger.Error("error to bind payload", "error", err)
-		return ectx.NoContent(http.StatusBadRequest)
+		return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	if err := ectx.Validate(&payload); err != nil {
+		logger.Warn("invalid payload", "error", err)
+		return err
 	}
 
 	contacts := models.ToContacts(payload.Contacts)
@@ -62,33 +84,64 @@ func (c *clientHandler) CreateClient(ectx echo.Context) error {
 	response, err := c.cs.CreateClient(ectx.Request().Context(), payload.Name, contacts)
 	if err != nil {
 		logger.Error("error to create client", "error", err)
-		return ectx.NoContent(http.StatusInternalServerError)
+		return err
 	}
 
+	setETag(ectx, response.Version)
 	return ectx.JSON(http.StatusCreated, response)
 }
 
 // GetClientsWithContact godoc
-// @Summary Lista todos os clientes com seus contatos
-// @Description Retorna uma lista de clientes com os respectivos contatos associados
+// @Summary Lista os clientes com seus contatos
+// @Description Retorna uma página de clientes com os respectivos contatos associados, paginada por cursor.
+// @Description Os links para as páginas seguinte e anterior também são enviados no header Link.
 // @Tags clients
 // @Produce json
-// @Success 200 {array} models.ClientResponse
-// @Failure 500 {object} nil "Erro interno ao buscar clientes"
+// @Param cursor query string false "Cursor opaco retornado em meta.nextCursor ou meta.prevCursor"
+// @Param limit query int false "Quantidade de clientes por página (padrão 20, máximo 100)"
+// @Param sort query string false "Ordenação" Enums(name, -name, created_at, -created_at)
+// @Param name query string false "Prefixo do nome do cliente"
+// @Param createdFrom query string false "Data de criação inicial (RFC 3339, inclusiva)"
+// @Param createdTo query string false "Data de criação final (RFC 3339, exclusiva)"
+// @Param contactEmail query string false "E-mail de um contato do cliente"
+// @Param contactPhone query string false "Telefone de um contato do cliente, com ou sem separadores"
+// @Param X-Tenant-ID header string false "Tenant da requisição, obrigatório para credenciais com o escopo platform"
+// @Success 200 {object} models.ClientPageResponse
+// @Header 200 {string} Link "Links para as páginas seguinte (rel=next) e anterior (rel=prev)"
+// @Failure 400 {object} models.ProblemDetails "Parâmetros de busca inválidos"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário ou sem acesso ao tenant"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao buscar clientes"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /clients [get]
 func (c *clientHandler) GetClientsWithContact(ectx echo.Context) error {
-	logger := slog.With(
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
 		slog.String("handler", "client"),
 		slog.String("method", "GetClientsWithContact"),
 	)
 
-	clients, err := c.cs.GetClientsWithContact(ectx.Request().Context())
+	var query models.ListClientsQuery
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ectx, &query); err != nil {
+		logger.Warn("error to bind query", "error", err)
+		return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	if err := ectx.Validate(&query); err != nil {
+		logger.Warn("invalid query", "error", err)
+		return err
+	}
+
+	page, err := c.cs.GetClientsWithContact(ectx.Request().Context(), query)
 	if err != nil {
 		logger.Error("error to get clients with contact", "error", err)
-		return ectx.NoContent(http.StatusInternalServerError)
+		return err
 	}
 
-	return ectx.JSON(http.StatusOK, clients)
+	setPaginationLinks(ectx, page.Meta)
+
+	return ectx.JSON(http.StatusOK, page)
 }
 
 // GetClientContactsByID godoc
@@ -97,32 +150,335 @@ func (c *clientHandler) GetClientsWithContact(ectx echo.Context) error {
 // @Tags clients
 // @Produce json
 // @Param clientId path string true "ID do cliente"
+// @Param X-Tenant-ID header string false "Tenant da requisição, obrigatório para credenciais com o escopo platform"
 // @Success 200 {array} models.ContactResponse
-// @Failure 400 {object} nil "ID inválido ou ausente"
-// @Failure 404 {object} nil "Cliente não encontrado"
-// @Failure 500 {object} nil "Erro interno ao buscar contatos"
+// @Failure 400 {object} models.ProblemDetails "ID inválido ou ausente"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário ou sem acesso ao tenant"
+// @Failure 404 {object} models.ProblemDetails "Cliente não encontrado"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao buscar contatos"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /clients/{clientId}/contacts [get]
 func (c *clientHandler) GetClientContactsByID(ectx echo.Context) error {
-	logger := slog.With(
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
 		slog.String("handler", "client"),
 		slog.String("method", "GetClientContactsByID"),
 	)
 
-	id := ectx.Param("clientId")
-	if id == "" {
-		return ectx.NoContent(http.StatusBadRequest)
+	id, err := pathID(ectx, "clientId")
+	if err != nil {
+		return err
 	}
 
 	response, err := c.cs.GetClientContactsByID(ectx.Request().Context(), id)
 	if err != nil {
-		if err == models.ErrClientNotFound {
-			logger.Error("client not found", "error", err)
-			return ectx.NoContent(http.StatusNotFound)
-		}
-
 		logger.Error("error to get client contacts by id", "error", err)
-		return ectx.NoContent(http.StatusInternalServerError)
+		return err
+	}
+
+	return ectx.JSON(http.StatusOK, response)
+}
+
+// GetClient godoc
+// @Summary Busca um cliente
+// @Description Retorna um cliente, opcionalmente com seus contatos e apenas com os campos pedidos.
+// @Description Responde 304 quando o If-None-Match corresponde à versão atual.
+// @Tags clients
+// @Produce json
+// @Param clientId path string true "ID do cliente"
+// @Param include query string false "Relacionamentos a incluir" Enums(contacts)
+// @Param fields query string false "Campos do cliente separados por vírgula (id, name, version, created_at, updated_at, deleted_at)"
+// @Param If-None-Match header string false "ETag de uma representação já conhecida do cliente"
+// @Param X-Tenant-ID header string false "Tenant da requisição, obrigatório para credenciais com o escopo platform"
+// @Success 200 {object} models.ClientResponse
+// @Header 200 {string} ETag "Versão atual da representação; com fields ou include inclui um resumo da seleção e não serve para o If-Match"
+// @Success 304 "Cliente não foi alterado"
+// @Failure 400 {object} models.ProblemDetails "ID ou parâmetros include ou fields inválidos"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário ou sem acesso ao tenant"
+// @Failure 404 {object} models.ProblemDetails "Cliente não encontrado"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao buscar cliente"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /clients/{clientId} [get]
+func (c *clientHandler) GetClient(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "client"),
+		slog.String("method", "GetClient"),
+	)
+
+	id, err := pathID(ectx, "clientId")
+	if err != nil {
+		return err
+	}
+
+	var query models.GetClientQuery
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ectx, &query); err != nil {
+		logger.Warn("error to bind query", "error", err)
+		return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	if err := ectx.Validate(&query); err != nil {
+		logger.Warn("invalid query", "error", err)
+		return err
+	}
+
+	fields, err := query.SelectedFields()
+	if err != nil {
+		logger.Warn("invalid query", "error", err)
+		return err
+	}
+
+	response, err := c.cs.GetClient(ectx.Request().Context(), id, query.IncludeContacts())
+	if err != nil {
+		logger.Error("error to get client", "error", err)
+		return err
+	}
+
+	etag := representationETag(response.Version, fields)
+	ectx.Response().Header().Set(headerETag, etag)
+	if notModified(ectx.Request(), etag) {
+		return ectx.NoContent(http.StatusNotModified)
+	}
+
+	sparse, err := pkgs.SelectFields(response, fields)
+	if err != nil {
+		logger.Error("error to select client fields", "error", err)
+		return err
+	}
+
+	return ectx.JSON(http.StatusOK, sparse)
+}
+
+// UpdateClient godoc
+// @Summary Atualiza um cliente
+// @Description Substitui os dados de um cliente existente
+// @Tags clients
+// @Accept json
+// @Produce json
+// @Param clientId path string true "ID do cliente"
+// @Param If-Match header string true "ETag da versão lida do cliente, ou * para qualquer versão"
+// @Param payload body models.UpdateClientPayload true "Dados do cliente"
+// @Param X-Tenant-ID header string false "Tenant da requisição, obrigatório para credenciais com o escopo platform"
+// @Success 200 {object} models.ClientResponse
+// @Header 200 {string} ETag "Nova versão do cliente"
+// @Failure 400 {object} models.ProblemDetails "Erro de validação ou payload inválido"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário ou sem acesso ao tenant"
+// @Failure 404 {object} models.ProblemDetails "Cliente não encontrado"
+// @Failure 412 {object} models.ProblemDetails "Cliente alterado desde a leitura"
+// @Failure 428 {object} models.ProblemDetails "Header If-Match ausente"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao atualizar cliente"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /clients/{clientId} [put]
+func (c *clientHandler) UpdateClient(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "client"),
+		slog.String("method", "UpdateClient"),
+	)
+
+	id, err := pathID(ectx, "clientId")
+	if err != nil {
+		return err
+	}
+
+	version, err := ifMatchVersion(ectx.Request())
+	if err != nil {
+		return err
+	}
+
+	var payload models.UpdateClientPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	if err := ectx.Validate(&payload); err != nil {
+		logger.Warn("invalid payload", "error", err)
+		return err
+	}
+
+	response, err := c.cs.UpdateClient(ectx.Request().Context(), id, version, payload.Name)
+	if err != nil {
+		logger.Error("error to update client", "error", err)
+		return err
 	}
 
+	setETag(ectx, response.Version)
 	return ectx.JSON(http.StatusOK, response)
 }
+
+// PatchClient godoc
+// @Summary Atualiza parcialmente um cliente
+// @Description Aplica um JSON Merge Patch (RFC 7396) sobre os dados de um cliente existente
+// @Tags clients
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param clientId path string true "ID do cliente"
+// @Param If-Match header string true "ETag da versão lida do cliente, ou * para qualquer versão"
+// @Param payload body models.UpdateClientPayload true "Campos a serem alterados"
+// @Param X-Tenant-ID header string false "Tenant da requisição, obrigatório para credenciais com o escopo platform"
+// @Success 200 {object} models.ClientResponse
+// @Header 200 {string} ETag "Nova versão do cliente"
+// @Failure 400 {object} models.ProblemDetails "Erro de validação ou payload inválido"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário ou sem acesso ao tenant"
+// @Failure 404 {object} models.ProblemDetails "Cliente não encontrado"
+// @Failure 412 {object} models.ProblemDetails "Cliente alterado desde a leitura"
+// @Failure 415 {object} models.ProblemDetails "Content-Type não suportado"
+// @Failure 428 {object} models.ProblemDetails "Header If-Match ausente"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao atualizar cliente"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /clients/{clientId} [patch]
+func (c *clientHandler) PatchClient(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "client"),
+		slog.String("method", "PatchClient"),
+	)
+
+	id, err := pathID(ectx, "clientId")
+	if err != nil {
+		return err
+	}
+
+	if !isMergePatch(ectx.Request()) {
+		return echo.ErrUnsupportedMediaType
+	}
+
+	version, err := ifMatchVersion(ectx.Request())
+	if err != nil {
+		return err
+	}
+
+	patch, err := io.ReadAll(ectx.Request().Body)
+	if err != nil {
+		logger.Error("error to read payload", "error", err)
+		return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	response, err := c.cs.PatchClient(ectx.Request().Context(), id, version, patch)
+	if err != nil {
+		logger.Error("error to patch client", "error", err)
+		return err
+	}
+
+	setETag(ectx, response.Version)
+	return ectx.JSON(http.StatusOK, response)
+}
+
+// DeleteClient godoc
+// @Summary Remove um cliente
+// @Description Remove logicamente um cliente e seus contatos, que podem ser restaurados durante o período de retenção
+// @Tags clients
+// @Param clientId path string true "ID do cliente"
+// @Param If-Match header string true "ETag da versão lida do cliente, ou * para qualquer versão"
+// @Param X-Tenant-ID header string false "Tenant da requisição, obrigatório para credenciais com o escopo platform"
+// @Success 204
+// @Failure 400 {object} models.ProblemDetails "ID inválido"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário ou sem acesso ao tenant"
+// @Failure 404 {object} models.ProblemDetails "Cliente não encontrado"
+// @Failure 412 {object} models.ProblemDetails "Cliente alterado desde a leitura"
+// @Failure 428 {object} models.ProblemDetails "Header If-Match ausente"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao remover cliente"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /clients/{clientId} [delete]
+func (c *clientHandler) DeleteClient(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "client"),
+		slog.String("method", "DeleteClient"),
+	)
+
+	id, err := pathID(ectx, "clientId")
+	if err != nil {
+		return err
+	}
+
+	version, err := ifMatchVersion(ectx.Request())
+	if err != nil {
+		return err
+	}
+
+	if err := c.cs.DeleteClient(ectx.Request().Context(), id, version); err != nil {
+		logger.Error("error to delete client", "error", err)
+		return err
+	}
+
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+// RestoreClient godoc
+// @Summary Restaura um cliente removido
+// @Description Restaura um cliente removido logicamente junto com os contatos removidos com ele
+// @Tags clients
+// @Produce json
+// @Param clientId path string true "ID do cliente"
+// @Param X-Tenant-ID header string false "Tenant da requisição, obrigatório para credenciais com o escopo platform"
+// @Success 200 {object} models.ClientResponse
+// @Failure 400 {object} models.ProblemDetails "ID inválido"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário ou sem acesso ao tenant"
+// @Failure 404 {object} models.ProblemDetails "Cliente removido não encontrado"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao restaurar cliente"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /clients/{clientId}/restore [post]
+func (c *clientHandler) RestoreClient(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "client"),
+		slog.String("method", "RestoreClient"),
+	)
+
+	id, err := pathID(ectx, "clientId")
+	if err != nil {
+		return err
+	}
+
+	response, err := c.cs.RestoreClient(ectx.Request().Context(), id)
+	if err != nil {
+		logger.Error("error to restore client", "error", err)
+		return err
+	}
+
+	setETag(ectx, response.Version)
+	return ectx.JSON(http.StatusOK, response)
+}
+
+// GetDeletedClients godoc
+// @Summary Lista os clientes removidos
+// @Description Retorna os clientes removidos logicamente que ainda não foram expurgados
+// @Tags clients
+// @Produce json
+// @Param X-Tenant-ID header string false "Tenant da requisição, obrigatório para credenciais com o escopo platform"
+// @Success 200 {array} models.ClientResponse
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário ou sem acesso ao tenant"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao buscar clientes removidos"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /clients/deleted [get]
+func (c *clientHandler) GetDeletedClients(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "client"),
+		slog.String("method", "GetDeletedClients"),
+	)
+
+	clients, err := c.cs.GetDeletedClients(ectx.Request().Context())
+	if err != nil {
+		logger.Error("error to get deleted clients", "error", err)
+		return err
+	}
+
+	return ectx.JSON(http.StatusOK, clients)
+}
@@ -11,6 +11,8 @@ import (
 
 	"github.com/g-villarinho/nubank-challenge/mocks"
 	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/services"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -18,6 +20,7 @@ import (
 
 func TestClientHandler_CreateClient(t *testing.T) {
 	e := echo.New()
+	e.Validator = pkgs.NewValidator()
 	ctx := context.Background()
 
 	t.Run("should create client successfully", func(t *testing.T) {
@@ -44,7 +47,7 @@ func TestClientHandler_CreateClient(t *testing.T) {
 		clientService.
 			On("CreateClient", ctx, "Gabriel", mock.Anything).
 			Return(&models.ClientResponse{
-				ID:        "client-123",
+				ID:        "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f",
 				Name:      "Gabriel",
 				CreatedAt: time.Now(),
 				Contacts: []*models.ContactResponse{
@@ -69,8 +72,84 @@ func TestClientHandler_CreateClient(t *testing.T) {
 
 		err := handler.CreateClient(c)
 
+		assert.ErrorIs(t, err, models.ErrInvalidPayload)
+	})
+
+	t.Run("should return 400 listing every invalid field", func(t *testing.T) {
+		handler := &clientHandler{}
+
+		payload := `{
+			"name": "",
+			"contacts": [
+				{
+					"phone": "21999999999",
+					"email": "gabriel"
+				}
+			]
+		}`
+
+		req := httptest.NewRequest(http.MethodPost, "/clients", bytes.NewBuffer([]byte(payload)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := handler.CreateClient(c)
+
+		var validationErr *models.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []models.FieldError{
+			{Field: "name", Message: "is required"},
+			{Field: "contacts[0].phone", Message: "must be a valid E.164 phone number"},
+			{Field: "contacts[0].email", Message: "must be a valid email"},
+		}, validationErr.Fields)
+	})
+
+	t.Run("should return 400 when contacts is missing", func(t *testing.T) {
+		handler := &clientHandler{}
+
+		req := httptest.NewRequest(http.MethodPost, "/clients", bytes.NewBuffer([]byte(`{"name": "Gabriel"}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := handler.CreateClient(c)
+
+		var validationErr *models.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []models.FieldError{
+			{Field: "contacts", Message: "is required"},
+		}, validationErr.Fields)
+	})
+
+	t.Run("should not require clientId on nested contacts", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		payload := `{
+			"name": "Gabriel",
+			"contacts": [
+				{
+					"phone": "+5521999999999",
+					"email": "gabriel@gmail.com"
+				}
+			]
+		}`
+
+		req := httptest.NewRequest(http.MethodPost, "/clients", bytes.NewBuffer([]byte(payload)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetRequest(req.WithContext(ctx))
+
+		clientService.
+			On("CreateClient", ctx, "Gabriel", mock.Anything).
+			Return(&models.ClientResponse{ID: "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", Name: "Gabriel"}, nil)
+
+		err := handler.CreateClient(c)
+
 		assert.NoError(t, err)
-		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		clientService.AssertExpectations(t)
 	})
 
 	t.Run("should return 500 if service fails", func(t *testing.T) {
@@ -100,36 +179,39 @@ func TestClientHandler_CreateClient(t *testing.T) {
 
 		err := handler.CreateClient(c)
 
-		assert.NoError(t, err)
-		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.EqualError(t, err, "internal error")
 	})
 }
 
 func TestClientHandler_GetClientsWithContact(t *testing.T) {
 	e := echo.New()
+	e.Validator = pkgs.NewValidator()
 	ctx := context.Background()
 
-	t.Run("should return clients with contacts", func(t *testing.T) {
+	t.Run("should return a page of clients with contacts", func(t *testing.T) {
 		clientService := new(mocks.ClientServiceMock)
 		handler := &clientHandler{cs: clientService}
 
-		mockResponse := []models.ClientResponse{
-			{
-				ID:        "client-123",
-				Name:      "Gabriel",
-				CreatedAt: time.Now(),
-				Contacts: []*models.ContactResponse{
-					{
-						ID:    "contact-1",
-						Phone: "+5521999999999",
-						Email: "gabriel@gmail.com",
+		mockResponse := &models.ClientPageResponse{
+			Data: []models.ClientResponse{
+				{
+					ID:        "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f",
+					Name:      "Gabriel",
+					CreatedAt: time.Now(),
+					Contacts: []*models.ContactResponse{
+						{
+							ID:    "contact-1",
+							Phone: "+5521999999999",
+							Email: "gabriel@gmail.com",
+						},
 					},
 				},
 			},
+			Meta: models.PageMeta{Limit: 20},
 		}
 
 		clientService.
-			On("GetClientsWithContact", ctx).
+			On("GetClientsWithContact", ctx, models.ListClientsQuery{}).
 			Return(mockResponse, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/clients", nil)
@@ -141,15 +223,80 @@ func TestClientHandler_GetClientsWithContact(t *testing.T) {
 
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("Link"))
 		clientService.AssertExpectations(t)
 	})
 
+	t.Run("should bind query params and set relative pagination links", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		createdFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		clientService.
+			On("GetClientsWithContact", ctx, models.ListClientsQuery{
+				Cursor:      "current",
+				Limit:       10,
+				Sort:        "-name",
+				Name:        "Ga",
+				CreatedFrom: createdFrom,
+			}).
+			Return(&models.ClientPageResponse{
+				Data: []models.ClientResponse{},
+				Meta: models.PageMeta{Limit: 10, HasNext: true, HasPrev: true, NextCursor: "next", PrevCursor: "prev"},
+			}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/clients?cursor=current&limit=10&sort=-name&name=Ga&createdFrom=2025-01-01T00:00:00Z", nil)
+		req.Host = "attacker.example"
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetRequest(req.WithContext(ctx))
+
+		err := handler.GetClientsWithContact(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t,
+			`</clients?createdFrom=2025-01-01T00%3A00%3A00Z&cursor=next&limit=10&name=Ga&sort=-name>; rel="next", `+
+				`</clients?createdFrom=2025-01-01T00%3A00%3A00Z&cursor=prev&limit=10&name=Ga&sort=-name>; rel="prev"`,
+			rec.Header().Get("Link"))
+		clientService.AssertExpectations(t)
+	})
+
+	t.Run("should return validation error on invalid sort", func(t *testing.T) {
+		handler := &clientHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/clients?sort=email&limit=0", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := handler.GetClientsWithContact(c)
+
+		var validationErr *models.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []models.FieldError{
+			{Field: "sort", Message: "must be one of: name, -name, created_at, -created_at"},
+		}, validationErr.Fields)
+	})
+
+	t.Run("should return invalid payload on malformed query params", func(t *testing.T) {
+		handler := &clientHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/clients?limit=ten", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := handler.GetClientsWithContact(c)
+
+		assert.ErrorIs(t, err, models.ErrInvalidPayload)
+	})
+
 	t.Run("should return 500 if service fails", func(t *testing.T) {
 		clientService := new(mocks.ClientServiceMock)
 		handler := &clientHandler{cs: clientService}
 
 		clientService.
-			On("GetClientsWithContact", ctx).
+			On("GetClientsWithContact", ctx, mock.Anything).
 			Return(nil, errors.New("unexpected failure"))
 
 		req := httptest.NewRequest(http.MethodGet, "/clients", nil)
@@ -159,13 +306,13 @@ func TestClientHandler_GetClientsWithContact(t *testing.T) {
 
 		err := handler.GetClientsWithContact(c)
 
-		assert.NoError(t, err)
-		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.EqualError(t, err, "unexpected failure")
 	})
 }
 
 func TestClientHandler_GetClientContactsByID(t *testing.T) {
 	e := echo.New()
+	e.Validator = pkgs.NewValidator()
 	ctx := context.Background()
 
 	t.Run("should return contacts by client ID", func(t *testing.T) {
@@ -181,14 +328,14 @@ func TestClientHandler_GetClientContactsByID(t *testing.T) {
 		}
 
 		clientService.
-			On("GetClientContactsByID", ctx, "client-123").
+			On("GetClientContactsByID", ctx, "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f").
 			Return(mockContacts, nil)
 
-		req := httptest.NewRequest(http.MethodGet, "/clients/client-123/contacts", nil)
+		req := httptest.NewRequest(http.MethodGet, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f/contacts", nil)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 		c.SetParamNames("clientId")
-		c.SetParamValues("client-123")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
 		c.SetRequest(req.WithContext(ctx))
 
 		err := handler.GetClientContactsByID(c)
@@ -209,8 +356,7 @@ func TestClientHandler_GetClientContactsByID(t *testing.T) {
 
 		err := handler.GetClientContactsByID(c)
 
-		assert.NoError(t, err)
-		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.ErrorIs(t, err, models.ErrValidation)
 	})
 
 	t.Run("should return 404 if client not found", func(t *testing.T) {
@@ -218,20 +364,19 @@ func TestClientHandler_GetClientContactsByID(t *testing.T) {
 		handler := &clientHandler{cs: clientService}
 
 		clientService.
-			On("GetClientContactsByID", ctx, "missing-client").
+			On("GetClientContactsByID", ctx, "0e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a").
 			Return(nil, models.ErrClientNotFound)
 
-		req := httptest.NewRequest(http.MethodGet, "/clients/missing-client/contacts", nil)
+		req := httptest.NewRequest(http.MethodGet, "/clients/0e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a/contacts", nil)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 		c.SetParamNames("clientId")
-		c.SetParamValues("missing-client")
+		c.SetParamValues("0e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a")
 		c.SetRequest(req.WithContext(ctx))
 
 		err := handler.GetClientContactsByID(c)
 
-		assert.NoError(t, err)
-		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.ErrorIs(t, err, models.ErrClientNotFound)
 	})
 
 	t.Run("should return 500 if service fails", func(t *testing.T) {
@@ -239,19 +384,648 @@ func TestClientHandler_GetClientContactsByID(t *testing.T) {
 		handler := &clientHandler{cs: clientService}
 
 		clientService.
-			On("GetClientContactsByID", ctx, "client-123").
+			On("GetClientContactsByID", ctx, "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f").
 			Return(nil, errors.New("unexpected failure"))
 
-		req := httptest.NewRequest(http.MethodGet, "/clients/client-123/contacts", nil)
+		req := httptest.NewRequest(http.MethodGet, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f/contacts", nil)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 		c.SetParamNames("clientId")
-		c.SetParamValues("client-123")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
 		c.SetRequest(req.WithContext(ctx))
 
 		err := handler.GetClientContactsByID(c)
 
+		assert.EqualError(t, err, "unexpected failure")
+	})
+}
+
+func TestClientHandler_GetClient(t *testing.T) {
+	e := echo.New()
+	e.Validator = pkgs.NewValidator()
+	ctx := context.Background()
+
+	t.Run("should return client with its ETag", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodGet, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+		c.SetRequest(req.WithContext(ctx))
+
+		clientService.
+			On("GetClient", ctx, "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", false).
+			Return(&models.ClientResponse{ID: "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", Name: "Gabriel", Version: 3}, nil)
+
+		err := handler.GetClient(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+		assert.NotContains(t, rec.Body.String(), "contacts")
+	})
+
+	t.Run("should return 400 if clientId is not a uuid", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodGet, "/clients/abc", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("abc")
+
+		err := handler.GetClient(c)
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+		clientService.AssertNotCalled(t, "GetClient", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should include contacts when requested", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodGet, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f?include=contacts", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+		c.SetRequest(req.WithContext(ctx))
+
+		clientService.
+			On("GetClient", ctx, "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", true).
+			Return(&models.ClientResponse{
+				ID:       "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f",
+				Name:     "Gabriel",
+				Version:  3,
+				Contacts: []*models.ContactResponse{{ID: "contact-1", Email: "gabriel@gmail.com"}},
+			}, nil)
+
+		err := handler.GetClient(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"contacts":[{`)
+		clientService.AssertExpectations(t)
+	})
+
+	t.Run("should return only the requested fields", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodGet, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f?fields=id,name", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+		c.SetRequest(req.WithContext(ctx))
+
+		clientService.
+			On("GetClient", ctx, "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", false).
+			Return(&models.ClientResponse{ID: "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", Name: "Gabriel", Version: 3, CreatedAt: time.Now()}, nil)
+
+		err := handler.GetClient(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"id":"6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f","name":"Gabriel"}`, rec.Body.String())
+		assert.Regexp(t, `^"3-[0-9a-f]{8}"$`, rec.Header().Get("ETag"))
+	})
+
+	t.Run("should not revalidate a partial representation with the full ETag", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodGet, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f?fields=id,name", nil)
+		req.Header.Set("If-None-Match", `"3"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+		c.SetRequest(req.WithContext(ctx))
+
+		clientService.
+			On("GetClient", ctx, "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", false).
+			Return(&models.ClientResponse{ID: "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", Name: "Gabriel", Version: 3}, nil)
+
+		err := handler.GetClient(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotEqual(t, `"3"`, rec.Header().Get("ETag"))
+	})
+
+	t.Run("should give the same ETag to the same selection in any order", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		clientService.
+			On("GetClient", ctx, "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", true).
+			Return(&models.ClientResponse{ID: "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", Name: "Gabriel", Version: 3}, nil)
+
+		etags := make([]string, 0, 2)
+		for _, query := range []string{"fields=id,name&include=contacts", "include=contacts&fields=name,id"} {
+			req := httptest.NewRequest(http.MethodGet, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f?"+query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("clientId")
+			c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+			c.SetRequest(req.WithContext(ctx))
+
+			assert.NoError(t, handler.GetClient(c))
+			etags = append(etags, rec.Header().Get("ETag"))
+		}
+
+		assert.Equal(t, etags[0], etags[1])
+	})
+
+	t.Run("should return validation error on unknown field", func(t *testing.T) {
+		handler := &clientHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f?fields=id,password", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+
+		err := handler.GetClient(c)
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+	})
+
+	t.Run("should return validation error on unknown include", func(t *testing.T) {
+		handler := &clientHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f?include=addresses", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+
+		err := handler.GetClient(c)
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+	})
+
+	t.Run("should return 304 when If-None-Match matches the current version", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodGet, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", nil)
+		req.Header.Set("If-None-Match", `"2", W/"3"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+		c.SetRequest(req.WithContext(ctx))
+
+		clientService.
+			On("GetClient", ctx, "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", false).
+			Return(&models.ClientResponse{ID: "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", Name: "Gabriel", Version: 3}, nil)
+
+		err := handler.GetClient(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("should return client when If-None-Match is stale", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodGet, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", nil)
+		req.Header.Set("If-None-Match", `"2"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+		c.SetRequest(req.WithContext(ctx))
+
+		clientService.
+			On("GetClient", ctx, "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", false).
+			Return(&models.ClientResponse{ID: "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", Name: "Gabriel", Version: 3}, nil)
+
+		err := handler.GetClient(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("should return 404 if client not found", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodGet, "/clients/0e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("0e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a")
+		c.SetRequest(req.WithContext(ctx))
+
+		clientService.On("GetClient", ctx, "0e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a", false).Return(nil, models.ErrClientNotFound)
+
+		err := handler.GetClient(c)
+
+		assert.ErrorIs(t, err, models.ErrClientNotFound)
+	})
+}
+
+func TestClientHandler_UpdateClient(t *testing.T) {
+	e := echo.New()
+	e.Validator = pkgs.NewValidator()
+	ctx := context.Background()
+
+	t.Run("should update client successfully", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodPut, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", bytes.NewBuffer([]byte(`{"name": "Gabriel Villarinho"}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", `"2"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+		c.SetRequest(req.WithContext(ctx))
+
+		clientService.
+			On("UpdateClient", ctx, "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", int64(2), "Gabriel Villarinho").
+			Return(&models.ClientResponse{ID: "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", Name: "Gabriel Villarinho", Version: 3}, nil)
+
+		err := handler.UpdateClient(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+		clientService.AssertExpectations(t)
+	})
+
+	t.Run("should accept any version with If-Match *", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodPut, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", bytes.NewBuffer([]byte(`{"name": "Gabriel Villarinho"}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", "*")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+		c.SetRequest(req.WithContext(ctx))
+
+		clientService.
+			On("UpdateClient", ctx, "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", services.AnyVersion, "Gabriel Villarinho").
+			Return(&models.ClientResponse{ID: "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", Name: "Gabriel Villarinho", Version: 3}, nil)
+
+		err := handler.UpdateClient(c)
+
+		assert.NoError(t, err)
+		clientService.AssertExpectations(t)
+	})
+
+	t.Run("should require If-Match", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodPut, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", bytes.NewBuffer([]byte(`{"name": "Gabriel"}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+
+		err := handler.UpdateClient(c)
+
+		assert.ErrorIs(t, err, models.ErrPreconditionRequired)
+		clientService.AssertNotCalled(t, "UpdateClient", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should fail the precondition with a weak ETag", func(t *testing.T) {
+		handler := &clientHandler{}
+
+		req := httptest.NewRequest(http.MethodPut, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", bytes.NewBuffer([]byte(`{"name": "Gabriel"}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", `W/"2"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+
+		err := handler.UpdateClient(c)
+
+		assert.ErrorIs(t, err, models.ErrPreconditionFailed)
+	})
+
+	t.Run("should return validation error when name is missing", func(t *testing.T) {
+		handler := &clientHandler{}
+
+		req := httptest.NewRequest(http.MethodPut, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", bytes.NewBuffer([]byte(`{}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+
+		err := handler.UpdateClient(c)
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+	})
+
+	t.Run("should return 404 if client not found", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodPut, "/clients/0e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a", bytes.NewBuffer([]byte(`{"name": "Gabriel"}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("0e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a")
+		c.SetRequest(req.WithContext(ctx))
+
+		clientService.
+			On("UpdateClient", ctx, "0e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a", int64(1), "Gabriel").
+			Return(nil, models.ErrClientNotFound)
+
+		err := handler.UpdateClient(c)
+
+		assert.ErrorIs(t, err, models.ErrClientNotFound)
+	})
+}
+
+func TestClientHandler_PatchClient(t *testing.T) {
+	e := echo.New()
+	ctx := context.Background()
+
+	t.Run("should patch client successfully", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodPatch, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", bytes.NewBuffer([]byte(`{"name": "Caio"}`)))
+		req.Header.Set(echo.HeaderContentType, pkgs.MIMEApplicationMergePatchJSON)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+		c.SetRequest(req.WithContext(ctx))
+
+		clientService.
+			On("PatchClient", ctx, "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", int64(1), []byte(`{"name": "Caio"}`)).
+			Return(&models.ClientResponse{ID: "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", Name: "Caio", Version: 2}, nil)
+
+		err := handler.PatchClient(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+		clientService.AssertExpectations(t)
+	})
+
+	t.Run("should reject unsupported content type", func(t *testing.T) {
+		handler := &clientHandler{}
+
+		req := httptest.NewRequest(http.MethodPatch, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", bytes.NewBuffer([]byte(`name=Caio`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+
+		err := handler.PatchClient(c)
+
+		assert.ErrorIs(t, err, echo.ErrUnsupportedMediaType)
+	})
+
+	t.Run("should require If-Match", func(t *testing.T) {
+		handler := &clientHandler{}
+
+		req := httptest.NewRequest(http.MethodPatch, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", bytes.NewBuffer([]byte(`{"name": "Caio"}`)))
+		req.Header.Set(echo.HeaderContentType, pkgs.MIMEApplicationMergePatchJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+
+		err := handler.PatchClient(c)
+
+		assert.ErrorIs(t, err, models.ErrPreconditionRequired)
+	})
+
+	t.Run("should return 412 when the client changed since it was read", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodPatch, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", bytes.NewBuffer([]byte(`{"name": "Caio"}`)))
+		req.Header.Set(echo.HeaderContentType, pkgs.MIMEApplicationMergePatchJSON)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+		c.SetRequest(req.WithContext(ctx))
+
+		clientService.
+			On("PatchClient", ctx, "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", int64(1), mock.Anything).
+			Return(nil, models.ErrPreconditionFailed)
+
+		err := handler.PatchClient(c)
+
+		assert.ErrorIs(t, err, models.ErrPreconditionFailed)
+	})
+
+	t.Run("should return 404 if client not found", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodPatch, "/clients/0e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a", bytes.NewBuffer([]byte(`{"name": "Caio"}`)))
+		req.Header.Set(echo.HeaderContentType, pkgs.MIMEApplicationMergePatchJSON)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("0e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a")
+		c.SetRequest(req.WithContext(ctx))
+
+		clientService.
+			On("PatchClient", ctx, "0e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a", int64(1), mock.Anything).
+			Return(nil, models.ErrClientNotFound)
+
+		err := handler.PatchClient(c)
+
+		assert.ErrorIs(t, err, models.ErrClientNotFound)
+	})
+}
+
+func TestClientHandler_DeleteClient(t *testing.T) {
+	e := echo.New()
+	ctx := context.Background()
+
+	t.Run("should delete client successfully", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodDelete, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", nil)
+		req.Header.Set("If-Match", `"4"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+		c.SetRequest(req.WithContext(ctx))
+
+		clientService.On("DeleteClient", ctx, "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", int64(4)).Return(nil)
+
+		err := handler.DeleteClient(c)
+
 		assert.NoError(t, err)
-		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		clientService.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 if clientId is not a uuid", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodDelete, "/clients/abc", nil)
+		req.Header.Set("If-Match", "*")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("abc")
+
+		err := handler.DeleteClient(c)
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+		clientService.AssertNotCalled(t, "DeleteClient", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should require If-Match", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodDelete, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+
+		err := handler.DeleteClient(c)
+
+		assert.ErrorIs(t, err, models.ErrPreconditionRequired)
+		clientService.AssertNotCalled(t, "DeleteClient", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return 404 if client not found", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodDelete, "/clients/0e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a", nil)
+		req.Header.Set("If-Match", "*")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("0e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a")
+		c.SetRequest(req.WithContext(ctx))
+
+		clientService.On("DeleteClient", ctx, "0e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a", services.AnyVersion).Return(models.ErrClientNotFound)
+
+		err := handler.DeleteClient(c)
+
+		assert.ErrorIs(t, err, models.ErrClientNotFound)
+	})
+}
+
+func TestClientHandler_RestoreClient(t *testing.T) {
+	e := echo.New()
+	ctx := context.Background()
+
+	t.Run("should restore client successfully", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodPost, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f/restore", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+		c.SetRequest(req.WithContext(ctx))
+
+		clientService.
+			On("RestoreClient", ctx, "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f").
+			Return(&models.ClientResponse{ID: "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", Name: "Gabriel"}, nil)
+
+		err := handler.RestoreClient(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		clientService.AssertExpectations(t)
+	})
+
+	t.Run("should return 404 if deleted client not found", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		req := httptest.NewRequest(http.MethodPost, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f/restore", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("clientId")
+		c.SetParamValues("6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f")
+		c.SetRequest(req.WithContext(ctx))
+
+		clientService.On("RestoreClient", ctx, "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f").Return(nil, models.ErrClientNotFound)
+
+		err := handler.RestoreClient(c)
+
+		assert.ErrorIs(t, err, models.ErrClientNotFound)
+	})
+}
+
+func TestClientHandler_GetDeletedClients(t *testing.T) {
+	e := echo.New()
+	ctx := context.Background()
+
+	t.Run("should return deleted clients", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		deletedAt := time.Now()
+		clientService.
+			On("GetDeletedClients", ctx).
+			Return([]models.ClientResponse{{ID: "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", Name: "Gabriel", DeletedAt: &deletedAt}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/clients/deleted", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetRequest(req.WithContext(ctx))
+
+		err := handler.GetDeletedClients(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "deleted_at")
+	})
+
+	t.Run("should return error if service fails", func(t *testing.T) {
+		clientService := new(mocks.ClientServiceMock)
+		handler := &clientHandler{cs: clientService}
+
+		clientService.On("GetDeletedClients", ctx).Return(nil, errors.New("unexpected failure"))
+
+		req := httptest.NewRequest(http.MethodGet, "/clients/deleted", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetRequest(req.WithContext(ctx))
+
+		err := handler.GetDeletedClients(c)
+
+		assert.EqualError(t, err, "unexpected failure")
 	})
 }
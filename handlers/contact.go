@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 
@@ -14,6 +15,12 @@ import (
 
 type ContactHandler interface {
 	CreateContact(ectx echo.Context) error
+	GetContact(ectx echo.Context) error
+	UpdateContact(ectx echo.Context) error
+	PatchContact(ectx echo.Context) error
+	DeleteContact(ectx echo.Context) error
+	TransferContact(ectx echo.Context) error
+	SearchContacts(ectx echo.Context) error
 }
 
 type contactHandler struct {
@@ -40,13 +47,23 @@ func NewContactHandler(di *pkgs.Di) (ContactHandler, error) {
 // @Accept json
 // @Produce json
 // @Param payload body models.CreateContactPayload true "Dados do contato"
+// @Param Idempotency-Key header string false "Chave para repetir a requisição com segurança; repetições com o mesmo corpo reproduzem a resposta original"
+// @Param X-Tenant-ID header string false "Tenant da requisição, obrigatório para credenciais com o escopo platform"
 // @Success 201 {object} models.ContactResponse
-// @Failure 400 {object} nil "Erro de validação ou payload inválido"
-// @Failure 404 {object} nil "Cliente não encontrado"
-// @Failure 500 {object} nil "Erro interno ao criar contato"
+// @Header 201 {string} ETag "Versão do contato criado"
+// @Failure 400 {object} models.ProblemDetails "Erro de validação ou payload inválido"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário ou sem acesso ao tenant"
+// @Failure 404 {object} models.ProblemDetails "Cliente não encontrado"
+// @Failure 409 {object} models.ProblemDetails "Requisição com a mesma Idempotency-Key ainda em andamento"
+// @Failure 422 {object} models.ProblemDetails "Idempotency-Key reutilizada com outro corpo"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao criar contato"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /contacts [post]
 func (c *contactHandler) CreateContact(ectx echo.Context) error {
-	logger := slog.With(
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
 		slog.String("handler", "contact"),
 		slog.String("method", "CreateContact"),
 	)
@@ -54,17 +71,321 @@ func (c *contactHandler) CreateContact(ectx echo.Context) error {
 	var payload models.CreateContactPayload
 	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
 		logger.Error("decode payload", slog.Any("error", err))
-		return ectx.NoContent(http.StatusBadRequest)
+		return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	if err := ectx.Validate(&payload); err != nil {
+		logger.Warn("invalid payload", slog.Any("error", err))
+		return err
 	}
 
 	response, err := c.cs.CreateContact(ectx.Request().Context(), payload.Phone, payload.Email, payload.ClientID)
 	if err != nil {
 		logger.Error("create contact", slog.Any("error", err))
-		if err == models.ErrClientNotFound {
-			return ectx.NoContent(http.StatusNotFound)
-		}
-		return ectx.NoContent(http.StatusInternalServerError)
+		return err
 	}
 
+	setETag(ectx, response.Version)
 	return ectx.JSON(http.StatusCreated, response)
 }
+
+// GetContact godoc
+// @Summary Busca um contato
+// @Tags contacts
+// @Produce json
+// @Param contactId path string true "ID do contato"
+// @Param If-None-Match header string false "ETag de uma versão já conhecida do contato"
+// @Param X-Tenant-ID header string false "Tenant da requisição, obrigatório para credenciais com o escopo platform"
+// @Success 200 {object} models.ContactResponse
+// @Header 200 {string} ETag "Versão atual do contato"
+// @Success 304 "Contato não foi alterado"
+// @Failure 400 {object} models.ProblemDetails "ID inválido"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário ou sem acesso ao tenant"
+// @Failure 404 {object} models.ProblemDetails "Contato ou cliente não encontrado"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao buscar contato"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /contacts/{contactId} [get]
+func (c *contactHandler) GetContact(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "contact"),
+		slog.String("method", "GetContact"),
+	)
+
+	id, err := pathID(ectx, "contactId")
+	if err != nil {
+		return err
+	}
+
+	response, err := c.cs.GetContactByID(ectx.Request().Context(), id)
+	if err != nil {
+		logger.Error("get contact", slog.Any("error", err))
+		return err
+	}
+
+	setETag(ectx, response.Version)
+	if notModified(ectx.Request(), versionETag(response.Version)) {
+		return ectx.NoContent(http.StatusNotModified)
+	}
+
+	return ectx.JSON(http.StatusOK, response)
+}
+
+// UpdateContact godoc
+// @Summary Atualiza um contato
+// @Description Substitui o telefone e o e-mail de um contato existente
+// @Tags contacts
+// @Accept json
+// @Produce json
+// @Param contactId path string true "ID do contato"
+// @Param If-Match header string true "ETag da versão lida do contato, ou * para qualquer versão"
+// @Param payload body models.UpdateContactPayload true "Dados do contato"
+// @Param X-Tenant-ID header string false "Tenant da requisição, obrigatório para credenciais com o escopo platform"
+// @Success 200 {object} models.ContactResponse
+// @Header 200 {string} ETag "Nova versão do contato"
+// @Failure 400 {object} models.ProblemDetails "Erro de validação ou payload inválido"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário ou sem acesso ao tenant"
+// @Failure 404 {object} models.ProblemDetails "Contato ou cliente não encontrado"
+// @Failure 412 {object} models.ProblemDetails "Contato alterado desde a leitura"
+// @Failure 428 {object} models.ProblemDetails "Header If-Match ausente"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao atualizar contato"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /contacts/{contactId} [put]
+func (c *contactHandler) UpdateContact(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "contact"),
+		slog.String("method", "UpdateContact"),
+	)
+
+	id, err := pathID(ectx, "contactId")
+	if err != nil {
+		return err
+	}
+
+	version, err := ifMatchVersion(ectx.Request())
+	if err != nil {
+		return err
+	}
+
+	var payload models.UpdateContactPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("decode payload", slog.Any("error", err))
+		return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	if err := ectx.Validate(&payload); err != nil {
+		logger.Warn("invalid payload", slog.Any("error", err))
+		return err
+	}
+
+	response, err := c.cs.UpdateContact(ectx.Request().Context(), id, version, payload.Phone, payload.Email)
+	if err != nil {
+		logger.Error("update contact", slog.Any("error", err))
+		return err
+	}
+
+	setETag(ectx, response.Version)
+	return ectx.JSON(http.StatusOK, response)
+}
+
+// PatchContact godoc
+// @Summary Atualiza parcialmente um contato
+// @Description Aplica um JSON Merge Patch (RFC 7396) sobre o telefone e o e-mail de um contato
+// @Tags contacts
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param contactId path string true "ID do contato"
+// @Param If-Match header string true "ETag da versão lida do contato, ou * para qualquer versão"
+// @Param payload body models.UpdateContactPayload true "Campos a serem alterados"
+// @Param X-Tenant-ID header string false "Tenant da requisição, obrigatório para credenciais com o escopo platform"
+// @Success 200 {object} models.ContactResponse
+// @Header 200 {string} ETag "Nova versão do contato"
+// @Failure 400 {object} models.ProblemDetails "Erro de validação ou payload inválido"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário ou sem acesso ao tenant"
+// @Failure 404 {object} models.ProblemDetails "Contato ou cliente não encontrado"
+// @Failure 412 {object} models.ProblemDetails "Contato alterado desde a leitura"
+// @Failure 415 {object} models.ProblemDetails "Content-Type não suportado"
+// @Failure 428 {object} models.ProblemDetails "Header If-Match ausente"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao atualizar contato"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /contacts/{contactId} [patch]
+func (c *contactHandler) PatchContact(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "contact"),
+		slog.String("method", "PatchContact"),
+	)
+
+	id, err := pathID(ectx, "contactId")
+	if err != nil {
+		return err
+	}
+
+	if !isMergePatch(ectx.Request()) {
+		return echo.ErrUnsupportedMediaType
+	}
+
+	version, err := ifMatchVersion(ectx.Request())
+	if err != nil {
+		return err
+	}
+
+	patch, err := io.ReadAll(ectx.Request().Body)
+	if err != nil {
+		logger.Error("read payload", slog.Any("error", err))
+		return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	response, err := c.cs.PatchContact(ectx.Request().Context(), id, version, patch)
+	if err != nil {
+		logger.Error("patch contact", slog.Any("error", err))
+		return err
+	}
+
+	setETag(ectx, response.Version)
+	return ectx.JSON(http.StatusOK, response)
+}
+
+// DeleteContact godoc
+// @Summary Remove um contato
+// @Tags contacts
+// @Param contactId path string true "ID do contato"
+// @Param If-Match header string true "ETag da versão lida do contato, ou * para qualquer versão"
+// @Param X-Tenant-ID header string false "Tenant da requisição, obrigatório para credenciais com o escopo platform"
+// @Success 204
+// @Failure 400 {object} models.ProblemDetails "ID inválido"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário ou sem acesso ao tenant"
+// @Failure 404 {object} models.ProblemDetails "Contato ou cliente não encontrado"
+// @Failure 412 {object} models.ProblemDetails "Contato alterado desde a leitura"
+// @Failure 428 {object} models.ProblemDetails "Header If-Match ausente"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao remover contato"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /contacts/{contactId} [delete]
+func (c *contactHandler) DeleteContact(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "contact"),
+		slog.String("method", "DeleteContact"),
+	)
+
+	id, err := pathID(ectx, "contactId")
+	if err != nil {
+		return err
+	}
+
+	version, err := ifMatchVersion(ectx.Request())
+	if err != nil {
+		return err
+	}
+
+	if err := c.cs.DeleteContact(ectx.Request().Context(), id, version); err != nil {
+		logger.Error("delete contact", slog.Any("error", err))
+		return err
+	}
+
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+// TransferContact godoc
+// @Summary Transfere um contato para outro cliente
+// @Description Move o contato para outro cliente existente
+// @Tags contacts
+// @Accept json
+// @Produce json
+// @Param contactId path string true "ID do contato"
+// @Param payload body models.TransferContactPayload true "Cliente de destino"
+// @Param X-Tenant-ID header string false "Tenant da requisição, obrigatório para credenciais com o escopo platform"
+// @Success 200 {object} models.ContactResponse
+// @Failure 400 {object} models.ProblemDetails "Erro de validação ou payload inválido"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário ou sem acesso ao tenant"
+// @Failure 404 {object} models.ProblemDetails "Contato ou cliente não encontrado"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao transferir contato"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /contacts/{contactId}/transfer [post]
+func (c *contactHandler) TransferContact(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "contact"),
+		slog.String("method", "TransferContact"),
+	)
+
+	id, err := pathID(ectx, "contactId")
+	if err != nil {
+		return err
+	}
+
+	var payload models.TransferContactPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("decode payload", slog.Any("error", err))
+		return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	if err := ectx.Validate(&payload); err != nil {
+		logger.Warn("invalid payload", slog.Any("error", err))
+		return err
+	}
+
+	response, err := c.cs.TransferContact(ectx.Request().Context(), id, payload.ClientID)
+	if err != nil {
+		logger.Error("transfer contact", slog.Any("error", err))
+		return err
+	}
+
+	setETag(ectx, response.Version)
+	return ectx.JSON(http.StatusOK, response)
+}
+
+// SearchContacts godoc
+// @Summary Busca contatos por e-mail ou telefone
+// @Description Busca reversa de contatos pelo e-mail e/ou telefone, ignorando maiúsculas, espaços e separadores.
+// @Description Quando os dois parâmetros são informados, o contato precisa corresponder a ambos.
+// @Tags contacts
+// @Produce json
+// @Param email query string false "E-mail do contato"
+// @Param phone query string false "Telefone do contato, com ou sem separadores"
+// @Param X-Tenant-ID header string false "Tenant da requisição, obrigatório para credenciais com o escopo platform"
+// @Success 200 {array} models.ContactResponse
+// @Failure 400 {object} models.ProblemDetails "Nenhum parâmetro de busca informado"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário ou sem acesso ao tenant"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao buscar contatos"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /contacts [get]
+func (c *contactHandler) SearchContacts(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "contact"),
+		slog.String("method", "SearchContacts"),
+	)
+
+	var query models.ContactLookupQuery
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ectx, &query); err != nil {
+		logger.Warn("bind query", slog.Any("error", err))
+		return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	if err := ectx.Validate(&query); err != nil {
+		logger.Warn("invalid query", slog.Any("error", err))
+		return err
+	}
+
+	response, err := c.cs.SearchContacts(ectx.Request().Context(), query.Email, query.Phone)
+	if err != nil {
+		logger.Error("search contacts", slog.Any("error", err))
+		return err
+	}
+
+	return ectx.JSON(http.StatusOK, response)
+}
@@ -11,12 +11,15 @@ import (
 
 	"github.com/g-villarinho/nubank-challenge/mocks"
 	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestCreateContactHandler(t *testing.T) {
 	e := echo.New()
+	e.Validator = pkgs.NewValidator()
 	ctx := context.Background()
 
 	t.Run("should create contact successfully", func(t *testing.T) {
@@ -29,7 +32,7 @@ func TestCreateContactHandler(t *testing.T) {
 		payload := `{
 			"phone": "+5521999999999",
 			"email": "gabriel@gmail.com",
-			"clientId": "client-123"
+			"clientId": "7a395834-0ed5-4954-8e1d-b63cd2fdb97a"
 		}`
 
 		req := httptest.NewRequest(http.MethodPost, "/contacts", bytes.NewBuffer([]byte(payload)))
@@ -39,9 +42,9 @@ func TestCreateContactHandler(t *testing.T) {
 		c.SetRequest(req.WithContext(ctx))
 
 		contactService.
-			On("CreateContact", ctx, "+5521999999999", "gabriel@gmail.com", "client-123").
+			On("CreateContact", ctx, "+5521999999999", "gabriel@gmail.com", "7a395834-0ed5-4954-8e1d-b63cd2fdb97a").
 			Return(&models.ContactResponse{
-				ID:        "contact-1",
+				ID:        "3c2b1a09-8f7e-4d6c-b5a4-93827160fedc",
 				Phone:     "+5521999999999",
 				Email:     "gabriel@gmail.com",
 				CreatedAt: time.Now(),
@@ -64,8 +67,32 @@ func TestCreateContactHandler(t *testing.T) {
 
 		err := handler.CreateContact(c)
 
-		assert.NoError(t, err)
-		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.ErrorIs(t, err, models.ErrInvalidPayload)
+	})
+
+	t.Run("should return 400 listing every invalid field", func(t *testing.T) {
+		handler := &contactHandler{}
+
+		payload := `{
+			"phone": "21999999999",
+			"email": "gabriel",
+			"clientId": "client-123"
+		}`
+
+		req := httptest.NewRequest(http.MethodPost, "/contacts", bytes.NewBuffer([]byte(payload)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := handler.CreateContact(c)
+
+		var validationErr *models.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []models.FieldError{
+			{Field: "phone", Message: "must be a valid E.164 phone number"},
+			{Field: "email", Message: "must be a valid email"},
+			{Field: "clientId", Message: "must be a valid UUID"},
+		}, validationErr.Fields)
 	})
 
 	t.Run("should return 404 if client not found", func(t *testing.T) {
@@ -78,7 +105,7 @@ func TestCreateContactHandler(t *testing.T) {
 		payload := `{
 			"phone": "+5521999999999",
 			"email": "gabriel@gmail.com",
-			"clientId": "missing-client"
+			"clientId": "0b6f3c1e-2f9a-4d8e-9c55-3e1f8a7d2b10"
 		}`
 
 		req := httptest.NewRequest(http.MethodPost, "/contacts", bytes.NewBuffer([]byte(payload)))
@@ -88,13 +115,12 @@ func TestCreateContactHandler(t *testing.T) {
 		c.SetRequest(req.WithContext(ctx))
 
 		contactService.
-			On("CreateContact", ctx, "+5521999999999", "gabriel@gmail.com", "missing-client").
+			On("CreateContact", ctx, "+5521999999999", "gabriel@gmail.com", "0b6f3c1e-2f9a-4d8e-9c55-3e1f8a7d2b10").
 			Return(nil, models.ErrClientNotFound)
 
 		err := handler.CreateContact(c)
 
-		assert.NoError(t, err)
-		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.ErrorIs(t, err, models.ErrClientNotFound)
 	})
 
 	t.Run("should return 500 if service fails", func(t *testing.T) {
@@ -107,7 +133,7 @@ func TestCreateContactHandler(t *testing.T) {
 		payload := `{
 			"phone": "+5521999999999",
 			"email": "gabriel@gmail.com",
-			"clientId": "client-123"
+			"clientId": "7a395834-0ed5-4954-8e1d-b63cd2fdb97a"
 		}`
 
 		req := httptest.NewRequest(http.MethodPost, "/contacts", bytes.NewBuffer([]byte(payload)))
@@ -117,12 +143,398 @@ func TestCreateContactHandler(t *testing.T) {
 		c.SetRequest(req.WithContext(ctx))
 
 		contactService.
-			On("CreateContact", ctx, "+5521999999999", "gabriel@gmail.com", "client-123").
+			On("CreateContact", ctx, "+5521999999999", "gabriel@gmail.com", "7a395834-0ed5-4954-8e1d-b63cd2fdb97a").
 			Return(nil, errors.New("unexpected failure"))
 
 		err := handler.CreateContact(c)
 
+		assert.EqualError(t, err, "unexpected failure")
+	})
+}
+
+func TestGetContactHandler(t *testing.T) {
+	e := echo.New()
+	ctx := context.Background()
+
+	t.Run("should return contact", func(t *testing.T) {
+		contactService := new(mocks.ContactServiceMock)
+		handler := &contactHandler{cs: contactService}
+
+		req := httptest.NewRequest(http.MethodGet, "/contacts/3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("contactId")
+		c.SetParamValues("3c2b1a09-8f7e-4d6c-b5a4-93827160fedc")
+		c.SetRequest(req.WithContext(ctx))
+
+		contactService.
+			On("GetContactByID", ctx, "3c2b1a09-8f7e-4d6c-b5a4-93827160fedc").
+			Return(&models.ContactResponse{ID: "3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", Email: "gabriel@gmail.com", Version: 2}, nil)
+
+		err := handler.GetContact(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+	})
+
+	t.Run("should return 400 if contactId is not a uuid", func(t *testing.T) {
+		contactService := new(mocks.ContactServiceMock)
+		handler := &contactHandler{cs: contactService}
+
+		req := httptest.NewRequest(http.MethodGet, "/contacts/abc", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("contactId")
+		c.SetParamValues("abc")
+
+		err := handler.GetContact(c)
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+		contactService.AssertNotCalled(t, "GetContactByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return 304 when If-None-Match matches the current version", func(t *testing.T) {
+		contactService := new(mocks.ContactServiceMock)
+		handler := &contactHandler{cs: contactService}
+
+		req := httptest.NewRequest(http.MethodGet, "/contacts/3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", nil)
+		req.Header.Set("If-None-Match", `"2"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("contactId")
+		c.SetParamValues("3c2b1a09-8f7e-4d6c-b5a4-93827160fedc")
+		c.SetRequest(req.WithContext(ctx))
+
+		contactService.
+			On("GetContactByID", ctx, "3c2b1a09-8f7e-4d6c-b5a4-93827160fedc").
+			Return(&models.ContactResponse{ID: "3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", Version: 2}, nil)
+
+		err := handler.GetContact(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+	})
+
+	t.Run("should return 404 if contact not found", func(t *testing.T) {
+		contactService := new(mocks.ContactServiceMock)
+		handler := &contactHandler{cs: contactService}
+
+		req := httptest.NewRequest(http.MethodGet, "/contacts/9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("contactId")
+		c.SetParamValues("9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d")
+		c.SetRequest(req.WithContext(ctx))
+
+		contactService.On("GetContactByID", ctx, "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d").Return(nil, models.ErrContactNotFound)
+
+		err := handler.GetContact(c)
+
+		assert.ErrorIs(t, err, models.ErrContactNotFound)
+	})
+}
+
+func TestUpdateContactHandler(t *testing.T) {
+	e := echo.New()
+	e.Validator = pkgs.NewValidator()
+	ctx := context.Background()
+
+	t.Run("should update contact successfully", func(t *testing.T) {
+		contactService := new(mocks.ContactServiceMock)
+		handler := &contactHandler{cs: contactService}
+
+		payload := `{"phone": "+5521988888888", "email": "new@gmail.com"}`
+
+		req := httptest.NewRequest(http.MethodPut, "/contacts/3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", bytes.NewBuffer([]byte(payload)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("contactId")
+		c.SetParamValues("3c2b1a09-8f7e-4d6c-b5a4-93827160fedc")
+		c.SetRequest(req.WithContext(ctx))
+
+		contactService.
+			On("UpdateContact", ctx, "3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", int64(1), "+5521988888888", "new@gmail.com").
+			Return(&models.ContactResponse{ID: "3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", Phone: "+5521988888888", Email: "new@gmail.com", Version: 2}, nil)
+
+		err := handler.UpdateContact(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+		contactService.AssertExpectations(t)
+	})
+
+	t.Run("should return validation error on invalid payload", func(t *testing.T) {
+		handler := &contactHandler{}
+
+		req := httptest.NewRequest(http.MethodPut, "/contacts/3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", bytes.NewBuffer([]byte(`{"phone": "", "email": "new"}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("contactId")
+		c.SetParamValues("3c2b1a09-8f7e-4d6c-b5a4-93827160fedc")
+
+		err := handler.UpdateContact(c)
+
+		var validationErr *models.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Len(t, validationErr.Fields, 2)
+	})
+
+	t.Run("should require If-Match", func(t *testing.T) {
+		handler := &contactHandler{}
+
+		req := httptest.NewRequest(http.MethodPut, "/contacts/3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", bytes.NewBuffer([]byte(`{"phone": "+5521988888888", "email": "new@gmail.com"}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("contactId")
+		c.SetParamValues("3c2b1a09-8f7e-4d6c-b5a4-93827160fedc")
+
+		err := handler.UpdateContact(c)
+
+		assert.ErrorIs(t, err, models.ErrPreconditionRequired)
+	})
+}
+
+func TestPatchContactHandler(t *testing.T) {
+	e := echo.New()
+	ctx := context.Background()
+
+	t.Run("should patch contact successfully", func(t *testing.T) {
+		contactService := new(mocks.ContactServiceMock)
+		handler := &contactHandler{cs: contactService}
+
+		req := httptest.NewRequest(http.MethodPatch, "/contacts/3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", bytes.NewBuffer([]byte(`{"email": "new@gmail.com"}`)))
+		req.Header.Set(echo.HeaderContentType, pkgs.MIMEApplicationMergePatchJSON)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("contactId")
+		c.SetParamValues("3c2b1a09-8f7e-4d6c-b5a4-93827160fedc")
+		c.SetRequest(req.WithContext(ctx))
+
+		contactService.
+			On("PatchContact", ctx, "3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", int64(1), []byte(`{"email": "new@gmail.com"}`)).
+			Return(&models.ContactResponse{ID: "3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", Email: "new@gmail.com"}, nil)
+
+		err := handler.PatchContact(c)
+
 		assert.NoError(t, err)
-		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("should reject unsupported content type", func(t *testing.T) {
+		handler := &contactHandler{}
+
+		req := httptest.NewRequest(http.MethodPatch, "/contacts/3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", bytes.NewBuffer([]byte(`email=new`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("contactId")
+		c.SetParamValues("3c2b1a09-8f7e-4d6c-b5a4-93827160fedc")
+
+		err := handler.PatchContact(c)
+
+		assert.ErrorIs(t, err, echo.ErrUnsupportedMediaType)
+	})
+}
+
+func TestDeleteContactHandler(t *testing.T) {
+	e := echo.New()
+	ctx := context.Background()
+
+	t.Run("should delete contact successfully", func(t *testing.T) {
+		contactService := new(mocks.ContactServiceMock)
+		handler := &contactHandler{cs: contactService}
+
+		req := httptest.NewRequest(http.MethodDelete, "/contacts/3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", nil)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("contactId")
+		c.SetParamValues("3c2b1a09-8f7e-4d6c-b5a4-93827160fedc")
+		c.SetRequest(req.WithContext(ctx))
+
+		contactService.On("DeleteContact", ctx, "3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", int64(1)).Return(nil)
+
+		err := handler.DeleteContact(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("should return 400 if contactId is not a uuid", func(t *testing.T) {
+		contactService := new(mocks.ContactServiceMock)
+		handler := &contactHandler{cs: contactService}
+
+		req := httptest.NewRequest(http.MethodDelete, "/contacts/abc", nil)
+		req.Header.Set("If-Match", "*")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("contactId")
+		c.SetParamValues("abc")
+
+		err := handler.DeleteContact(c)
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+		contactService.AssertNotCalled(t, "DeleteContact", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return 404 if contact not found", func(t *testing.T) {
+		contactService := new(mocks.ContactServiceMock)
+		handler := &contactHandler{cs: contactService}
+
+		req := httptest.NewRequest(http.MethodDelete, "/contacts/9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d", nil)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("contactId")
+		c.SetParamValues("9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d")
+		c.SetRequest(req.WithContext(ctx))
+
+		contactService.On("DeleteContact", ctx, "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d", int64(1)).Return(models.ErrContactNotFound)
+
+		err := handler.DeleteContact(c)
+
+		assert.ErrorIs(t, err, models.ErrContactNotFound)
+	})
+
+	t.Run("should return 412 when the contact changed since it was read", func(t *testing.T) {
+		contactService := new(mocks.ContactServiceMock)
+		handler := &contactHandler{cs: contactService}
+
+		req := httptest.NewRequest(http.MethodDelete, "/contacts/3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", nil)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("contactId")
+		c.SetParamValues("3c2b1a09-8f7e-4d6c-b5a4-93827160fedc")
+		c.SetRequest(req.WithContext(ctx))
+
+		contactService.On("DeleteContact", ctx, "3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", int64(1)).Return(models.ErrPreconditionFailed)
+
+		err := handler.DeleteContact(c)
+
+		assert.ErrorIs(t, err, models.ErrPreconditionFailed)
+	})
+}
+
+func TestTransferContactHandler(t *testing.T) {
+	e := echo.New()
+	e.Validator = pkgs.NewValidator()
+	ctx := context.Background()
+
+	t.Run("should transfer contact successfully", func(t *testing.T) {
+		contactService := new(mocks.ContactServiceMock)
+		handler := &contactHandler{cs: contactService}
+
+		payload := `{"clientId": "0b6f3c1e-2f9a-4d8e-9c55-3e1f8a7d2b10"}`
+
+		req := httptest.NewRequest(http.MethodPost, "/contacts/3c2b1a09-8f7e-4d6c-b5a4-93827160fedc/transfer", bytes.NewBuffer([]byte(payload)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("contactId")
+		c.SetParamValues("3c2b1a09-8f7e-4d6c-b5a4-93827160fedc")
+		c.SetRequest(req.WithContext(ctx))
+
+		contactService.
+			On("TransferContact", ctx, "3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", "0b6f3c1e-2f9a-4d8e-9c55-3e1f8a7d2b10").
+			Return(&models.ContactResponse{ID: "3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", ClientID: "0b6f3c1e-2f9a-4d8e-9c55-3e1f8a7d2b10"}, nil)
+
+		err := handler.TransferContact(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		contactService.AssertExpectations(t)
+	})
+
+	t.Run("should return validation error on invalid client id", func(t *testing.T) {
+		handler := &contactHandler{}
+
+		req := httptest.NewRequest(http.MethodPost, "/contacts/3c2b1a09-8f7e-4d6c-b5a4-93827160fedc/transfer", bytes.NewBuffer([]byte(`{"clientId": "client-456"}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("contactId")
+		c.SetParamValues("3c2b1a09-8f7e-4d6c-b5a4-93827160fedc")
+
+		err := handler.TransferContact(c)
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+	})
+
+	t.Run("should return 404 if target client not found", func(t *testing.T) {
+		contactService := new(mocks.ContactServiceMock)
+		handler := &contactHandler{cs: contactService}
+
+		payload := `{"clientId": "0b6f3c1e-2f9a-4d8e-9c55-3e1f8a7d2b10"}`
+
+		req := httptest.NewRequest(http.MethodPost, "/contacts/3c2b1a09-8f7e-4d6c-b5a4-93827160fedc/transfer", bytes.NewBuffer([]byte(payload)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("contactId")
+		c.SetParamValues("3c2b1a09-8f7e-4d6c-b5a4-93827160fedc")
+		c.SetRequest(req.WithContext(ctx))
+
+		contactService.
+			On("TransferContact", ctx, "3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", "0b6f3c1e-2f9a-4d8e-9c55-3e1f8a7d2b10").
+			Return(nil, models.ErrClientNotFound)
+
+		err := handler.TransferContact(c)
+
+		assert.ErrorIs(t, err, models.ErrClientNotFound)
+	})
+}
+
+func TestSearchContactsHandler(t *testing.T) {
+	e := echo.New()
+	e.Validator = pkgs.NewValidator()
+	ctx := context.Background()
+
+	t.Run("should return contacts matching the phone", func(t *testing.T) {
+		contactService := new(mocks.ContactServiceMock)
+		handler := &contactHandler{cs: contactService}
+
+		req := httptest.NewRequest(http.MethodGet, "/contacts?phone=%2B55%2021%2099999-9999", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetRequest(req.WithContext(ctx))
+
+		contactService.
+			On("SearchContacts", ctx, "", "+55 21 99999-9999").
+			Return([]models.ContactResponse{{ID: "3c2b1a09-8f7e-4d6c-b5a4-93827160fedc", ClientID: "client-123"}}, nil)
+
+		err := handler.SearchContacts(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"clientId":"client-123"`)
+	})
+
+	t.Run("should return validation error without email and phone", func(t *testing.T) {
+		contactService := new(mocks.ContactServiceMock)
+		handler := &contactHandler{cs: contactService}
+
+		req := httptest.NewRequest(http.MethodGet, "/contacts", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := handler.SearchContacts(c)
+
+		var validationErr *models.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []models.FieldError{
+			{Field: "email", Message: "is required when phone is not present"},
+			{Field: "phone", Message: "is required when email is not present"},
+		}, validationErr.Fields)
+		contactService.AssertNotCalled(t, "SearchContacts", mock.Anything, mock.Anything, mock.Anything)
 	})
 }
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/labstack/echo/v4"
+)
+
+type problem struct {
+	target error
+	status int
+	slug   string
+	title  string
+}
+
+var problems = []problem{
+	{target: models.ErrValidation, status: http.StatusBadRequest, slug: "validation-error", title: "Validation failed"},
+	{target: models.ErrInvalidPayload, status: http.StatusBadRequest, slug: "invalid-payload", title: "Invalid payload"},
+	{target: models.ErrClientNotFound, status: http.StatusNotFound, slug: "client-not-found", title: "Client not found"},
+	{target: models.ErrContactNotFound, status: http.StatusNotFound, slug: "contact-not-found", title: "Contact not found"},
+	{target: models.ErrConflict, status: http.StatusConflict, slug: "conflict", title: "Resource conflict"},
+	{target: models.ErrWebhookNotFound, status: http.StatusNotFound, slug: "webhook-not-found", title: "Webhook not found"},
+	{target: models.ErrUnauthorized, status: http.StatusUnauthorized, slug: "unauthorized", title: "Unauthorized"},
+	{target: models.ErrAuthenticationUnavailable, status: http.StatusServiceUnavailable, slug: "authentication-unavailable", title: "Authentication unavailable"},
+	{target: models.ErrForbidden, status: http.StatusForbidden, slug: "forbidden", title: "Forbidden"},
+	{target: models.ErrTenantForbidden, status: http.StatusForbidden, slug: "tenant-forbidden", title: "Tenant forbidden"},
+	{target: models.ErrAPIKeyNotFound, status: http.StatusNotFound, slug: "api-key-not-found", title: "API key not found"},
+	{target: models.ErrWebhookDeliveryNotFound, status: http.StatusNotFound, slug: "webhook-delivery-not-found", title: "Webhook delivery not found"},
+	{target: models.ErrPreconditionFailed, status: http.StatusPreconditionFailed, slug: "precondition-failed", title: "Precondition failed"},
+	{target: models.ErrPreconditionRequired, status: http.StatusPreconditionRequired, slug: "precondition-required", title: "Precondition required"},
+	{target: models.ErrTooManyRequests, status: http.StatusTooManyRequests, slug: "too-many-requests", title: "Too many requests"},
+	{target: models.ErrIdempotencyKeyReused, status: http.StatusUnprocessableEntity, slug: "idempotency-key-reused", title: "Idempotency key reused"},
+	{target: models.ErrIdempotencyKeyInProgress, status: http.StatusConflict, slug: "idempotency-key-in-progress", title: "Idempotency key in progress"},
+}
+
+// HTTPErrorHandler converte os erros retornados pelos handlers em respostas application/problem+json
+func HTTPErrorHandler(err error, ectx echo.Context) {
+	if ectx.Response().Committed {
+		return
+	}
+
+	details := toProblemDetails(err)
+	details.Instance = ectx.Request().URL.Path
+	details.CorrelationID = correlationID(ectx)
+
+	// O detail do problema é fixo por tipo; a cadeia completa do erro fica só no log
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("method", ectx.Request().Method),
+		slog.String("path", details.Instance),
+		slog.String("correlationId", details.CorrelationID),
+		slog.Any("error", err),
+	)
+	if details.Status >= http.StatusInternalServerError {
+		logger.Error("unhandled error")
+	} else {
+		logger.Info("request failed")
+	}
+
+	if ectx.Request().Method == http.MethodHead {
+		err = ectx.NoContent(details.Status)
+	} else {
+		ectx.Response().Header().Set(echo.HeaderContentType, models.MIMEApplicationProblemJSON)
+		err = ectx.JSON(details.Status, details)
+	}
+
+	if err != nil {
+		pkgs.LoggerFromContext(ectx.Request().Context()).Error("write problem details", slog.Any("error", err))
+	}
+}
+
+func toProblemDetails(err error) *models.ProblemDetails {
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		return &models.ProblemDetails{
+			Type:   "about:blank",
+			Title:  http.StatusText(he.Code),
+			Status: he.Code,
+			Detail: fmt.Sprint(he.Message),
+		}
+	}
+
+	for _, p := range problems {
+		if !errors.Is(err, p.target) {
+			continue
+		}
+
+		details := &models.ProblemDetails{
+			Type:   "/problems/" + p.slug,
+			Title:  p.title,
+			Status: p.status,
+			Detail: p.target.Error(),
+		}
+
+		var validationErr *models.ValidationError
+		if errors.As(err, &validationErr) {
+			details.Detail = "one or more fields are invalid"
+			details.Errors = validationErr.Fields
+		}
+
+		return details
+	}
+
+	return &models.ProblemDetails{
+		Type:   "about:blank",
+		Title:  http.StatusText(http.StatusInternalServerError),
+		Status: http.StatusInternalServerError,
+		Detail: "an unexpected error occurred while processing the request",
+	}
+}
+
+func correlationID(ectx echo.Context) string {
+	if id := ectx.Response().Header().Get(echo.HeaderXRequestID); id != "" {
+		return id
+	}
+
+	return ectx.Request().Header.Get(echo.HeaderXRequestID)
+}
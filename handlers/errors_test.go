@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorHandler(t *testing.T) {
+	e := echo.New()
+
+	t.Run("should render validation errors as problem details", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/clients", nil)
+		rec := httptest.NewRecorder()
+		rec.Header().Set(echo.HeaderXRequestID, "request-123")
+		c := e.NewContext(req, rec)
+
+		err := &models.ValidationError{
+			Fields: []models.FieldError{
+				{Field: "name", Message: "is required"},
+				{Field: "contacts[0].phone", Message: "must be a valid E.164 phone number"},
+			},
+		}
+
+		HTTPErrorHandler(err, c)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, models.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+		assert.JSONEq(t, `{
+			"type": "/problems/validation-error",
+			"title": "Validation failed",
+			"status": 400,
+			"detail": "one or more fields are invalid",
+			"instance": "/clients",
+			"errors": [
+				{"field": "name", "message": "is required"},
+				{"field": "contacts[0].phone", "message": "must be a valid E.164 phone number"}
+			],
+			"correlationId": "request-123"
+		}`, rec.Body.String())
+	})
+
+	t.Run("should map wrapped not found errors to 404", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/clients/client-123/contacts", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := fmt.Errorf("get client by id client-123: %w", models.ErrClientNotFound)
+
+		HTTPErrorHandler(err, c)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.JSONEq(t, `{
+			"type": "/problems/client-not-found",
+			"title": "Client not found",
+			"status": 404,
+			"detail": "client not found",
+			"instance": "/clients/client-123/contacts"
+		}`, rec.Body.String())
+	})
+
+	t.Run("should map conflict errors to 409", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/clients", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		HTTPErrorHandler(fmt.Errorf("create client: %w", models.ErrConflict), c)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("should map unavailable authentication to 503", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/clients", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		HTTPErrorHandler(fmt.Errorf("%w: fetch jwks: unexpected status 502", models.ErrAuthenticationUnavailable), c)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+
+	t.Run("should not leak the wrapped error chain in the detail", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/clients", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := fmt.Errorf("decode cursor eyJpZCI6IjEifQ: illegal base64 data at input byte 4: %w", models.ErrInvalidPayload)
+
+		HTTPErrorHandler(err, c)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"detail":"invalid payload"`)
+		assert.NotContains(t, rec.Body.String(), "base64")
+	})
+
+	t.Run("should keep status of echo http errors", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/unknown", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		HTTPErrorHandler(echo.ErrNotFound, c)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.JSONEq(t, `{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Not Found",
+			"instance": "/unknown"
+		}`, rec.Body.String())
+	})
+
+	t.Run("should hide unexpected errors behind a 500", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/clients", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		HTTPErrorHandler(errors.New("pq: connection refused"), c)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "connection refused")
+	})
+}
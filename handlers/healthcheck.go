@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/services"
+	"github.com/labstack/echo/v4"
+)
+
+type HealthCheckHandler interface {
+	Liveness(ectx echo.Context) error
+	Readiness(ectx echo.Context) error
+}
+
+type healthCheckHandler struct {
+	di *pkgs.Di
+	hs services.HealthcheckService
+}
+
+func NewHealthCheckHandler(di *pkgs.Di) (HealthCheckHandler, error) {
+	healthcheckService, err := pkgs.Invoke[services.HealthcheckService](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke services.healthcheck: %w", err)
+	}
+
+	return &healthCheckHandler{
+		di: di,
+		hs: healthcheckService,
+	}, nil
+}
+
+// Liveness godoc
+// @Summary Verifica se o processo está vivo
+// @Description Responde 200 enquanto o processo atende requisições. Não verifica dependências.
+// @Tags health
+// @Produce json
+// @Success 200 {object} models.HealthResponse
+// @Router /healthz [get]
+func (h *healthCheckHandler) Liveness(ectx echo.Context) error {
+	return ectx.JSON(http.StatusOK, h.hs.Liveness())
+}
+
+// Readiness godoc
+// @Summary Verifica se a instância pode receber tráfego
+// @Description Verifica o Postgres com tempo limite curto e informa o estado de cada dependência. Responde 503 quando alguma dependência está indisponível ou quando a instância está em desligamento (status draining).
+// @Tags health
+// @Produce json
+// @Success 200 {object} models.HealthResponse
+// @Failure 503 {object} models.HealthResponse "Dependência indisponível ou instância em desligamento"
+// @Router /readyz [get]
+func (h *healthCheckHandler) Readiness(ectx echo.Context) error {
+	response := h.hs.Readiness(ectx.Request().Context())
+	if !response.IsUp() {
+		return ectx.JSON(http.StatusServiceUnavailable, response)
+	}
+
+	return ectx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/g-villarinho/nubank-challenge/mocks"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthCheckHandler_Liveness(t *testing.T) {
+	e := echo.New()
+
+	t.Run("should return 200", func(t *testing.T) {
+		healthcheckService := new(mocks.HealthcheckServiceMock)
+		handler := &healthCheckHandler{hs: healthcheckService}
+
+		healthcheckService.On("Liveness").Return(&models.HealthResponse{Status: models.HealthStatusUp})
+
+		req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := handler.Liveness(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"status":"up"}`, rec.Body.String())
+	})
+}
+
+func TestHealthCheckHandler_Readiness(t *testing.T) {
+	e := echo.New()
+	ctx := context.Background()
+
+	t.Run("should return 200 with the checks when ready", func(t *testing.T) {
+		healthcheckService := new(mocks.HealthcheckServiceMock)
+		handler := &healthCheckHandler{hs: healthcheckService}
+
+		healthcheckService.On("Readiness", ctx).Return(&models.HealthResponse{
+			Status: models.HealthStatusUp,
+			Checks: map[string]models.HealthCheck{
+				models.HealthDependencyPostgres: {Status: models.HealthStatusUp, LatencyMs: 1.5},
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetRequest(req.WithContext(ctx))
+
+		err := handler.Readiness(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"status":"up","checks":{"postgres":{"status":"up","latencyMs":1.5}}}`, rec.Body.String())
+	})
+
+	t.Run("should return 503 when a dependency is down", func(t *testing.T) {
+		healthcheckService := new(mocks.HealthcheckServiceMock)
+		handler := &healthCheckHandler{hs: healthcheckService}
+
+		healthcheckService.On("Readiness", ctx).Return(&models.HealthResponse{
+			Status: models.HealthStatusDown,
+			Checks: map[string]models.HealthCheck{
+				models.HealthDependencyPostgres: {Status: models.HealthStatusDown, LatencyMs: 1000},
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetRequest(req.WithContext(ctx))
+
+		err := handler.Readiness(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.JSONEq(t, `{"status":"down","checks":{"postgres":{"status":"down","latencyMs":1000}}}`, rec.Body.String())
+	})
+
+	t.Run("should return 503 while draining", func(t *testing.T) {
+		healthcheckService := new(mocks.HealthcheckServiceMock)
+		handler := &healthCheckHandler{hs: healthcheckService}
+
+		healthcheckService.On("Readiness", ctx).Return(&models.HealthResponse{Status: models.HealthStatusDraining})
+
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetRequest(req.WithContext(ctx))
+
+		err := handler.Readiness(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.JSONEq(t, `{"status":"draining"}`, rec.Body.String())
+	})
+}
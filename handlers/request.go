@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	headerETag        = "ETag"
+	headerIfMatch     = "If-Match"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+// pathID lê o ID do parâmetro de rota name. O ID vai para a comparação com uma coluna uuid; um
+// valor qualquer faria o Postgres falhar com 500, então é recusado aqui e segue na forma canônica
+func pathID(ectx echo.Context, name string) (string, error) {
+	value := ectx.Param(name)
+	if value == "" {
+		return "", models.NewValidationError(name, "is required")
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return "", models.NewValidationError(name, "is invalid")
+	}
+
+	return id.String(), nil
+}
+
+func isMergePatch(req *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get(echo.HeaderContentType))
+	if err != nil {
+		return false
+	}
+
+	return mediaType == pkgs.MIMEApplicationMergePatchJSON || mediaType == echo.MIMEApplicationJSON
+}
+
+// setPaginationLinks monta o header Link (RFC 8288) com as páginas seguinte e anterior,
+// preservando os demais parâmetros da busca
+func setPaginationLinks(ectx echo.Context, meta models.PageMeta) {
+	var links []string
+
+	if meta.NextCursor != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageURL(ectx, meta.NextCursor)))
+	}
+
+	if meta.PrevCursor != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, pageURL(ectx, meta.PrevCursor)))
+	}
+
+	if len(links) > 0 {
+		ectx.Response().Header().Set("Link", strings.Join(links, ", "))
+	}
+}
+
+// pageURL monta o link relativo da página, resolvido pelo cliente contra a URL da requisição. O
+// host não entra no link porque vem do header Host, escolhido por quem faz a requisição.
+func pageURL(ectx echo.Context, cursor string) string {
+	req := ectx.Request()
+
+	query := req.URL.Query()
+	query.Set("cursor", cursor)
+
+	return (&url.URL{Path: req.URL.Path, RawQuery: query.Encode()}).String()
+}
+
+func setETag(ectx echo.Context, version int64) {
+	ectx.Response().Header().Set(headerETag, versionETag(version))
+}
+
+func versionETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// representationETag monta a ETag de uma representação do cliente. A representação completa
+// usa só a versão, a mesma aceita no If-Match; as parciais (?fields= e ?include=) acrescentam um
+// resumo da seleção, para que representações diferentes da mesma versão não compartilhem a ETag
+func representationETag(version int64, fields []string) string {
+	selected := slices.Clone(fields)
+	slices.Sort(selected)
+	selected = slices.Compact(selected)
+
+	full := slices.Sorted(slices.Values(models.ClientFields))
+	if slices.Equal(selected, full) {
+		return versionETag(version)
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(selected, ",")))
+
+	return strconv.Quote(fmt.Sprintf("%d-%s", version, hex.EncodeToString(sum[:4])))
+}
+
+// ifMatchVersion lê a versão esperada do header If-Match. O header é obrigatório nas escritas
+// e If-Match: * aceita qualquer versão atual do recurso.
+func ifMatchVersion(req *http.Request) (int64, error) {
+	value := strings.TrimSpace(req.Header.Get(headerIfMatch))
+	if value == "" {
+		return 0, models.ErrPreconditionRequired
+	}
+
+	if value == "*" {
+		return services.AnyVersion, nil
+	}
+
+	// If-Match usa comparação forte, então ETags fracas nunca correspondem
+	version, ok := parseETag(value)
+	if !ok || version == services.AnyVersion {
+		return 0, models.ErrPreconditionFailed
+	}
+
+	return version, nil
+}
+
+// notModified indica se alguma ETag do header If-None-Match corresponde à ETag da representação
+// atual, usando comparação fraca como define a RFC 9110
+func notModified(req *http.Request, etag string) bool {
+	value := strings.TrimSpace(req.Header.Get(headerIfNoneMatch))
+	if value == "" {
+		return false
+	}
+
+	if value == "*" {
+		return true
+	}
+
+	for _, tag := range strings.Split(value, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseETag(tag string) (int64, bool) {
+	unquoted, err := strconv.Unquote(tag)
+	if err != nil || !strings.HasPrefix(tag, `"`) {
+		return 0, false
+	}
+
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return version, true
+}
@@ -0,0 +1,357 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/services"
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookHandler interface {
+	CreateWebhook(ectx echo.Context) error
+	GetWebhooks(ectx echo.Context) error
+	GetWebhookByID(ectx echo.Context) error
+	UpdateWebhook(ectx echo.Context) error
+	DeleteWebhook(ectx echo.Context) error
+	GetDeliveries(ectx echo.Context) error
+	GetDeadLetters(ectx echo.Context) error
+	Redeliver(ectx echo.Context) error
+}
+
+type webhookHandler struct {
+	di *pkgs.Di
+	ws services.WebhookService
+}
+
+func NewWebhookHandler(di *pkgs.Di) (WebhookHandler, error) {
+	webhookService, err := pkgs.Invoke[services.WebhookService](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke services.webhook: %w", err)
+	}
+
+	return &webhookHandler{
+		di: di,
+		ws: webhookService,
+	}, nil
+}
+
+// CreateWebhook godoc
+// @Summary Cria uma inscrição de webhook
+// @Description Os eventos assinados são enviados por POST para a URL, assinados com HMAC-SHA256 do secret.
+// @Description O header Webhook-Signature tem o formato t=<unix>,v1=<hex> e assina "<unix>.<corpo>".
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param payload body models.CreateWebhookPayload true "Dados da inscrição"
+// @Success 201 {object} models.WebhookResponse
+// @Failure 400 {object} models.ProblemDetails "Erro de validação ou payload inválido"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao criar a inscrição"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhooks [post]
+func (w *webhookHandler) CreateWebhook(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "webhook"),
+		slog.String("method", "CreateWebhook"),
+	)
+
+	var payload models.CreateWebhookPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	if err := ectx.Validate(&payload); err != nil {
+		logger.Warn("invalid payload", "error", err)
+		return err
+	}
+
+	response, err := w.ws.CreateWebhook(ectx.Request().Context(), payload)
+	if err != nil {
+		logger.Error("error to create webhook", "error", err)
+		return err
+	}
+
+	return ectx.JSON(http.StatusCreated, response)
+}
+
+// GetWebhooks godoc
+// @Summary Lista as inscrições de webhook
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} models.WebhookResponse
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao buscar as inscrições"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhooks [get]
+func (w *webhookHandler) GetWebhooks(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "webhook"),
+		slog.String("method", "GetWebhooks"),
+	)
+
+	response, err := w.ws.GetWebhooks(ectx.Request().Context())
+	if err != nil {
+		logger.Error("error to get webhooks", "error", err)
+		return err
+	}
+
+	return ectx.JSON(http.StatusOK, response)
+}
+
+// GetWebhookByID godoc
+// @Summary Busca uma inscrição de webhook
+// @Tags webhooks
+// @Produce json
+// @Param webhookId path string true "ID da inscrição"
+// @Success 200 {object} models.WebhookResponse
+// @Failure 400 {object} models.ProblemDetails "ID inválido"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário"
+// @Failure 404 {object} models.ProblemDetails "Inscrição não encontrada"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao buscar a inscrição"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhooks/{webhookId} [get]
+func (w *webhookHandler) GetWebhookByID(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "webhook"),
+		slog.String("method", "GetWebhookByID"),
+	)
+
+	id, err := pathID(ectx, "webhookId")
+	if err != nil {
+		return err
+	}
+
+	response, err := w.ws.GetWebhookByID(ectx.Request().Context(), id)
+	if err != nil {
+		logger.Error("error to get webhook", "error", err)
+		return err
+	}
+
+	return ectx.JSON(http.StatusOK, response)
+}
+
+// UpdateWebhook godoc
+// @Summary Atualiza uma inscrição de webhook
+// @Description Substitui a URL, os eventos e o estado da inscrição. O secret só é trocado quando informado.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhookId path string true "ID da inscrição"
+// @Param payload body models.UpdateWebhookPayload true "Dados da inscrição"
+// @Success 200 {object} models.WebhookResponse
+// @Failure 400 {object} models.ProblemDetails "Erro de validação ou payload inválido"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário"
+// @Failure 404 {object} models.ProblemDetails "Inscrição não encontrada"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao atualizar a inscrição"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhooks/{webhookId} [put]
+func (w *webhookHandler) UpdateWebhook(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "webhook"),
+		slog.String("method", "UpdateWebhook"),
+	)
+
+	id, err := pathID(ectx, "webhookId")
+	if err != nil {
+		return err
+	}
+
+	var payload models.UpdateWebhookPayload
+	if err := jsoniter.NewDecoder(ectx.Request().Body).Decode(&payload); err != nil {
+		logger.Error("error to bind payload", "error", err)
+		return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	if err := ectx.Validate(&payload); err != nil {
+		logger.Warn("invalid payload", "error", err)
+		return err
+	}
+
+	response, err := w.ws.UpdateWebhook(ectx.Request().Context(), id, payload)
+	if err != nil {
+		logger.Error("error to update webhook", "error", err)
+		return err
+	}
+
+	return ectx.JSON(http.StatusOK, response)
+}
+
+// DeleteWebhook godoc
+// @Summary Remove uma inscrição de webhook
+// @Description Remove a inscrição junto com suas entregas e o log de tentativas
+// @Tags webhooks
+// @Param webhookId path string true "ID da inscrição"
+// @Success 204
+// @Failure 400 {object} models.ProblemDetails "ID inválido"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário"
+// @Failure 404 {object} models.ProblemDetails "Inscrição não encontrada"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao remover a inscrição"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhooks/{webhookId} [delete]
+func (w *webhookHandler) DeleteWebhook(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "webhook"),
+		slog.String("method", "DeleteWebhook"),
+	)
+
+	id, err := pathID(ectx, "webhookId")
+	if err != nil {
+		return err
+	}
+
+	if err := w.ws.DeleteWebhook(ectx.Request().Context(), id); err != nil {
+		logger.Error("error to delete webhook", "error", err)
+		return err
+	}
+
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+// GetDeliveries godoc
+// @Summary Lista as entregas de uma inscrição
+// @Description Retorna as entregas mais recentes da inscrição com o log de tentativas de cada uma
+// @Tags webhooks
+// @Produce json
+// @Param webhookId path string true "ID da inscrição"
+// @Param status query string false "Situação da entrega" Enums(pending, delivered, dead)
+// @Param limit query int false "Quantidade de entregas (padrão 20, máximo 100)"
+// @Success 200 {array} models.WebhookDeliveryResponse
+// @Failure 400 {object} models.ProblemDetails "ID ou parâmetros de busca inválidos"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário"
+// @Failure 404 {object} models.ProblemDetails "Inscrição não encontrada"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao buscar as entregas"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhooks/{webhookId}/deliveries [get]
+func (w *webhookHandler) GetDeliveries(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "webhook"),
+		slog.String("method", "GetDeliveries"),
+	)
+
+	id, err := pathID(ectx, "webhookId")
+	if err != nil {
+		return err
+	}
+
+	var query models.WebhookDeliveryQuery
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ectx, &query); err != nil {
+		logger.Warn("error to bind query", "error", err)
+		return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	if err := ectx.Validate(&query); err != nil {
+		logger.Warn("invalid query", "error", err)
+		return err
+	}
+
+	response, err := w.ws.GetDeliveries(ectx.Request().Context(), id, query.Status, query.PageLimit())
+	if err != nil {
+		logger.Error("error to get webhook deliveries", "error", err)
+		return err
+	}
+
+	return ectx.JSON(http.StatusOK, response)
+}
+
+// GetDeadLetters godoc
+// @Summary Lista as entregas que esgotaram as tentativas
+// @Description Retorna as dead letters de todas as inscrições, das mais recentes para as mais antigas
+// @Tags webhooks
+// @Produce json
+// @Param limit query int false "Quantidade de entregas (padrão 20, máximo 100)"
+// @Success 200 {array} models.WebhookDeliveryResponse
+// @Failure 400 {object} models.ProblemDetails "Parâmetros de busca inválidos"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao buscar as dead letters"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhooks/dead-letters [get]
+func (w *webhookHandler) GetDeadLetters(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "webhook"),
+		slog.String("method", "GetDeadLetters"),
+	)
+
+	var query models.WebhookDeliveryQuery
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ectx, &query); err != nil {
+		logger.Warn("error to bind query", "error", err)
+		return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	if err := ectx.Validate(&query); err != nil {
+		logger.Warn("invalid query", "error", err)
+		return err
+	}
+
+	response, err := w.ws.GetDeadLetters(ectx.Request().Context(), query.PageLimit())
+	if err != nil {
+		logger.Error("error to get webhook dead letters", "error", err)
+		return err
+	}
+
+	return ectx.JSON(http.StatusOK, response)
+}
+
+// Redeliver godoc
+// @Summary Reenvia uma entrega de webhook
+// @Description Agenda um novo envio imediato de uma entrega concluída ou em dead letter, com um novo ciclo de tentativas, sem zerar a contagem
+// @Tags webhooks
+// @Produce json
+// @Param deliveryId path string true "ID da entrega"
+// @Success 202 {object} models.WebhookDeliveryResponse
+// @Failure 400 {object} models.ProblemDetails "ID inválido"
+// @Failure 401 {object} models.ProblemDetails "Credencial ausente ou inválida"
+// @Failure 403 {object} models.ProblemDetails "Credencial sem o escopo necessário"
+// @Failure 404 {object} models.ProblemDetails "Entrega não encontrada"
+// @Failure 409 {object} models.ProblemDetails "Entrega ainda pendente"
+// @Failure 429 {object} models.ProblemDetails "Limite de requisições excedido; tente de novo após Retry-After"
+// @Failure 500 {object} models.ProblemDetails "Erro interno ao reenviar a entrega"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhooks/deliveries/{deliveryId}/redeliver [post]
+func (w *webhookHandler) Redeliver(ectx echo.Context) error {
+	logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+		slog.String("handler", "webhook"),
+		slog.String("method", "Redeliver"),
+	)
+
+	id, err := pathID(ectx, "deliveryId")
+	if err != nil {
+		return err
+	}
+
+	response, err := w.ws.Redeliver(ectx.Request().Context(), id)
+	if err != nil {
+		logger.Error("error to redeliver webhook", "error", err)
+		return err
+	}
+
+	return ectx.JSON(http.StatusAccepted, response)
+}
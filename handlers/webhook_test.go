@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/g-villarinho/nubank-challenge/mocks"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookHandler_CreateWebhook(t *testing.T) {
+	e := echo.New()
+	e.Validator = pkgs.NewValidator()
+	ctx := context.Background()
+
+	t.Run("should create the subscription", func(t *testing.T) {
+		webhookService := new(mocks.WebhookServiceMock)
+		handler := &webhookHandler{ws: webhookService}
+
+		payload := models.CreateWebhookPayload{
+			URL:        "https://partner.example.com/webhooks",
+			EventTypes: []string{models.EventClientCreated},
+			Secret:     "whsec_5f2b7c9d1e3a4b6c",
+		}
+		webhookService.On("CreateWebhook", ctx, payload).Return(&models.WebhookResponse{ID: "2d7f4a91-c3b8-4e56-a0f2-8b9c1d3e5f47"}, nil)
+
+		body := `{"url":"https://partner.example.com/webhooks","eventTypes":["ClientCreated"],"secret":"whsec_5f2b7c9d1e3a4b6c"}`
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetRequest(req.WithContext(ctx))
+
+		err := handler.CreateWebhook(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "secret")
+		webhookService.AssertExpectations(t)
+	})
+
+	t.Run("should return validation error on unknown event type", func(t *testing.T) {
+		handler := &webhookHandler{}
+
+		body := `{"url":"https://partner.example.com/webhooks","eventTypes":["ClientRead"],"secret":"whsec_5f2b7c9d1e3a4b6c"}`
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := handler.CreateWebhook(c)
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+	})
+
+	t.Run("should return validation error on short secret", func(t *testing.T) {
+		handler := &webhookHandler{}
+
+		body := `{"url":"https://partner.example.com/webhooks","eventTypes":["ClientCreated"],"secret":"short"}`
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := handler.CreateWebhook(c)
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+	})
+
+	t.Run("should return validation error on plain http url", func(t *testing.T) {
+		handler := &webhookHandler{}
+
+		body := `{"url":"http://partner.example.com/webhooks","eventTypes":["ClientCreated"],"secret":"whsec_5f2b7c9d1e3a4b6c"}`
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := handler.CreateWebhook(c)
+
+		var validationErr *models.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []models.FieldError{{Field: "url", Message: "must be a valid https URL"}}, validationErr.Fields)
+	})
+
+	t.Run("should return validation error on unknown event type", func(t *testing.T) {
+		handler := &webhookHandler{}
+
+		body := `{"url":"https://partner.example.com/webhooks","eventTypes":["ClientCreated","ClientArchived"],"secret":"whsec_5f2b7c9d1e3a4b6c"}`
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := handler.CreateWebhook(c)
+
+		var validationErr *models.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []models.FieldError{{
+			Field:   "eventTypes[1]",
+			Message: "must be one of: " + strings.Join(models.WebhookEventTypes, ", "),
+		}}, validationErr.Fields)
+	})
+}
+
+func TestWebhookHandler_GetDeliveries(t *testing.T) {
+	e := echo.New()
+	e.Validator = pkgs.NewValidator()
+	ctx := context.Background()
+
+	t.Run("should list deliveries filtered by status", func(t *testing.T) {
+		webhookService := new(mocks.WebhookServiceMock)
+		handler := &webhookHandler{ws: webhookService}
+
+		webhookService.
+			On("GetDeliveries", ctx, "2d7f4a91-c3b8-4e56-a0f2-8b9c1d3e5f47", models.WebhookDeliveryDead, 5).
+			Return([]models.WebhookDeliveryResponse{{ID: "b4e1c7d2-6a3f-4e8b-9d05-7c2a1f6e3b98", Status: models.WebhookDeliveryDead}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/webhooks/2d7f4a91-c3b8-4e56-a0f2-8b9c1d3e5f47/deliveries?status=dead&limit=5", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetRequest(req.WithContext(ctx))
+		c.SetParamNames("webhookId")
+		c.SetParamValues("2d7f4a91-c3b8-4e56-a0f2-8b9c1d3e5f47")
+
+		err := handler.GetDeliveries(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		webhookService.AssertExpectations(t)
+	})
+
+	t.Run("should return validation error on invalid status", func(t *testing.T) {
+		handler := &webhookHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/webhooks/2d7f4a91-c3b8-4e56-a0f2-8b9c1d3e5f47/deliveries?status=failed", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("webhookId")
+		c.SetParamValues("2d7f4a91-c3b8-4e56-a0f2-8b9c1d3e5f47")
+
+		err := handler.GetDeliveries(c)
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+	})
+}
+
+func TestWebhookHandler_Redeliver(t *testing.T) {
+	e := echo.New()
+	ctx := context.Background()
+
+	t.Run("should accept the redelivery", func(t *testing.T) {
+		webhookService := new(mocks.WebhookServiceMock)
+		handler := &webhookHandler{ws: webhookService}
+
+		webhookService.
+			On("Redeliver", ctx, "b4e1c7d2-6a3f-4e8b-9d05-7c2a1f6e3b98").
+			Return(&models.WebhookDeliveryResponse{ID: "b4e1c7d2-6a3f-4e8b-9d05-7c2a1f6e3b98", Status: models.WebhookDeliveryPending}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/deliveries/b4e1c7d2-6a3f-4e8b-9d05-7c2a1f6e3b98/redeliver", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetRequest(req.WithContext(ctx))
+		c.SetParamNames("deliveryId")
+		c.SetParamValues("b4e1c7d2-6a3f-4e8b-9d05-7c2a1f6e3b98")
+
+		err := handler.Redeliver(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+	})
+
+	t.Run("should return conflict if the delivery is still pending", func(t *testing.T) {
+		webhookService := new(mocks.WebhookServiceMock)
+		handler := &webhookHandler{ws: webhookService}
+
+		webhookService.
+			On("Redeliver", ctx, "b4e1c7d2-6a3f-4e8b-9d05-7c2a1f6e3b98").
+			Return(nil, fmt.Errorf("%w: delivery b4e1c7d2-6a3f-4e8b-9d05-7c2a1f6e3b98 is still pending", models.ErrConflict))
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/deliveries/b4e1c7d2-6a3f-4e8b-9d05-7c2a1f6e3b98/redeliver", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetRequest(req.WithContext(ctx))
+		c.SetParamNames("deliveryId")
+		c.SetParamValues("b4e1c7d2-6a3f-4e8b-9d05-7c2a1f6e3b98")
+
+		err := handler.Redeliver(c)
+
+		assert.ErrorIs(t, err, models.ErrConflict)
+	})
+
+	t.Run("should return 400 if deliveryId is not a uuid", func(t *testing.T) {
+		webhookService := new(mocks.WebhookServiceMock)
+		handler := &webhookHandler{ws: webhookService}
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/deliveries/abc/redeliver", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("deliveryId")
+		c.SetParamValues("abc")
+
+		err := handler.Redeliver(c)
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+		webhookService.AssertNotCalled(t, "Redeliver", mock.Anything, mock.Anything)
+	})
+}
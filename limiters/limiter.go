@@ -0,0 +1,41 @@
+package limiters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/g-villarinho/nubank-challenge/configs"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/repositories"
+)
+
+const (
+	StoreMemory   = "memory"
+	StorePostgres = "postgres"
+)
+
+// Limiter controla os baldes de tokens do rate limit. Allow consome um token do balde key,
+// criado cheio na primeira requisição, e informa se a requisição pode seguir. Peek informa o
+// mesmo sem consumir o token, para quando só depois se sabe se a requisição deve ser cobrada.
+type Limiter interface {
+	Allow(ctx context.Context, key string, policy models.RateLimitPolicy) (*models.RateLimitResult, error)
+	Peek(ctx context.Context, key string, policy models.RateLimitPolicy) (*models.RateLimitResult, error)
+}
+
+// NewLimiter cria o limiter com os baldes guardados no store escolhido em RATE_LIMIT_STORE
+func NewLimiter(di *pkgs.Di) (Limiter, error) {
+	switch configs.Env.RateLimit.Store {
+	case StoreMemory:
+		return NewMemoryLimiter(), nil
+	case StorePostgres:
+		rateLimitRepository, err := pkgs.Invoke[repositories.RateLimitRepository](di)
+		if err != nil {
+			return nil, fmt.Errorf("invoke repositories.rate_limit: %w", err)
+		}
+
+		return NewPostgresLimiter(rateLimitRepository), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q", configs.Env.RateLimit.Store)
+	}
+}
@@ -0,0 +1,92 @@
+package limiters
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+)
+
+// memorySweepInterval espaça a remoção dos baldes que já voltaram a ficar cheios
+const memorySweepInterval = time.Minute
+
+// MemoryLimiter guarda os baldes em memória. Os limites valem por instância da API; com mais de
+// uma instância, use o PostgresLimiter.
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	buckets map[string]*memoryBucket
+	sweptAt time.Time
+}
+
+type memoryBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	fullAt    time.Time
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		now:     time.Now,
+		buckets: make(map[string]*memoryBucket),
+	}
+}
+
+func (m *MemoryLimiter) Allow(ctx context.Context, key string, policy models.RateLimitPolicy) (*models.RateLimitResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweep(now)
+
+	bucket, ok := m.buckets[key]
+	if !ok {
+		bucket = &memoryBucket{tokens: float64(policy.Burst), updatedAt: now}
+		m.buckets[key] = bucket
+	}
+
+	tokens := policy.Refill(bucket.tokens, now.Sub(bucket.updatedAt))
+
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+
+	result := policy.Result(tokens, allowed)
+
+	bucket.tokens = tokens
+	bucket.updatedAt = now
+	bucket.fullAt = now.Add(result.Reset)
+
+	return result, nil
+}
+
+func (m *MemoryLimiter) Peek(ctx context.Context, key string, policy models.RateLimitPolicy) (*models.RateLimitResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Um balde que ainda não existe, ou já removido pelo sweep, está cheio
+	tokens := float64(policy.Burst)
+	if bucket, ok := m.buckets[key]; ok {
+		tokens = policy.Refill(bucket.tokens, m.now().Sub(bucket.updatedAt))
+	}
+
+	return policy.Result(tokens, tokens >= 1), nil
+}
+
+// sweep remove os baldes cheios, que equivalem a um balde novo, para que a memória não cresça
+// com cada IP ou credencial que já passou pela API
+func (m *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(m.sweptAt) < memorySweepInterval {
+		return
+	}
+
+	for key, bucket := range m.buckets {
+		if !now.Before(bucket.fullAt) {
+			delete(m.buckets, key)
+		}
+	}
+
+	m.sweptAt = now
+}
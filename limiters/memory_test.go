@@ -0,0 +1,119 @@
+package limiters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryLimiter_Allow(t *testing.T) {
+	ctx := context.Background()
+	policy := models.RateLimitPolicy{Burst: 2, PerMinute: 60}
+
+	newLimiter := func(now *time.Time) *MemoryLimiter {
+		limiter := NewMemoryLimiter()
+		limiter.now = func() time.Time { return *now }
+		return limiter
+	}
+
+	t.Run("should allow the burst and deny the next request until a token is refilled", func(t *testing.T) {
+		now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		limiter := newLimiter(&now)
+
+		first, _ := limiter.Allow(ctx, "key", policy)
+		second, _ := limiter.Allow(ctx, "key", policy)
+		third, err := limiter.Allow(ctx, "key", policy)
+
+		assert.NoError(t, err)
+		assert.True(t, first.Allowed)
+		assert.Equal(t, 1, first.Remaining)
+		assert.True(t, second.Allowed)
+		assert.Equal(t, 0, second.Remaining)
+		assert.False(t, third.Allowed)
+		assert.Equal(t, 2, third.Limit)
+		assert.Equal(t, time.Second, third.RetryAfter)
+		assert.Equal(t, 2*time.Second, third.Reset)
+
+		now = now.Add(time.Second)
+		fourth, _ := limiter.Allow(ctx, "key", policy)
+
+		assert.True(t, fourth.Allowed)
+		assert.Equal(t, 0, fourth.Remaining)
+	})
+
+	t.Run("should keep a bucket per key", func(t *testing.T) {
+		now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		limiter := newLimiter(&now)
+
+		limiter.Allow(ctx, "key-1", policy)
+		limiter.Allow(ctx, "key-1", policy)
+		result, _ := limiter.Allow(ctx, "key-2", policy)
+
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 1, result.Remaining)
+	})
+
+	t.Run("should not refill past the burst", func(t *testing.T) {
+		now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		limiter := newLimiter(&now)
+
+		limiter.Allow(ctx, "key", policy)
+		now = now.Add(time.Hour)
+		result, _ := limiter.Allow(ctx, "key", policy)
+
+		assert.Equal(t, 1, result.Remaining)
+	})
+
+	t.Run("should sweep the buckets that are full again", func(t *testing.T) {
+		now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		limiter := newLimiter(&now)
+
+		limiter.Allow(ctx, "idle", policy)
+		now = now.Add(memorySweepInterval)
+		limiter.Allow(ctx, "active", policy)
+
+		assert.NotContains(t, limiter.buckets, "idle")
+		assert.Contains(t, limiter.buckets, "active")
+	})
+}
+
+func TestMemoryLimiter_Peek(t *testing.T) {
+	ctx := context.Background()
+	policy := models.RateLimitPolicy{Burst: 2, PerMinute: 60}
+
+	t.Run("should report a full bucket for an unknown key", func(t *testing.T) {
+		limiter := NewMemoryLimiter()
+
+		result, err := limiter.Peek(ctx, "key", policy)
+
+		assert.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 2, result.Remaining)
+		assert.NotContains(t, limiter.buckets, "key")
+	})
+
+	t.Run("should report the refilled bucket without consuming a token", func(t *testing.T) {
+		now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		limiter := NewMemoryLimiter()
+		limiter.now = func() time.Time { return now }
+
+		limiter.Allow(ctx, "key", policy)
+		limiter.Allow(ctx, "key", policy)
+
+		empty, _ := limiter.Peek(ctx, "key", policy)
+		assert.False(t, empty.Allowed)
+		assert.Equal(t, time.Second, empty.RetryAfter)
+
+		now = now.Add(time.Second)
+		refilled, _ := limiter.Peek(ctx, "key", policy)
+		again, _ := limiter.Peek(ctx, "key", policy)
+
+		assert.True(t, refilled.Allowed)
+		assert.Equal(t, 1, refilled.Remaining)
+		assert.Equal(t, refilled, again)
+	})
+
+}
@@ -0,0 +1,46 @@
+package limiters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/repositories"
+)
+
+// PostgresLimiter guarda os baldes no Postgres, de modo que os limites valem para todas as
+// instâncias da API juntas
+type PostgresLimiter struct {
+	rlr repositories.RateLimitRepository
+}
+
+func NewPostgresLimiter(rateLimitRepository repositories.RateLimitRepository) *PostgresLimiter {
+	return &PostgresLimiter{
+		rlr: rateLimitRepository,
+	}
+}
+
+func (p *PostgresLimiter) Allow(ctx context.Context, key string, policy models.RateLimitPolicy) (*models.RateLimitResult, error) {
+	bucket, err := p.rlr.TakeToken(ctx, key, policy, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("take token from bucket %s: %w", key, err)
+	}
+
+	return policy.Result(bucket.Tokens, bucket.Allowed), nil
+}
+
+func (p *PostgresLimiter) Peek(ctx context.Context, key string, policy models.RateLimitPolicy) (*models.RateLimitResult, error) {
+	bucket, err := p.rlr.GetBucket(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("get bucket %s: %w", key, err)
+	}
+
+	// Um balde que ainda não existe, ou já expurgado, está cheio
+	tokens := float64(policy.Burst)
+	if bucket != nil {
+		tokens = policy.Refill(bucket.Tokens, time.Now().UTC().Sub(bucket.UpdatedAt))
+	}
+
+	return policy.Result(tokens, tokens >= 1), nil
+}
@@ -3,16 +3,30 @@
 // @description Esta API gerencia clientes e contatos.
 // @host localhost:8080
 // @BasePath /
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Token JWT no formato "Bearer <token>"
 package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/g-villarinho/nubank-challenge/configs"
 	_ "github.com/g-villarinho/nubank-challenge/docs"
+	"github.com/g-villarinho/nubank-challenge/handlers"
 	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/tracing"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	echoSwagger "github.com/swaggo/echo-swagger"
@@ -20,24 +34,55 @@ import (
 
 func main() {
 	e := echo.New()
+	e.Validator = pkgs.NewValidator()
+	e.HTTPErrorHandler = handlers.HTTPErrorHandler
 
 	if err := configs.LoadEnv(); err != nil {
 		e.Logger.Fatal(fmt.Sprintf("load env: %v", err))
 	}
 
+	setupLogger()
+
 	di := pkgs.NewDi()
 
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		e.Logger.Fatal(fmt.Sprintf("setup tracing: %v", err))
+	}
+	di.OnShutdown("tracing", shutdownTracing)
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	e.Use(middleware.Recover())
 
 	initDependencies(ctx, di)
+
+	if err := di.Validate(); err != nil {
+		e.Logger.Fatal(fmt.Sprintf("validate dependencies: %v", err))
+	}
+
 	setupRoutes(e, di)
+	setupWorkers(e, di)
+
+	if err := di.Start(ctx); err != nil {
+		e.Logger.Fatal(fmt.Sprintf("start dependencies: %v", err))
+	}
 
 	if configs.Env.Env == "DEV" {
 		e.GET("/swagger/*", echoSwagger.WrapHandler)
 	}
 
-	e.Logger.Fatal(e.Start(":8080"))
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	go func() {
+		if err := e.Start(":8080"); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			e.Logger.Fatal(err)
+		}
+	}()
+
+	<-signalCtx.Done()
+
+	shutdown(e, di)
 }
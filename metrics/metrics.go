@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "nubank"
+
+// Metrics reúne as métricas expostas em /metrics num registry próprio, em vez do global do
+// client_golang, para não expor métricas registradas por dependências. Cada container tem o seu,
+// então os testes não compartilham contadores.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	HTTPRequests        *prometheus.CounterVec
+	HTTPRequestDuration *prometheus.HistogramVec
+	ClientsCreated      prometheus.Counter
+	ContactsCreated     prometheus.Counter
+}
+
+func NewMetrics(di *pkgs.Di) (*Metrics, error) {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		HTTPRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Total de requisições HTTP por método, rota e status.",
+		}, []string{"method", "route", "status"}),
+
+		HTTPRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latência das requisições HTTP por método, rota e status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+
+		ClientsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "clients_created_total",
+			Help:      "Total de clientes criados.",
+		}),
+
+		ContactsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "contacts_created_total",
+			Help:      "Total de contatos criados, inclusive os criados junto com o cliente.",
+		}),
+	}
+
+	for _, collector := range []prometheus.Collector{
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.HTTPRequests,
+		m.HTTPRequestDuration,
+		m.ClientsCreated,
+		m.ContactsCreated,
+	} {
+		if err := m.registry.Register(collector); err != nil {
+			return nil, fmt.Errorf("register collector: %w", err)
+		}
+	}
+
+	return m, nil
+}
+
+// RegisterDB exporta as estatísticas do pool de conexões (conexões abertas, em uso, ociosas e
+// as esperas por uma conexão livre) com o label db_name
+func (m *Metrics) RegisterDB(db *sql.DB, name string) error {
+	if err := m.registry.Register(collectors.NewDBStatsCollector(db, name)); err != nil {
+		return fmt.Errorf("register db stats collector: %w", err)
+	}
+
+	return nil
+}
+
+// Handler responde as métricas do registry no formato de exposição do Prometheus
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
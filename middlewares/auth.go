@@ -0,0 +1,154 @@
+package middlewares
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/services"
+	"github.com/labstack/echo/v4"
+)
+
+type AuthMiddleware interface {
+	Require(scopes ...string) echo.MiddlewareFunc
+}
+
+type authMiddleware struct {
+	di   *pkgs.Di
+	aks  services.APIKeyService
+	jwts services.JWTService
+}
+
+func NewAuthMiddleware(di *pkgs.Di) (AuthMiddleware, error) {
+	apiKeyService, err := pkgs.Invoke[services.APIKeyService](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke services.api_key: %w", err)
+	}
+
+	jwtService, err := pkgs.Invoke[services.JWTService](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke services.jwt: %w", err)
+	}
+
+	return &authMiddleware{
+		di:   di,
+		aks:  apiKeyService,
+		jwts: jwtService,
+	}, nil
+}
+
+// Require autentica a requisição por um token JWT no header Authorization (Bearer) ou pela
+// chave do header X-API-Key e exige que a credencial tenha todos os escopos informados. A
+// credencial autenticada e o tenant da requisição ficam no contexto, e a credencial passa a ser
+// o autor registrado na auditoria, no lugar do header X-Actor. Deve ser registrado depois do
+// RequestContextMiddleware.
+//
+// Exemplo:
+//
+// e.POST("/clients", clientHandler.CreateClient, auth.Require(models.ScopeClientsWrite), idempotency.Handle)
+func (a *authMiddleware) Require(scopes ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ectx echo.Context) error {
+			logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+				slog.String("middleware", "auth"),
+				slog.String("path", ectx.Path()),
+			)
+
+			principal, err := a.authenticate(ectx)
+			if err != nil {
+				if errors.Is(err, models.ErrUnauthorized) {
+					logger.Warn("invalid credentials", "error", err)
+					a.challenge(ectx)
+					return err
+				}
+
+				logger.Error("error to authenticate request", "error", err)
+				return err
+			}
+
+			// A credencial verificada fica no contexto mesmo que falte escopo ou tenant, para que o
+			// limite anônimo não cobre a requisição do IP
+			ctx := pkgs.WithPrincipal(ectx.Request().Context(), principal)
+			ectx.SetRequest(ectx.Request().WithContext(ctx))
+
+			for _, scope := range scopes {
+				if !principal.HasScope(scope) {
+					logger.Warn("missing scope", "actor", principal.Actor(), "scope", scope)
+					return fmt.Errorf("%w: %s is required", models.ErrForbidden, scope)
+				}
+			}
+
+			tenant, err := resolveTenant(ectx, principal)
+			if err != nil {
+				logger.Warn("invalid tenant", "actor", principal.Actor(), "error", err)
+				return err
+			}
+
+			ctx = pkgs.WithActor(ctx, principal.Actor())
+			ctx = pkgs.WithTenant(ctx, tenant)
+			ectx.SetRequest(ectx.Request().WithContext(ctx))
+
+			return next(ectx)
+		}
+	}
+}
+
+func (a *authMiddleware) authenticate(ectx echo.Context) (*models.Principal, error) {
+	ctx := ectx.Request().Context()
+
+	if authorization := ectx.Request().Header.Get(echo.HeaderAuthorization); authorization != "" {
+		scheme, token, found := strings.Cut(authorization, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return nil, fmt.Errorf("%w: authorization header must use the bearer scheme", models.ErrUnauthorized)
+		}
+
+		return a.jwts.Authenticate(ctx, strings.TrimSpace(token))
+	}
+
+	if key := ectx.Request().Header.Get(models.HeaderAPIKey); key != "" {
+		return a.aks.Authenticate(ctx, key)
+	}
+
+	return nil, models.ErrUnauthorized
+}
+
+// challenge informa ao cliente como se autenticar (RFC 9110, seção 11.6.1)
+func (a *authMiddleware) challenge(ectx echo.Context) {
+	challenge := fmt.Sprintf(`ApiKey header=%q`, models.HeaderAPIKey)
+	if a.jwts.Enabled() {
+		challenge = "Bearer, " + challenge
+	}
+
+	ectx.Response().Header().Set(echo.HeaderWWWAuthenticate, challenge)
+}
+
+// resolveTenant retorna o tenant da credencial quando ela está vinculada a um, que não pode pedir
+// outro tenant pelo header. Uma credencial sem tenant só é aceita com models.ScopePlatform e deve
+// escolher o tenant pelo header X-Tenant-ID.
+func resolveTenant(ectx echo.Context, principal *models.Principal) (string, error) {
+	requested := ectx.Request().Header.Get(models.HeaderTenant)
+	if requested != "" && !models.IsValidTenant(requested) {
+		return "", models.NewValidationError(models.HeaderTenant, "is invalid")
+	}
+
+	if principal.TenantID != "" {
+		if requested != "" && requested != principal.TenantID {
+			return "", fmt.Errorf("%w: %s", models.ErrTenantForbidden, requested)
+		}
+
+		return principal.TenantID, nil
+	}
+
+	if !principal.HasScope(models.ScopePlatform) {
+		return "", fmt.Errorf("%w: credential is not bound to a tenant", models.ErrTenantForbidden)
+	}
+
+	if requested == "" {
+		return "", models.NewValidationError(models.HeaderTenant, "is required")
+	}
+
+	return requested, nil
+}
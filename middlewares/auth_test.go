@@ -0,0 +1,208 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/g-villarinho/nubank-challenge/mocks"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthMiddleware_Require(t *testing.T) {
+	e := echo.New()
+
+	t.Run("should authenticate the key and use it as the actor", func(t *testing.T) {
+		apiKeyService := new(mocks.APIKeyServiceMock)
+		middleware := &authMiddleware{aks: apiKeyService}
+
+		apiKeyService.On("Authenticate", mock.Anything, "nbk_key").Return(&models.Principal{
+			Method:   models.AuthMethodAPIKey,
+			Subject:  "backoffice",
+			Scopes:   []string{models.ScopeClientsRead, models.ScopeClientsWrite},
+			TenantID: "retail",
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/clients", nil)
+		req.Header.Set(models.HeaderAPIKey, "nbk_key")
+		req.Header.Set(models.HeaderActor, "someone-else")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		var actor string
+		var principal *models.Principal
+		err := middleware.Require(models.ScopeClientsWrite)(func(ectx echo.Context) error {
+			actor = pkgs.ActorFromContext(ectx.Request().Context())
+			principal = pkgs.PrincipalFromContext(ectx.Request().Context())
+			return nil
+		})(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "api-key:backoffice", actor)
+		assert.Equal(t, "backoffice", principal.Subject)
+	})
+
+	t.Run("should authenticate the bearer token and use its subject as the actor", func(t *testing.T) {
+		jwtService := new(mocks.JWTServiceMock)
+		middleware := &authMiddleware{jwts: jwtService}
+
+		jwtService.On("Authenticate", mock.Anything, "eyJ.token").Return(&models.Principal{
+			Method:   models.AuthMethodJWT,
+			Subject:  "billing",
+			Scopes:   []string{models.ScopeClientsRead},
+			TenantID: "retail",
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/clients", nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer eyJ.token")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		var actor string
+		err := middleware.Require(models.ScopeClientsRead)(func(ectx echo.Context) error {
+			actor = pkgs.ActorFromContext(ectx.Request().Context())
+			return nil
+		})(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "jwt:billing", actor)
+	})
+
+	t.Run("should return unauthorized when the key is missing", func(t *testing.T) {
+		jwtService := new(mocks.JWTServiceMock)
+		middleware := &authMiddleware{jwts: jwtService}
+
+		jwtService.On("Enabled").Return(false)
+
+		req := httptest.NewRequest(http.MethodGet, "/clients", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := middleware.Require(models.ScopeClientsRead)(func(ectx echo.Context) error {
+			t.Fatal("handler should not be called")
+			return nil
+		})(c)
+
+		assert.ErrorIs(t, err, models.ErrUnauthorized)
+		assert.Equal(t, `ApiKey header="X-API-Key"`, rec.Header().Get(echo.HeaderWWWAuthenticate))
+	})
+
+	t.Run("should offer the bearer scheme when tokens are accepted", func(t *testing.T) {
+		jwtService := new(mocks.JWTServiceMock)
+		middleware := &authMiddleware{jwts: jwtService}
+
+		jwtService.On("Enabled").Return(true)
+
+		req := httptest.NewRequest(http.MethodGet, "/clients", nil)
+		req.Header.Set(echo.HeaderAuthorization, "Basic dXNlcjpwYXNz")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := middleware.Require(models.ScopeClientsRead)(func(ectx echo.Context) error { return nil })(c)
+
+		assert.ErrorIs(t, err, models.ErrUnauthorized)
+		assert.Equal(t, `Bearer, ApiKey header="X-API-Key"`, rec.Header().Get(echo.HeaderWWWAuthenticate))
+	})
+
+	t.Run("should return unauthorized when the key is invalid", func(t *testing.T) {
+		apiKeyService := new(mocks.APIKeyServiceMock)
+		jwtService := new(mocks.JWTServiceMock)
+		middleware := &authMiddleware{aks: apiKeyService, jwts: jwtService}
+
+		apiKeyService.On("Authenticate", mock.Anything, "nbk_revoked").Return(nil, models.ErrUnauthorized)
+		jwtService.On("Enabled").Return(false)
+
+		req := httptest.NewRequest(http.MethodGet, "/clients", nil)
+		req.Header.Set(models.HeaderAPIKey, "nbk_revoked")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := middleware.Require(models.ScopeClientsRead)(func(ectx echo.Context) error { return nil })(c)
+
+		assert.ErrorIs(t, err, models.ErrUnauthorized)
+	})
+
+	t.Run("should return forbidden when a scope is missing", func(t *testing.T) {
+		apiKeyService := new(mocks.APIKeyServiceMock)
+		middleware := &authMiddleware{aks: apiKeyService}
+
+		apiKeyService.On("Authenticate", mock.Anything, "nbk_key").Return(&models.Principal{
+			Method:  models.AuthMethodAPIKey,
+			Subject: "reports",
+			Scopes:  []string{models.ScopeClientsRead},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/clients/1/contacts", nil)
+		req.Header.Set(models.HeaderAPIKey, "nbk_key")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := middleware.Require(models.ScopeClientsRead, models.ScopeContactsRead)(func(ectx echo.Context) error {
+			t.Fatal("handler should not be called")
+			return nil
+		})(c)
+
+		assert.ErrorIs(t, err, models.ErrForbidden)
+		assert.EqualError(t, err, fmt.Sprintf("%v: contacts:read is required", models.ErrForbidden))
+	})
+
+	t.Run("should resolve the tenant of the request", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			bound    string
+			scopes   []string
+			header   string
+			expected string
+			err      error
+		}{
+			{name: "tenant chosen by a platform credential", scopes: []string{models.ScopePlatform}, header: "retail", expected: "retail"},
+			{name: "platform credential without the header", scopes: []string{models.ScopePlatform}, err: models.ErrValidation},
+			{name: "unbound credential without the platform scope", header: "retail", err: models.ErrTenantForbidden},
+			{name: "tenant of a bound credential", bound: "retail", expected: "retail"},
+			{name: "same tenant in the header", bound: "retail", header: "retail", expected: "retail"},
+			{name: "another tenant in the header", bound: "retail", header: "wholesale", err: models.ErrTenantForbidden},
+			{name: "invalid tenant in the header", scopes: []string{models.ScopePlatform}, header: "Retail;DROP", err: models.ErrValidation},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				apiKeyService := new(mocks.APIKeyServiceMock)
+				middleware := &authMiddleware{aks: apiKeyService}
+
+				apiKeyService.On("Authenticate", mock.Anything, "nbk_key").Return(&models.Principal{
+					Method:   models.AuthMethodAPIKey,
+					Subject:  "backoffice",
+					Scopes:   append([]string{models.ScopeClientsRead}, tt.scopes...),
+					TenantID: tt.bound,
+				}, nil)
+
+				req := httptest.NewRequest(http.MethodGet, "/clients", nil)
+				req.Header.Set(models.HeaderAPIKey, "nbk_key")
+				if tt.header != "" {
+					req.Header.Set(models.HeaderTenant, tt.header)
+				}
+				rec := httptest.NewRecorder()
+				c := e.NewContext(req, rec)
+
+				var tenant string
+				err := middleware.Require(models.ScopeClientsRead)(func(ectx echo.Context) error {
+					tenant = pkgs.TenantFromContext(ectx.Request().Context())
+					return nil
+				})(c)
+
+				if tt.err != nil {
+					assert.ErrorIs(t, err, tt.err)
+					return
+				}
+
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, tenant)
+			})
+		}
+	})
+}
@@ -0,0 +1,176 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/services"
+	"github.com/labstack/echo/v4"
+)
+
+type IdempotencyMiddleware interface {
+	Handle(next echo.HandlerFunc) echo.HandlerFunc
+}
+
+type idempotencyMiddleware struct {
+	di *pkgs.Di
+	is services.IdempotencyService
+}
+
+func NewIdempotencyMiddleware(di *pkgs.Di) (IdempotencyMiddleware, error) {
+	idempotencyService, err := pkgs.Invoke[services.IdempotencyService](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke services.idempotency: %w", err)
+	}
+
+	return &idempotencyMiddleware{
+		di: di,
+		is: idempotencyService,
+	}, nil
+}
+
+// Handle honra o header Idempotency-Key: a primeira requisição com a chave é processada e sua
+// resposta armazenada; as repetições com o mesmo corpo recebem a resposta original.
+// Requisições sem o header seguem sem alteração.
+//
+// Exemplo:
+//
+// e.POST("/clients", clientHandler.CreateClient, idempotency.Handle)
+func (i *idempotencyMiddleware) Handle(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ectx echo.Context) error {
+		key := ectx.Request().Header.Get(models.HeaderIdempotencyKey)
+		if key == "" {
+			return next(ectx)
+		}
+
+		logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+			slog.String("middleware", "idempotency"),
+			slog.String("path", ectx.Path()),
+		)
+
+		if len(key) > models.MaxIdempotencyKeyLength {
+			return models.NewValidationError(models.HeaderIdempotencyKey, fmt.Sprintf("must be at most %d characters", models.MaxIdempotencyKeyLength))
+		}
+
+		body, err := io.ReadAll(ectx.Request().Body)
+		if err != nil {
+			logger.Error("read body", slog.Any("error", err))
+			return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+		}
+		ectx.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := ectx.Request().Context()
+		scope := ectx.Request().Method + " " + ectx.Path()
+
+		// Tenants diferentes podem gerar a mesma chave sem que um receba a resposta do outro
+		if tenant := pkgs.TenantFromContext(ctx); tenant != "" {
+			scope = tenant + " " + scope
+		}
+
+		// A resposta fica retida até a transação da chave terminar, para que o cliente não receba
+		// uma resposta de sucesso de uma criação que acabou desfeita
+		res := ectx.Response()
+		writer := res.Writer
+		recorder := newResponseRecorder(writer.Header())
+		res.Writer = recorder
+
+		req := ectx.Request()
+		stored, err := i.is.Execute(ctx, scope, key, fingerprint(req, body), func(ctx context.Context) (*models.IdempotentResponse, error) {
+			ectx.SetRequest(req.WithContext(ctx))
+			if err := next(ectx); err != nil {
+				return nil, err
+			}
+
+			// Respostas 5xx não são armazenadas para que o cliente possa tentar novamente
+			if !res.Committed || res.Status >= http.StatusInternalServerError {
+				return nil, nil
+			}
+
+			return &models.IdempotentResponse{
+				StatusCode:  res.Status,
+				ContentType: recorder.header.Get(echo.HeaderContentType),
+				Body:        recorder.body.Bytes(),
+			}, nil
+		})
+
+		// A transação já terminou e não pode seguir no contexto da requisição
+		ectx.SetRequest(req)
+		res.Writer = writer
+
+		if err != nil {
+			logger.Warn("execute idempotent request", slog.Any("error", err))
+
+			// Descarta a resposta retida para que o erro seja respondido no lugar dela
+			res.Committed = false
+			res.Status = http.StatusOK
+			res.Size = 0
+			return err
+		}
+
+		if stored != nil {
+			res.Header().Set(models.HeaderIdempotentReplayed, "true")
+			return ectx.Blob(stored.StatusCode, stored.ContentType, stored.ResponseBody)
+		}
+
+		if res.Committed {
+			recorder.flush(writer)
+		}
+
+		return nil
+	}
+}
+
+// fingerprint identifica a requisição pelo método, rota e corpo enviados com a chave
+func fingerprint(req *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(req.URL.Path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder retém a resposta do handler, com headers próprios, até que ela seja enviada
+// por flush
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseRecorder(header http.Header) *responseRecorder {
+	return &responseRecorder{header: header.Clone(), status: http.StatusOK}
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+// flush envia a resposta retida para w, substituindo os headers de w pelos do handler
+func (r *responseRecorder) flush(w http.ResponseWriter) {
+	header := w.Header()
+	clear(header)
+	maps.Copy(header, r.header)
+
+	w.WriteHeader(r.status)
+	_, _ = w.Write(r.body.Bytes())
+}
@@ -0,0 +1,258 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/g-villarinho/nubank-challenge/mocks"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIdempotencyMiddleware_Handle(t *testing.T) {
+	e := echo.New()
+	ctx := context.Background()
+	body := `{"name":"Gabriel"}`
+
+	newContext := func(key string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodPost, "/clients", bytes.NewBufferString(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if key != "" {
+			req.Header.Set(models.HeaderIdempotencyKey, key)
+		}
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req.WithContext(ctx), rec)
+		c.SetPath("/clients")
+
+		return c, rec
+	}
+
+	created := func(ectx echo.Context) error {
+		payload, err := io.ReadAll(ectx.Request().Body)
+		if err != nil {
+			return err
+		}
+
+		return ectx.JSONBlob(http.StatusCreated, payload)
+	}
+
+	// run simula a transação do serviço executando o handler e devolvendo o erro de fn
+	run := func(t *testing.T, expected *models.IdempotentResponse) func(context.Context, string, string, string, func(context.Context) (*models.IdempotentResponse, error)) (*models.IdempotencyKey, error) {
+		return func(ctx context.Context, scope string, key string, fingerprint string, fn func(context.Context) (*models.IdempotentResponse, error)) (*models.IdempotencyKey, error) {
+			response, err := fn(ctx)
+			assert.Equal(t, expected, response)
+			return nil, err
+		}
+	}
+
+	t.Run("should skip requests without the header", func(t *testing.T) {
+		idempotencyService := new(mocks.IdempotencyServiceMock)
+		middleware := &idempotencyMiddleware{is: idempotencyService}
+
+		c, rec := newContext("")
+
+		err := middleware.Handle(created)(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		idempotencyService.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should process the first request and store its response", func(t *testing.T) {
+		idempotencyService := new(mocks.IdempotencyServiceMock)
+		middleware := &idempotencyMiddleware{is: idempotencyService}
+
+		c, rec := newContext("key-1")
+
+		idempotencyService.
+			On("Execute", ctx, "POST /clients", "key-1", mock.Anything, mock.Anything).
+			Return(run(t, &models.IdempotentResponse{StatusCode: http.StatusCreated, ContentType: echo.MIMEApplicationJSON, Body: []byte(body)}))
+
+		err := middleware.Handle(created)(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, echo.MIMEApplicationJSON, rec.Header().Get(echo.HeaderContentType))
+		assert.JSONEq(t, body, rec.Body.String())
+		idempotencyService.AssertExpectations(t)
+	})
+
+	t.Run("should replay the stored response without calling the handler", func(t *testing.T) {
+		idempotencyService := new(mocks.IdempotencyServiceMock)
+		middleware := &idempotencyMiddleware{is: idempotencyService}
+
+		c, rec := newContext("key-1")
+
+		idempotencyService.
+			On("Execute", ctx, "POST /clients", "key-1", mock.Anything, mock.Anything).
+			Return(&models.IdempotencyKey{StatusCode: http.StatusCreated, ContentType: echo.MIMEApplicationJSON, ResponseBody: []byte(`{"id":"client-1"}`)}, nil)
+
+		err := middleware.Handle(func(ectx echo.Context) error {
+			t.Fatal("handler must not be called on replay")
+			return nil
+		})(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "true", rec.Header().Get(models.HeaderIdempotentReplayed))
+		assert.JSONEq(t, `{"id":"client-1"}`, rec.Body.String())
+	})
+
+	t.Run("should use the same fingerprint for the same request", func(t *testing.T) {
+		idempotencyService := new(mocks.IdempotencyServiceMock)
+		middleware := &idempotencyMiddleware{is: idempotencyService}
+
+		var fingerprints []string
+		idempotencyService.
+			On("Execute", ctx, "POST /clients", "key-1", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { fingerprints = append(fingerprints, args.String(3)) }).
+			Return(nil, models.ErrIdempotencyKeyInProgress)
+
+		for range 2 {
+			c, _ := newContext("key-1")
+			err := middleware.Handle(created)(c)
+			assert.ErrorIs(t, err, models.ErrIdempotencyKeyInProgress)
+		}
+
+		assert.Len(t, fingerprints, 2)
+		assert.Equal(t, fingerprints[0], fingerprints[1])
+	})
+
+	t.Run("should return the error when the key was reused with another body", func(t *testing.T) {
+		idempotencyService := new(mocks.IdempotencyServiceMock)
+		middleware := &idempotencyMiddleware{is: idempotencyService}
+
+		c, _ := newContext("key-1")
+
+		idempotencyService.
+			On("Execute", ctx, "POST /clients", "key-1", mock.Anything, mock.Anything).
+			Return(nil, models.ErrIdempotencyKeyReused)
+
+		err := middleware.Handle(created)(c)
+
+		assert.ErrorIs(t, err, models.ErrIdempotencyKeyReused)
+	})
+
+	t.Run("should return the error of the handler without storing a response", func(t *testing.T) {
+		idempotencyService := new(mocks.IdempotencyServiceMock)
+		middleware := &idempotencyMiddleware{is: idempotencyService}
+
+		c, _ := newContext("key-1")
+
+		idempotencyService.
+			On("Execute", ctx, "POST /clients", "key-1", mock.Anything, mock.Anything).
+			Return(run(t, nil))
+
+		err := middleware.Handle(func(ectx echo.Context) error {
+			return errors.New("db failure")
+		})(c)
+
+		assert.EqualError(t, err, "db failure")
+		idempotencyService.AssertExpectations(t)
+	})
+
+	t.Run("should not store server errors", func(t *testing.T) {
+		idempotencyService := new(mocks.IdempotencyServiceMock)
+		middleware := &idempotencyMiddleware{is: idempotencyService}
+
+		c, rec := newContext("key-1")
+
+		idempotencyService.
+			On("Execute", ctx, "POST /clients", "key-1", mock.Anything, mock.Anything).
+			Return(run(t, nil))
+
+		err := middleware.Handle(func(ectx echo.Context) error {
+			return ectx.JSON(http.StatusServiceUnavailable, map[string]string{"title": "unavailable"})
+		})(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.JSONEq(t, `{"title":"unavailable"}`, rec.Body.String())
+	})
+
+	t.Run("should discard the response when the transaction of the key fails", func(t *testing.T) {
+		idempotencyService := new(mocks.IdempotencyServiceMock)
+		middleware := &idempotencyMiddleware{is: idempotencyService}
+
+		c, rec := newContext("key-1")
+
+		idempotencyService.
+			On("Execute", ctx, "POST /clients", "key-1", mock.Anything, mock.Anything).
+			Return(func(ctx context.Context, scope string, key string, fingerprint string, fn func(context.Context) (*models.IdempotentResponse, error)) (*models.IdempotencyKey, error) {
+				if _, err := fn(ctx); err != nil {
+					return nil, err
+				}
+
+				return nil, errors.New("commit failure")
+			})
+
+		err := middleware.Handle(created)(c)
+
+		assert.EqualError(t, err, "commit failure")
+		assert.False(t, c.Response().Committed)
+		assert.Empty(t, rec.Body.String())
+		assert.Empty(t, rec.Header().Get(echo.HeaderContentType))
+	})
+
+	t.Run("should restore the request context after the transaction", func(t *testing.T) {
+		idempotencyService := new(mocks.IdempotencyServiceMock)
+		middleware := &idempotencyMiddleware{is: idempotencyService}
+
+		c, _ := newContext("key-1")
+		type txKey struct{}
+		txCtx := context.WithValue(ctx, txKey{}, "tx")
+
+		idempotencyService.
+			On("Execute", ctx, "POST /clients", "key-1", mock.Anything, mock.Anything).
+			Return(func(_ context.Context, scope string, key string, fingerprint string, fn func(context.Context) (*models.IdempotentResponse, error)) (*models.IdempotencyKey, error) {
+				_, err := fn(txCtx)
+				return nil, err
+			})
+
+		err := middleware.Handle(func(ectx echo.Context) error {
+			assert.Equal(t, txCtx, ectx.Request().Context())
+			return ectx.NoContent(http.StatusCreated)
+		})(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, ctx, c.Request().Context())
+	})
+
+	t.Run("should reject keys that are too long", func(t *testing.T) {
+		idempotencyService := new(mocks.IdempotencyServiceMock)
+		middleware := &idempotencyMiddleware{is: idempotencyService}
+
+		c, _ := newContext(string(bytes.Repeat([]byte("k"), models.MaxIdempotencyKeyLength+1)))
+
+		err := middleware.Handle(created)(c)
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+	})
+
+	t.Run("should keep the keys of each tenant apart", func(t *testing.T) {
+		idempotencyService := new(mocks.IdempotencyServiceMock)
+		middleware := &idempotencyMiddleware{is: idempotencyService}
+
+		c, _ := newContext("key-1")
+		tenantCtx := pkgs.WithTenant(ctx, "tenant-a")
+		c.SetRequest(c.Request().WithContext(tenantCtx))
+
+		idempotencyService.
+			On("Execute", tenantCtx, "tenant-a POST /clients", "key-1", mock.Anything, mock.Anything).
+			Return(run(t, &models.IdempotentResponse{StatusCode: http.StatusCreated, ContentType: echo.MIMEApplicationJSON, Body: []byte(body)}))
+
+		err := middleware.Handle(created)(c)
+
+		assert.NoError(t, err)
+		idempotencyService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,68 @@
+package middlewares
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/metrics"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/labstack/echo/v4"
+)
+
+// unmatchedRoute agrupa as requisições que não casaram com nenhuma rota, para que caminhos
+// arbitrários não criem uma série por URL
+const unmatchedRoute = "unmatched"
+
+type MetricsMiddleware interface {
+	Handle(next echo.HandlerFunc) echo.HandlerFunc
+}
+
+type metricsMiddleware struct {
+	di *pkgs.Di
+	m  *metrics.Metrics
+}
+
+func NewMetricsMiddleware(di *pkgs.Di) (MetricsMiddleware, error) {
+	m, err := pkgs.Invoke[*metrics.Metrics](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke metrics: %w", err)
+	}
+
+	return &metricsMiddleware{
+		di: di,
+		m:  m,
+	}, nil
+}
+
+// Handle conta as requisições e mede a latência por método, rota e status. A rota é o padrão
+// registrado (ex.: /clients/:clientId), não a URL. Os erros são respondidos aqui pelo
+// HTTPErrorHandler para que o status medido seja o enviado ao cliente. Deve ser registrado
+// logo depois do RequestLoggerMiddleware para medir também os demais.
+//
+// Exemplo:
+//
+// e.Use(requestLogger.Handle)
+// e.Use(metrics.Handle)
+func (m *metricsMiddleware) Handle(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ectx echo.Context) error {
+		start := time.Now()
+
+		if err := next(ectx); err != nil {
+			ectx.Error(err)
+		}
+
+		method := ectx.Request().Method
+		status := strconv.Itoa(ectx.Response().Status)
+
+		route := ectx.Path()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		m.m.HTTPRequests.WithLabelValues(method, route, status).Inc()
+		m.m.HTTPRequestDuration.WithLabelValues(method, route, status).Observe(time.Since(start).Seconds())
+
+		return nil
+	}
+}
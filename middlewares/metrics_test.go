@@ -0,0 +1,66 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/g-villarinho/nubank-challenge/handlers"
+	"github.com/g-villarinho/nubank-challenge/metrics"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsMiddleware_Handle(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = handlers.HTTPErrorHandler
+	m, err := metrics.NewMetrics(nil)
+	require.NoError(t, err)
+	middleware := &metricsMiddleware{m: m}
+
+	e.Use(middleware.Handle)
+	e.GET("/metrics-test/:id", func(ectx echo.Context) error {
+		if ectx.Param("id") == "missing" {
+			return models.ErrClientNotFound
+		}
+
+		return ectx.NoContent(http.StatusOK)
+	})
+
+	t.Run("should count the request by route pattern and status", func(t *testing.T) {
+		counter := m.HTTPRequests.WithLabelValues(http.MethodGet, "/metrics-test/:id", "200")
+		before := testutil.ToFloat64(counter)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics-test/1", nil))
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics-test/2", nil))
+
+		assert.Equal(t, before+2, testutil.ToFloat64(counter))
+	})
+
+	t.Run("should count the status written by the error handler", func(t *testing.T) {
+		counter := m.HTTPRequests.WithLabelValues(http.MethodGet, "/metrics-test/:id", "404")
+		before := testutil.ToFloat64(counter)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics-test/missing", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "application/problem+json", rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, before+1, testutil.ToFloat64(counter))
+	})
+
+	t.Run("should group the requests that match no route", func(t *testing.T) {
+		counter := m.HTTPRequests.WithLabelValues(http.MethodGet, unmatchedRoute, "404")
+		before := testutil.ToFloat64(counter)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/does-not-exist", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, before+1, testutil.ToFloat64(counter))
+	})
+}
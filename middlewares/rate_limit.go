@@ -0,0 +1,130 @@
+package middlewares
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/configs"
+	"github.com/g-villarinho/nubank-challenge/limiters"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/labstack/echo/v4"
+)
+
+type RateLimitMiddleware interface {
+	Limit(group string) echo.MiddlewareFunc
+}
+
+type rateLimitMiddleware struct {
+	di       *pkgs.Di
+	l        limiters.Limiter
+	enabled  bool
+	policies map[string]models.RateLimitPolicy
+}
+
+func NewRateLimitMiddleware(di *pkgs.Di) (RateLimitMiddleware, error) {
+	limiter, err := pkgs.Invoke[limiters.Limiter](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke limiters.limiter: %w", err)
+	}
+
+	return &rateLimitMiddleware{
+		di:       di,
+		l:        limiter,
+		enabled:  configs.Env.RateLimit.Enabled,
+		policies: configs.Env.RateLimit.Policies(),
+	}, nil
+}
+
+// Limit aplica a política do grupo de rotas a cada credencial, ou ao IP quando a requisição ainda
+// não foi autenticada, e informa o estado do balde nos headers RateLimit-*. Ao esgotar o balde,
+// responde 429 com Retry-After. O grupo models.RateLimitGroupAnon é registrado antes do
+// AuthMiddleware, para barrar por IP quem tenta credenciais inválidas: ele consulta o balde sem
+// consumir e só cobra o token depois, das requisições que não se autenticaram. Os demais grupos
+// ficam depois do AuthMiddleware, para que o balde seja o da credencial.
+//
+// Exemplo:
+//
+// e.GET("/clients", handler, rateLimit.Limit(models.RateLimitGroupAnon), auth.Require(models.ScopeClientsRead), rateLimit.Limit(models.RateLimitGroupRead))
+func (r *rateLimitMiddleware) Limit(group string) echo.MiddlewareFunc {
+	policy, ok := r.policies[group]
+	if !ok {
+		panic(fmt.Sprintf("unknown rate limit group %q", group))
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if !r.enabled {
+			return next
+		}
+
+		return func(ectx echo.Context) error {
+			logger := pkgs.LoggerFromContext(ectx.Request().Context()).With(
+				slog.String("middleware", "rate_limit"),
+				slog.String("group", group),
+			)
+
+			ctx := ectx.Request().Context()
+			key := rateLimitKey(ectx, group)
+			anon := group == models.RateLimitGroupAnon
+
+			check := r.l.Allow
+			if anon {
+				check = r.l.Peek
+			}
+
+			result, err := check(ctx, key, policy)
+			if err != nil {
+				// Uma falha no store não deve derrubar a API; a requisição segue sem limite
+				logger.Error("error to check rate limit", "error", err)
+				return next(ectx)
+			}
+
+			setRateLimitHeaders(ectx, result)
+
+			if !result.Allowed {
+				ectx.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(max(seconds(result.RetryAfter), 1)))
+				logger.Warn("rate limit exceeded", "path", ectx.Path())
+				return models.ErrTooManyRequests
+			}
+
+			err = next(ectx)
+
+			// O balde do IP só é cobrado de quem não se autenticou; com a credencial verificada,
+			// a requisição já foi cobrada no balde dela
+			if anon && pkgs.PrincipalFromContext(ectx.Request().Context()) == nil {
+				charged, chargeErr := r.l.Allow(ctx, key, policy)
+				if chargeErr != nil {
+					logger.Error("error to charge rate limit token", "error", chargeErr)
+				} else if !ectx.Response().Committed {
+					setRateLimitHeaders(ectx, charged)
+				}
+			}
+
+			return err
+		}
+	}
+}
+
+// setRateLimitHeaders informa nos headers RateLimit-* o estado do balde
+func setRateLimitHeaders(ectx echo.Context, result *models.RateLimitResult) {
+	header := ectx.Response().Header()
+	header.Set(models.HeaderRateLimitLimit, strconv.Itoa(result.Limit))
+	header.Set(models.HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+	header.Set(models.HeaderRateLimitReset, strconv.Itoa(seconds(result.Reset)))
+}
+
+// rateLimitKey identifica o balde pela credencial autenticada ou, antes da autenticação, pelo IP de origem
+func rateLimitKey(ectx echo.Context, group string) string {
+	if principal := pkgs.PrincipalFromContext(ectx.Request().Context()); principal != nil {
+		return group + ":" + principal.Method + ":" + principal.ID
+	}
+
+	return group + ":ip:" + ectx.RealIP()
+}
+
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
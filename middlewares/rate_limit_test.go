@@ -0,0 +1,214 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/mocks"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRateLimitMiddleware_Limit(t *testing.T) {
+	e := echo.New()
+	policy := models.RateLimitPolicy{Burst: 100, PerMinute: 600}
+	policies := map[string]models.RateLimitPolicy{models.RateLimitGroupRead: policy}
+
+	t.Run("should use the credential bucket and report it in the headers", func(t *testing.T) {
+		limiter := new(mocks.LimiterMock)
+		middleware := &rateLimitMiddleware{l: limiter, enabled: true, policies: policies}
+
+		limiter.On("Allow", mock.Anything, "read:api-key:key-1", policy).Return(&models.RateLimitResult{
+			Allowed:   true,
+			Limit:     100,
+			Remaining: 99,
+			Reset:     100 * time.Millisecond,
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/clients", nil)
+		req = req.WithContext(pkgs.WithPrincipal(req.Context(), &models.Principal{Method: models.AuthMethodAPIKey, ID: "key-1"}))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		called := false
+		err := middleware.Limit(models.RateLimitGroupRead)(func(ectx echo.Context) error {
+			called = true
+			return nil
+		})(c)
+
+		assert.NoError(t, err)
+		assert.True(t, called)
+		assert.Equal(t, "100", rec.Header().Get(models.HeaderRateLimitLimit))
+		assert.Equal(t, "99", rec.Header().Get(models.HeaderRateLimitRemaining))
+		assert.Equal(t, "1", rec.Header().Get(models.HeaderRateLimitReset))
+		assert.Empty(t, rec.Header().Get(echo.HeaderRetryAfter))
+		limiter.AssertExpectations(t)
+	})
+
+	t.Run("should use the client ip when there is no credential", func(t *testing.T) {
+		limiter := new(mocks.LimiterMock)
+		middleware := &rateLimitMiddleware{l: limiter, enabled: true, policies: policies}
+
+		limiter.On("Allow", mock.Anything, "read:ip:192.0.2.1", policy).Return(&models.RateLimitResult{Allowed: true, Limit: 100}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/clients", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := middleware.Limit(models.RateLimitGroupRead)(func(ectx echo.Context) error {
+			return nil
+		})(c)
+
+		assert.NoError(t, err)
+		limiter.AssertExpectations(t)
+	})
+
+	t.Run("should return too many requests with retry after when the bucket is empty", func(t *testing.T) {
+		limiter := new(mocks.LimiterMock)
+		middleware := &rateLimitMiddleware{l: limiter, enabled: true, policies: policies}
+
+		limiter.On("Allow", mock.Anything, mock.Anything, policy).Return(&models.RateLimitResult{
+			Allowed:    false,
+			Limit:      100,
+			Remaining:  0,
+			Reset:      10 * time.Second,
+			RetryAfter: 100 * time.Millisecond,
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/clients", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		called := false
+		err := middleware.Limit(models.RateLimitGroupRead)(func(ectx echo.Context) error {
+			called = true
+			return nil
+		})(c)
+
+		assert.ErrorIs(t, err, models.ErrTooManyRequests)
+		assert.False(t, called)
+		assert.Equal(t, "0", rec.Header().Get(models.HeaderRateLimitRemaining))
+		assert.Equal(t, "10", rec.Header().Get(models.HeaderRateLimitReset))
+		assert.Equal(t, "1", rec.Header().Get(echo.HeaderRetryAfter))
+	})
+
+	t.Run("should not charge the ip bucket when the credential is verified", func(t *testing.T) {
+		anonPolicy := models.RateLimitPolicy{Burst: 200, PerMinute: 1200}
+		limiter := new(mocks.LimiterMock)
+		middleware := &rateLimitMiddleware{l: limiter, enabled: true, policies: map[string]models.RateLimitPolicy{models.RateLimitGroupAnon: anonPolicy}}
+
+		limiter.On("Peek", mock.Anything, "anon:ip:192.0.2.1", anonPolicy).Return(&models.RateLimitResult{Allowed: true, Limit: 200}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/clients", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := middleware.Limit(models.RateLimitGroupAnon)(func(ectx echo.Context) error {
+			ctx := pkgs.WithPrincipal(ectx.Request().Context(), &models.Principal{Method: models.AuthMethodAPIKey, ID: "key-1"})
+			ectx.SetRequest(ectx.Request().WithContext(ctx))
+			return nil
+		})(c)
+
+		assert.NoError(t, err)
+		limiter.AssertExpectations(t)
+		limiter.AssertNotCalled(t, "Allow", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should charge the ip bucket when the request is not authenticated", func(t *testing.T) {
+		anonPolicy := models.RateLimitPolicy{Burst: 200, PerMinute: 1200}
+		limiter := new(mocks.LimiterMock)
+		middleware := &rateLimitMiddleware{l: limiter, enabled: true, policies: map[string]models.RateLimitPolicy{models.RateLimitGroupAnon: anonPolicy}}
+
+		limiter.On("Peek", mock.Anything, "anon:ip:192.0.2.1", anonPolicy).Return(&models.RateLimitResult{Allowed: true, Limit: 200, Remaining: 5}, nil)
+		limiter.On("Allow", mock.Anything, "anon:ip:192.0.2.1", anonPolicy).Return(&models.RateLimitResult{Allowed: true, Limit: 200, Remaining: 4}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/clients", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := middleware.Limit(models.RateLimitGroupAnon)(func(ectx echo.Context) error {
+			return models.ErrUnauthorized
+		})(c)
+
+		assert.ErrorIs(t, err, models.ErrUnauthorized)
+		assert.Equal(t, "4", rec.Header().Get(models.HeaderRateLimitRemaining))
+		limiter.AssertExpectations(t)
+	})
+
+	t.Run("should block the ip without calling the handler when the anon bucket is empty", func(t *testing.T) {
+		anonPolicy := models.RateLimitPolicy{Burst: 200, PerMinute: 1200}
+		limiter := new(mocks.LimiterMock)
+		middleware := &rateLimitMiddleware{l: limiter, enabled: true, policies: map[string]models.RateLimitPolicy{models.RateLimitGroupAnon: anonPolicy}}
+
+		limiter.On("Peek", mock.Anything, "anon:ip:192.0.2.1", anonPolicy).Return(&models.RateLimitResult{
+			Allowed:    false,
+			Limit:      200,
+			RetryAfter: 50 * time.Millisecond,
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/clients", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		called := false
+		err := middleware.Limit(models.RateLimitGroupAnon)(func(ectx echo.Context) error {
+			called = true
+			return nil
+		})(c)
+
+		assert.ErrorIs(t, err, models.ErrTooManyRequests)
+		assert.False(t, called)
+		assert.Equal(t, "1", rec.Header().Get(echo.HeaderRetryAfter))
+		limiter.AssertNotCalled(t, "Allow", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should let the request through when the store fails", func(t *testing.T) {
+		limiter := new(mocks.LimiterMock)
+		middleware := &rateLimitMiddleware{l: limiter, enabled: true, policies: policies}
+
+		limiter.On("Allow", mock.Anything, mock.Anything, policy).Return(nil, assert.AnError)
+
+		req := httptest.NewRequest(http.MethodGet, "/clients", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		called := false
+		err := middleware.Limit(models.RateLimitGroupRead)(func(ectx echo.Context) error {
+			called = true
+			return nil
+		})(c)
+
+		assert.NoError(t, err)
+		assert.True(t, called)
+		assert.Empty(t, rec.Header().Get(models.HeaderRateLimitLimit))
+	})
+
+	t.Run("should not check the limiter when rate limiting is disabled", func(t *testing.T) {
+		limiter := new(mocks.LimiterMock)
+		middleware := &rateLimitMiddleware{l: limiter, enabled: false, policies: policies}
+
+		req := httptest.NewRequest(http.MethodGet, "/clients", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := middleware.Limit(models.RateLimitGroupRead)(func(ectx echo.Context) error {
+			return nil
+		})(c)
+
+		assert.NoError(t, err)
+		limiter.AssertNotCalled(t, "Allow", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should panic on an unknown group", func(t *testing.T) {
+		middleware := &rateLimitMiddleware{enabled: true, policies: policies}
+
+		assert.Panics(t, func() {
+			middleware.Limit("unknown")
+		})
+	})
+}
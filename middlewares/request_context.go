@@ -0,0 +1,45 @@
+package middlewares
+
+import (
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/labstack/echo/v4"
+)
+
+type RequestContextMiddleware interface {
+	Handle(next echo.HandlerFunc) echo.HandlerFunc
+}
+
+type requestContextMiddleware struct {
+	di *pkgs.Di
+}
+
+func NewRequestContextMiddleware(di *pkgs.Di) (RequestContextMiddleware, error) {
+	return &requestContextMiddleware{
+		di: di,
+	}, nil
+}
+
+// Handle guarda no contexto da requisição quem a está realizando, lido do header X-Actor, para
+// que as alterações sejam registradas na auditoria. O ID da requisição é guardado pelo
+// RequestLoggerMiddleware.
+//
+// Exemplo:
+//
+// e.Use(requestLogger.Handle)
+// e.Use(requestContext.Handle)
+func (r *requestContextMiddleware) Handle(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ectx echo.Context) error {
+		ctx := ectx.Request().Context()
+
+		actor := ectx.Request().Header.Get(models.HeaderActor)
+		if actor == "" {
+			actor = models.AnonymousActor
+		}
+		ctx = pkgs.WithActor(ctx, actor)
+
+		ectx.SetRequest(ectx.Request().WithContext(ctx))
+
+		return next(ectx)
+	}
+}
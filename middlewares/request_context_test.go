@@ -0,0 +1,48 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestContextMiddleware_Handle(t *testing.T) {
+	e := echo.New()
+	middleware := &requestContextMiddleware{}
+
+	t.Run("should store the actor in the context", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/clients", nil)
+		req.Header.Set(models.HeaderActor, "backoffice")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		var actor string
+		err := middleware.Handle(func(ectx echo.Context) error {
+			actor = pkgs.ActorFromContext(ectx.Request().Context())
+			return nil
+		})(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "backoffice", actor)
+	})
+
+	t.Run("should use the anonymous actor when the header is missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/clients", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		var actor string
+		err := middleware.Handle(func(ectx echo.Context) error {
+			actor = pkgs.ActorFromContext(ectx.Request().Context())
+			return nil
+		})(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.AnonymousActor, actor)
+	})
+}
@@ -0,0 +1,91 @@
+package middlewares
+
+import (
+	"log/slog"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// requestIDPattern limita o X-Request-ID recebido, para que um valor arbitrário não seja
+// repassado aos logs e às respostas
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+type RequestLoggerMiddleware interface {
+	Handle(next echo.HandlerFunc) echo.HandlerFunc
+}
+
+type requestLoggerMiddleware struct {
+	di *pkgs.Di
+}
+
+func NewRequestLoggerMiddleware(di *pkgs.Di) (RequestLoggerMiddleware, error) {
+	return &requestLoggerMiddleware{
+		di: di,
+	}, nil
+}
+
+// Handle usa o X-Request-ID recebido, ou gera um, e o devolve na resposta. O ID é guardado no
+// contexto junto com um logger que já o inclui, para que os logs de handlers, serviços e
+// repositórios possam ser relacionados à requisição. Ao final, registra uma linha de acesso
+// com método, rota, status, latência e bytes enviados. Os erros são respondidos aqui pelo
+// HTTPErrorHandler para que a linha registre o status enviado. Deve ser registrado depois do
+// TracingMiddleware, para que o logger inclua o traceId.
+//
+// Exemplo:
+//
+// e.Use(tracing.Handle)
+// e.Use(requestLogger.Handle)
+func (r *requestLoggerMiddleware) Handle(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ectx echo.Context) error {
+		start := time.Now()
+		request := ectx.Request()
+
+		requestID := request.Header.Get(echo.HeaderXRequestID)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		ectx.Response().Header().Set(echo.HeaderXRequestID, requestID)
+
+		logger := slog.Default().With(slog.String("requestId", requestID))
+		if spanContext := trace.SpanContextFromContext(request.Context()); spanContext.IsValid() {
+			logger = logger.With(slog.String("traceId", spanContext.TraceID().String()))
+		}
+
+		ctx := pkgs.WithRequestID(request.Context(), requestID)
+		ctx = pkgs.WithLogger(ctx, logger)
+		ectx.SetRequest(request.WithContext(ctx))
+
+		if err := next(ectx); err != nil {
+			ectx.Error(err)
+		}
+
+		route := ectx.Path()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		status := ectx.Response().Status
+
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+
+		logger.LogAttrs(ctx, level, "request completed",
+			slog.String("method", request.Method),
+			slog.String("route", route),
+			slog.Int("status", status),
+			slog.Float64("latencyMs", float64(time.Since(start).Microseconds())/1000),
+			slog.Int64("bytes", ectx.Response().Size),
+		)
+
+		return nil
+	}
+}
@@ -0,0 +1,80 @@
+package middlewares
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/g-villarinho/nubank-challenge/handlers"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestLoggerMiddleware_Handle(t *testing.T) {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(pkgs.NewRedactHandler(slog.NewTextHandler(&buf, nil))))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	e := echo.New()
+	e.HTTPErrorHandler = handlers.HTTPErrorHandler
+	middleware := &requestLoggerMiddleware{}
+
+	var requestID string
+	e.Use(middleware.Handle)
+	e.GET("/logger-test/:id", func(ectx echo.Context) error {
+		ctx := ectx.Request().Context()
+		requestID = pkgs.RequestIDFromContext(ctx)
+		pkgs.LoggerFromContext(ctx).Warn("looking up contact gabriel@gmail.com")
+
+		if ectx.Param("id") == "missing" {
+			return models.ErrContactNotFound
+		}
+
+		return ectx.String(http.StatusOK, "ok")
+	})
+
+	t.Run("should propagate the request id to the context, the response and the logs", func(t *testing.T) {
+		buf.Reset()
+		req := httptest.NewRequest(http.MethodGet, "/logger-test/1", nil)
+		req.Header.Set(echo.HeaderXRequestID, "request-123")
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		out := buf.String()
+		assert.Equal(t, "request-123", requestID)
+		assert.Equal(t, "request-123", rec.Header().Get(echo.HeaderXRequestID))
+		assert.Contains(t, out, `msg="looking up contact [redacted-email]" requestId=request-123`)
+		assert.Contains(t, out, `msg="request completed" requestId=request-123 method=GET route=/logger-test/:id status=200`)
+		assert.Contains(t, out, "bytes=2")
+		assert.NotContains(t, out, "gabriel@gmail.com")
+	})
+
+	t.Run("should generate a request id when the header is missing or invalid", func(t *testing.T) {
+		for _, header := range []string{"", "bad id\nwith newline"} {
+			req := httptest.NewRequest(http.MethodGet, "/logger-test/1", nil)
+			req.Header.Set(echo.HeaderXRequestID, header)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Len(t, requestID, 36)
+			assert.Equal(t, requestID, rec.Header().Get(echo.HeaderXRequestID))
+		}
+	})
+
+	t.Run("should log the status written by the error handler", func(t *testing.T) {
+		buf.Reset()
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/logger-test/missing", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, buf.String(), "status=404")
+	})
+}
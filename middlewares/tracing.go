@@ -0,0 +1,73 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/tracing"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TracingMiddleware interface {
+	Handle(next echo.HandlerFunc) echo.HandlerFunc
+}
+
+type tracingMiddleware struct {
+	di *pkgs.Di
+}
+
+func NewTracingMiddleware(di *pkgs.Di) (TracingMiddleware, error) {
+	return &tracingMiddleware{
+		di: di,
+	}, nil
+}
+
+// Handle abre o span da requisição, continuando o trace do traceparent recebido, e o guarda
+// no contexto para que os spans dos serviços e das consultas fiquem abaixo dele. Os erros são
+// respondidos aqui pelo HTTPErrorHandler para que o span registre o status enviado. Deve ser
+// o primeiro middleware registrado para que o span cubra os demais.
+//
+// Exemplo:
+//
+// e.Use(tracing.Handle)
+// e.Use(requestLogger.Handle)
+func (t *tracingMiddleware) Handle(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ectx echo.Context) error {
+		request := ectx.Request()
+
+		route := ectx.Path()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		ctx := tracing.Extract(request.Context(), request.Header)
+		ctx, span := tracing.Start(ctx, request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(request.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(request.URL.Path),
+			),
+		)
+		defer span.End()
+
+		ectx.SetRequest(request.WithContext(ctx))
+
+		if err := next(ectx); err != nil {
+			ectx.Error(err)
+		}
+
+		status := ectx.Response().Status
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+
+		// Apenas erros do servidor marcam o span; respostas 4xx são erros do cliente
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		return nil
+	}
+}
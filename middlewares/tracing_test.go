@@ -0,0 +1,89 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/g-villarinho/nubank-challenge/handlers"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingMiddleware_Handle(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	e := echo.New()
+	e.HTTPErrorHandler = handlers.HTTPErrorHandler
+	middleware := &tracingMiddleware{}
+
+	var spanContext trace.SpanContext
+	e.Use(middleware.Handle)
+	e.GET("/tracing-test/:id", func(ectx echo.Context) error {
+		spanContext = trace.SpanContextFromContext(ectx.Request().Context())
+		if ectx.Param("id") == "broken" {
+			return assert.AnError
+		}
+
+		return ectx.NoContent(http.StatusOK)
+	})
+
+	lastSpan := func() sdktrace.ReadOnlySpan {
+		spans := recorder.Ended()
+		require.NotEmpty(t, spans)
+		return spans[len(spans)-1]
+	}
+
+	attributes := func(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+		values := make(map[attribute.Key]attribute.Value)
+		for _, kv := range span.Attributes() {
+			values[kv.Key] = kv.Value
+		}
+		return values
+	}
+
+	t.Run("should continue the trace of the inbound traceparent", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/tracing-test/1", nil)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		span := lastSpan()
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "GET /tracing-test/:id", span.Name())
+		assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+		assert.Equal(t, span.SpanContext().SpanID(), spanContext.SpanID())
+		assert.Equal(t, int64(http.StatusOK), attributes(span)["http.response.status_code"].AsInt64())
+		assert.Equal(t, codes.Unset, span.Status().Code)
+	})
+
+	t.Run("should mark the span when the server fails", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tracing-test/broken", nil))
+
+		span := lastSpan()
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.False(t, span.Parent().IsValid())
+		assert.Equal(t, int64(http.StatusInternalServerError), attributes(span)["http.response.status_code"].AsInt64())
+		assert.Equal(t, codes.Error, span.Status().Code)
+	})
+}
@@ -2,12 +2,15 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/g-villarinho/nubank-challenge/configs"
 	"github.com/g-villarinho/nubank-challenge/models"
 	"github.com/g-villarinho/nubank-challenge/storages"
+	"gorm.io/gorm"
 )
 
 func main() {
@@ -16,19 +19,177 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	db, err := storages.NewPostgresStorage(ctx)
+	db, err := storages.NewPostgresOwnerStorage(ctx)
 	if err != nil {
 		log.Fatal("connect to database: ", err)
 	}
 
+	if err := backfillNormalizedContacts(db); err != nil {
+		log.Fatal("backfill normalized contacts: ", err)
+	}
+
 	err = db.AutoMigrate(
 		&models.Client{},
 		&models.Contact{},
+		&models.IdempotencyKey{},
+		&models.AuditLog{},
+		&models.OutboxEvent{},
+		&models.WebhookSubscription{},
+		&models.WebhookDelivery{},
+		&models.WebhookAttempt{},
+		&models.APIKey{},
+		&models.RateLimitBucket{},
 	)
 
 	if err != nil {
 		log.Fatal("auto migrate: %w", err)
 	}
 
+	// Os registros de auditoria não podem ser alterados nem removidos
+	err = db.Exec(`
+		CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit_logs is append-only';
+		END;
+		$$ LANGUAGE plpgsql;
+
+		DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs;
+		CREATE TRIGGER audit_logs_append_only
+			BEFORE UPDATE OR DELETE ON audit_logs
+			FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
+
+		DROP TRIGGER IF EXISTS audit_logs_no_truncate ON audit_logs;
+		CREATE TRIGGER audit_logs_no_truncate
+			BEFORE TRUNCATE ON audit_logs
+			FOR EACH STATEMENT EXECUTE FUNCTION audit_logs_append_only();
+	`).Error
+	if err != nil {
+		log.Fatal("create audit logs triggers: ", err)
+	}
+
+	// Cada tenant só enxerga as próprias linhas, mesmo que uma consulta esqueça o filtro. O tenant
+	// vem da configuração da transação, definida pelos repositórios com SET LOCAL. FORCE aplica
+	// as políticas também ao dono das tabelas; apenas superusuários e papéis com BYPASSRLS as
+	// ignoram. Nenhum valor da configuração libera todos os tenants: as rotinas internas
+	// (expurgo, relay do outbox e dispatcher de webhooks) conectam com o papel criado abaixo.
+	tables := []string{"clients", "contacts", "audit_logs", "outbox_events", "webhook_subscriptions", "webhook_deliveries", "webhook_attempts"}
+	for _, table := range tables {
+		err = db.Exec(fmt.Sprintf(`
+			ALTER TABLE %[1]s ENABLE ROW LEVEL SECURITY;
+			ALTER TABLE %[1]s FORCE ROW LEVEL SECURITY;
+
+			DROP POLICY IF EXISTS tenant_isolation ON %[1]s;
+			CREATE POLICY tenant_isolation ON %[1]s
+				USING (tenant_id = current_setting('%[2]s', true))
+				WITH CHECK (tenant_id = current_setting('%[2]s', true));
+		`, table, models.TenantSetting)).Error
+		if err != nil {
+			log.Fatalf("enable row level security on %s: %v", table, err)
+		}
+	}
+
+	// A aplicação usa um papel próprio, sem superusuário nem BYPASSRLS, para que as políticas acima
+	// valham para ela. As rotinas internas usam outro papel, com BYPASSRLS, para alcançar todos os
+	// tenants. Os dois só leem e escrevem dados; a estrutura das tabelas fica com o dono.
+	postgres := configs.Env.Postgres
+	if postgres.User == postgres.OwnerUser || postgres.JobsUser == postgres.OwnerUser {
+		log.Fatal("POSTGRES_USER and POSTGRES_JOBS_USER must be different from POSTGRES_OWNER_USER")
+	}
+
+	if postgres.JobsUser == "" || postgres.JobsUser == postgres.User {
+		log.Fatal("POSTGRES_JOBS_USER must be set and different from POSTGRES_USER")
+	}
+
+	if err := createRole(db, postgres.User, postgres.Password, "NOBYPASSRLS"); err != nil {
+		log.Fatal("create application role: ", err)
+	}
+
+	if err := createRole(db, postgres.JobsUser, postgres.JobsPassword, "BYPASSRLS"); err != nil {
+		log.Fatal("create jobs role: ", err)
+	}
+
+	// Os baldes do rate limit são descartáveis; sem WAL, cada requisição custa menos ao Postgres
+	err = db.Exec(`ALTER TABLE rate_limit_buckets SET UNLOGGED`).Error
+	if err != nil {
+		log.Fatal("set rate limit buckets unlogged: ", err)
+	}
+
 	log.Println("migrations excuted succefully!")
 }
+
+// backfillNormalizedContacts cria as colunas normalizadas dos contatos e as preenche para os
+// contatos criados antes da busca reversa, aplicando as mesmas regras de pkgs.NormalizePhone e
+// pkgs.NormalizeEmail. Roda uma única vez, na mesma transação que cria as colunas; depois disso
+// os repositórios mantêm as colunas atualizadas.
+func backfillNormalizedContacts(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&models.Contact{}) || migrator.HasColumn(&models.Contact{}, "NormalizedPhone") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, field := range []string{"NormalizedPhone", "NormalizedEmail"} {
+			if err := tx.Migrator().AddColumn(&models.Contact{}, field); err != nil {
+				return fmt.Errorf("add column %s: %w", field, err)
+			}
+		}
+
+		// Com FORCE as políticas valeriam para o dono, que não informa tenant, e o UPDATE não
+		// alcançaria nenhuma linha; elas voltam a ser forçadas antes do commit
+		if err := tx.Exec("ALTER TABLE contacts NO FORCE ROW LEVEL SECURITY").Error; err != nil {
+			return fmt.Errorf("disable forced row level security: %w", err)
+		}
+
+		// Um telefone sem nenhum dígito, como "+", é normalizado para vazio
+		err := tx.Exec(`
+			UPDATE contacts
+			SET normalized_email = LOWER(REGEXP_REPLACE(email, '^[[:space:]]+|[[:space:]]+$', '', 'g')),
+				normalized_phone = CASE
+					WHEN REGEXP_REPLACE(phone, '[^0-9]', '', 'g') = '' THEN ''
+					WHEN REGEXP_REPLACE(phone, '^[[:space:]]+', '') LIKE '+%' THEN '+' || REGEXP_REPLACE(phone, '[^0-9]', '', 'g')
+					ELSE REGEXP_REPLACE(phone, '[^0-9]', '', 'g')
+				END
+		`).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Exec("ALTER TABLE contacts FORCE ROW LEVEL SECURITY").Error
+	})
+}
+
+// createRole cria o papel, se ainda não existir, e atualiza a senha, a permissão de ignorar as
+// políticas de row-level security (rls é BYPASSRLS ou NOBYPASSRLS) e o acesso aos dados
+func createRole(db *gorm.DB, user, password, rls string) error {
+	role := quoteIdentifier(user)
+
+	return db.Exec(fmt.Sprintf(`
+		DO $$
+		BEGIN
+			IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = %[1]s) THEN
+				CREATE ROLE %[2]s LOGIN;
+			END IF;
+		END
+		$$;
+
+		ALTER ROLE %[2]s WITH LOGIN NOSUPERUSER %[3]s NOCREATEDB NOCREATEROLE PASSWORD %[4]s;
+		GRANT CONNECT ON DATABASE %[5]s TO %[2]s;
+		GRANT USAGE ON SCHEMA public TO %[2]s;
+		GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO %[2]s;
+		GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO %[2]s;
+	`,
+		quoteLiteral(user),
+		role,
+		rls,
+		quoteLiteral(password),
+		quoteIdentifier(configs.Env.Postgres.DBName),
+	)).Error
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
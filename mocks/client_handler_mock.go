@@ -67,6 +67,98 @@ func (_c *ClientHandlerMock_CreateClient_Call) RunAndReturn(run func(echo.Contex
 	return _c
 }
 
+// DeleteClient provides a mock function with given fields: ectx
+func (_m *ClientHandlerMock) DeleteClient(ectx echo.Context) error {
+	ret := _m.Called(ectx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteClient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ectx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClientHandlerMock_DeleteClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteClient'
+type ClientHandlerMock_DeleteClient_Call struct {
+	*mock.Call
+}
+
+// DeleteClient is a helper method to define mock.On call
+//   - ectx echo.Context
+func (_e *ClientHandlerMock_Expecter) DeleteClient(ectx interface{}) *ClientHandlerMock_DeleteClient_Call {
+	return &ClientHandlerMock_DeleteClient_Call{Call: _e.mock.On("DeleteClient", ectx)}
+}
+
+func (_c *ClientHandlerMock_DeleteClient_Call) Run(run func(ectx echo.Context)) *ClientHandlerMock_DeleteClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(echo.Context))
+	})
+	return _c
+}
+
+func (_c *ClientHandlerMock_DeleteClient_Call) Return(_a0 error) *ClientHandlerMock_DeleteClient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ClientHandlerMock_DeleteClient_Call) RunAndReturn(run func(echo.Context) error) *ClientHandlerMock_DeleteClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetClient provides a mock function with given fields: ectx
+func (_m *ClientHandlerMock) GetClient(ectx echo.Context) error {
+	ret := _m.Called(ectx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ectx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClientHandlerMock_GetClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClient'
+type ClientHandlerMock_GetClient_Call struct {
+	*mock.Call
+}
+
+// GetClient is a helper method to define mock.On call
+//   - ectx echo.Context
+func (_e *ClientHandlerMock_Expecter) GetClient(ectx interface{}) *ClientHandlerMock_GetClient_Call {
+	return &ClientHandlerMock_GetClient_Call{Call: _e.mock.On("GetClient", ectx)}
+}
+
+func (_c *ClientHandlerMock_GetClient_Call) Run(run func(ectx echo.Context)) *ClientHandlerMock_GetClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(echo.Context))
+	})
+	return _c
+}
+
+func (_c *ClientHandlerMock_GetClient_Call) Return(_a0 error) *ClientHandlerMock_GetClient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ClientHandlerMock_GetClient_Call) RunAndReturn(run func(echo.Context) error) *ClientHandlerMock_GetClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetClientContactsByID provides a mock function with given fields: ectx
 func (_m *ClientHandlerMock) GetClientContactsByID(ectx echo.Context) error {
 	ret := _m.Called(ectx)
@@ -159,6 +251,190 @@ func (_c *ClientHandlerMock_GetClientsWithContact_Call) RunAndReturn(run func(ec
 	return _c
 }
 
+// GetDeletedClients provides a mock function with given fields: ectx
+func (_m *ClientHandlerMock) GetDeletedClients(ectx echo.Context) error {
+	ret := _m.Called(ectx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedClients")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ectx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClientHandlerMock_GetDeletedClients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedClients'
+type ClientHandlerMock_GetDeletedClients_Call struct {
+	*mock.Call
+}
+
+// GetDeletedClients is a helper method to define mock.On call
+//   - ectx echo.Context
+func (_e *ClientHandlerMock_Expecter) GetDeletedClients(ectx interface{}) *ClientHandlerMock_GetDeletedClients_Call {
+	return &ClientHandlerMock_GetDeletedClients_Call{Call: _e.mock.On("GetDeletedClients", ectx)}
+}
+
+func (_c *ClientHandlerMock_GetDeletedClients_Call) Run(run func(ectx echo.Context)) *ClientHandlerMock_GetDeletedClients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(echo.Context))
+	})
+	return _c
+}
+
+func (_c *ClientHandlerMock_GetDeletedClients_Call) Return(_a0 error) *ClientHandlerMock_GetDeletedClients_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ClientHandlerMock_GetDeletedClients_Call) RunAndReturn(run func(echo.Context) error) *ClientHandlerMock_GetDeletedClients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchClient provides a mock function with given fields: ectx
+func (_m *ClientHandlerMock) PatchClient(ectx echo.Context) error {
+	ret := _m.Called(ectx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchClient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ectx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClientHandlerMock_PatchClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchClient'
+type ClientHandlerMock_PatchClient_Call struct {
+	*mock.Call
+}
+
+// PatchClient is a helper method to define mock.On call
+//   - ectx echo.Context
+func (_e *ClientHandlerMock_Expecter) PatchClient(ectx interface{}) *ClientHandlerMock_PatchClient_Call {
+	return &ClientHandlerMock_PatchClient_Call{Call: _e.mock.On("PatchClient", ectx)}
+}
+
+func (_c *ClientHandlerMock_PatchClient_Call) Run(run func(ectx echo.Context)) *ClientHandlerMock_PatchClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(echo.Context))
+	})
+	return _c
+}
+
+func (_c *ClientHandlerMock_PatchClient_Call) Return(_a0 error) *ClientHandlerMock_PatchClient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ClientHandlerMock_PatchClient_Call) RunAndReturn(run func(echo.Context) error) *ClientHandlerMock_PatchClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreClient provides a mock function with given fields: ectx
+func (_m *ClientHandlerMock) RestoreClient(ectx echo.Context) error {
+	ret := _m.Called(ectx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreClient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ectx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClientHandlerMock_RestoreClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreClient'
+type ClientHandlerMock_RestoreClient_Call struct {
+	*mock.Call
+}
+
+// RestoreClient is a helper method to define mock.On call
+//   - ectx echo.Context
+func (_e *ClientHandlerMock_Expecter) RestoreClient(ectx interface{}) *ClientHandlerMock_RestoreClient_Call {
+	return &ClientHandlerMock_RestoreClient_Call{Call: _e.mock.On("RestoreClient", ectx)}
+}
+
+func (_c *ClientHandlerMock_RestoreClient_Call) Run(run func(ectx echo.Context)) *ClientHandlerMock_RestoreClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(echo.Context))
+	})
+	return _c
+}
+
+func (_c *ClientHandlerMock_RestoreClient_Call) Return(_a0 error) *ClientHandlerMock_RestoreClient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ClientHandlerMock_RestoreClient_Call) RunAndReturn(run func(echo.Context) error) *ClientHandlerMock_RestoreClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateClient provides a mock function with given fields: ectx
+func (_m *ClientHandlerMock) UpdateClient(ectx echo.Context) error {
+	ret := _m.Called(ectx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateClient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ectx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClientHandlerMock_UpdateClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateClient'
+type ClientHandlerMock_UpdateClient_Call struct {
+	*mock.Call
+}
+
+// UpdateClient is a helper method to define mock.On call
+//   - ectx echo.Context
+func (_e *ClientHandlerMock_Expecter) UpdateClient(ectx interface{}) *ClientHandlerMock_UpdateClient_Call {
+	return &ClientHandlerMock_UpdateClient_Call{Call: _e.mock.On("UpdateClient", ectx)}
+}
+
+func (_c *ClientHandlerMock_UpdateClient_Call) Run(run func(ectx echo.Context)) *ClientHandlerMock_UpdateClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(echo.Context))
+	})
+	return _c
+}
+
+func (_c *ClientHandlerMock_UpdateClient_Call) Return(_a0 error) *ClientHandlerMock_UpdateClient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ClientHandlerMock_UpdateClient_Call) RunAndReturn(run func(echo.Context) error) *ClientHandlerMock_UpdateClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewClientHandlerMock creates a new instance of ClientHandlerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewClientHandlerMock(t interface {
@@ -7,6 +7,8 @@ import (
 
 	models "github.com/g-villarinho/nubank-challenge/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // ClientRepositoryMock is an autogenerated mock type for the ClientRepository type
@@ -69,6 +71,55 @@ func (_c *ClientRepositoryMock_CreateClient_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// DeleteClient provides a mock function with given fields: ctx, id, version, deletedAt
+func (_m *ClientRepositoryMock) DeleteClient(ctx context.Context, id string, version int64, deletedAt time.Time) error {
+	ret := _m.Called(ctx, id, version, deletedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteClient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Time) error); ok {
+		r0 = rf(ctx, id, version, deletedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClientRepositoryMock_DeleteClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteClient'
+type ClientRepositoryMock_DeleteClient_Call struct {
+	*mock.Call
+}
+
+// DeleteClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - version int64
+//   - deletedAt time.Time
+func (_e *ClientRepositoryMock_Expecter) DeleteClient(ctx interface{}, id interface{}, version interface{}, deletedAt interface{}) *ClientRepositoryMock_DeleteClient_Call {
+	return &ClientRepositoryMock_DeleteClient_Call{Call: _e.mock.On("DeleteClient", ctx, id, version, deletedAt)}
+}
+
+func (_c *ClientRepositoryMock_DeleteClient_Call) Run(run func(ctx context.Context, id string, version int64, deletedAt time.Time)) *ClientRepositoryMock_DeleteClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ClientRepositoryMock_DeleteClient_Call) Return(_a0 error) *ClientRepositoryMock_DeleteClient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ClientRepositoryMock_DeleteClient_Call) RunAndReturn(run func(context.Context, string, int64, time.Time) error) *ClientRepositoryMock_DeleteClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetClientByID provides a mock function with given fields: ctx, id
 func (_m *ClientRepositoryMock) GetClientByID(ctx context.Context, id string) (*models.Client, error) {
 	ret := _m.Called(ctx, id)
@@ -187,14 +238,139 @@ func (_c *ClientRepositoryMock_GetClientWitContactsByID_Call) RunAndReturn(run f
 	return _c
 }
 
-// GetClientsWithContact provides a mock function with given fields: ctx
-func (_m *ClientRepositoryMock) GetClientsWithContact(ctx context.Context) ([]*models.Client, error) {
-	ret := _m.Called(ctx)
+// GetClientsWithContact provides a mock function with given fields: ctx, opts
+func (_m *ClientRepositoryMock) GetClientsWithContact(ctx context.Context, opts models.ClientListOptions) ([]*models.Client, bool, error) {
+	ret := _m.Called(ctx, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetClientsWithContact")
 	}
 
+	var r0 []*models.Client
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ClientListOptions) ([]*models.Client, bool, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.ClientListOptions) []*models.Client); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Client)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.ClientListOptions) bool); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, models.ClientListOptions) error); ok {
+		r2 = rf(ctx, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ClientRepositoryMock_GetClientsWithContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClientsWithContact'
+type ClientRepositoryMock_GetClientsWithContact_Call struct {
+	*mock.Call
+}
+
+// GetClientsWithContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts models.ClientListOptions
+func (_e *ClientRepositoryMock_Expecter) GetClientsWithContact(ctx interface{}, opts interface{}) *ClientRepositoryMock_GetClientsWithContact_Call {
+	return &ClientRepositoryMock_GetClientsWithContact_Call{Call: _e.mock.On("GetClientsWithContact", ctx, opts)}
+}
+
+func (_c *ClientRepositoryMock_GetClientsWithContact_Call) Run(run func(ctx context.Context, opts models.ClientListOptions)) *ClientRepositoryMock_GetClientsWithContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.ClientListOptions))
+	})
+	return _c
+}
+
+func (_c *ClientRepositoryMock_GetClientsWithContact_Call) Return(_a0 []*models.Client, _a1 bool, _a2 error) *ClientRepositoryMock_GetClientsWithContact_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *ClientRepositoryMock_GetClientsWithContact_Call) RunAndReturn(run func(context.Context, models.ClientListOptions) ([]*models.Client, bool, error)) *ClientRepositoryMock_GetClientsWithContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletedClientByID provides a mock function with given fields: ctx, id
+func (_m *ClientRepositoryMock) GetDeletedClientByID(ctx context.Context, id string) (*models.Client, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedClientByID")
+	}
+
+	var r0 *models.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Client, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Client); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Client)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClientRepositoryMock_GetDeletedClientByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedClientByID'
+type ClientRepositoryMock_GetDeletedClientByID_Call struct {
+	*mock.Call
+}
+
+// GetDeletedClientByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ClientRepositoryMock_Expecter) GetDeletedClientByID(ctx interface{}, id interface{}) *ClientRepositoryMock_GetDeletedClientByID_Call {
+	return &ClientRepositoryMock_GetDeletedClientByID_Call{Call: _e.mock.On("GetDeletedClientByID", ctx, id)}
+}
+
+func (_c *ClientRepositoryMock_GetDeletedClientByID_Call) Run(run func(ctx context.Context, id string)) *ClientRepositoryMock_GetDeletedClientByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ClientRepositoryMock_GetDeletedClientByID_Call) Return(_a0 *models.Client, _a1 error) *ClientRepositoryMock_GetDeletedClientByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ClientRepositoryMock_GetDeletedClientByID_Call) RunAndReturn(run func(context.Context, string) (*models.Client, error)) *ClientRepositoryMock_GetDeletedClientByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletedClients provides a mock function with given fields: ctx
+func (_m *ClientRepositoryMock) GetDeletedClients(ctx context.Context) ([]*models.Client, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedClients")
+	}
+
 	var r0 []*models.Client
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.Client, error)); ok {
@@ -217,30 +393,228 @@ func (_m *ClientRepositoryMock) GetClientsWithContact(ctx context.Context) ([]*m
 	return r0, r1
 }
 
-// ClientRepositoryMock_GetClientsWithContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClientsWithContact'
-type ClientRepositoryMock_GetClientsWithContact_Call struct {
+// ClientRepositoryMock_GetDeletedClients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedClients'
+type ClientRepositoryMock_GetDeletedClients_Call struct {
 	*mock.Call
 }
 
-// GetClientsWithContact is a helper method to define mock.On call
+// GetDeletedClients is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *ClientRepositoryMock_Expecter) GetClientsWithContact(ctx interface{}) *ClientRepositoryMock_GetClientsWithContact_Call {
-	return &ClientRepositoryMock_GetClientsWithContact_Call{Call: _e.mock.On("GetClientsWithContact", ctx)}
+func (_e *ClientRepositoryMock_Expecter) GetDeletedClients(ctx interface{}) *ClientRepositoryMock_GetDeletedClients_Call {
+	return &ClientRepositoryMock_GetDeletedClients_Call{Call: _e.mock.On("GetDeletedClients", ctx)}
 }
 
-func (_c *ClientRepositoryMock_GetClientsWithContact_Call) Run(run func(ctx context.Context)) *ClientRepositoryMock_GetClientsWithContact_Call {
+func (_c *ClientRepositoryMock_GetDeletedClients_Call) Run(run func(ctx context.Context)) *ClientRepositoryMock_GetDeletedClients_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *ClientRepositoryMock_GetClientsWithContact_Call) Return(_a0 []*models.Client, _a1 error) *ClientRepositoryMock_GetClientsWithContact_Call {
+func (_c *ClientRepositoryMock_GetDeletedClients_Call) Return(_a0 []*models.Client, _a1 error) *ClientRepositoryMock_GetDeletedClients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ClientRepositoryMock_GetDeletedClients_Call) RunAndReturn(run func(context.Context) ([]*models.Client, error)) *ClientRepositoryMock_GetDeletedClients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeDeletedClients provides a mock function with given fields: ctx, before
+func (_m *ClientRepositoryMock) PurgeDeletedClients(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedClients")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClientRepositoryMock_PurgeDeletedClients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeletedClients'
+type ClientRepositoryMock_PurgeDeletedClients_Call struct {
+	*mock.Call
+}
+
+// PurgeDeletedClients is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *ClientRepositoryMock_Expecter) PurgeDeletedClients(ctx interface{}, before interface{}) *ClientRepositoryMock_PurgeDeletedClients_Call {
+	return &ClientRepositoryMock_PurgeDeletedClients_Call{Call: _e.mock.On("PurgeDeletedClients", ctx, before)}
+}
+
+func (_c *ClientRepositoryMock_PurgeDeletedClients_Call) Run(run func(ctx context.Context, before time.Time)) *ClientRepositoryMock_PurgeDeletedClients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ClientRepositoryMock_PurgeDeletedClients_Call) Return(_a0 int64, _a1 error) *ClientRepositoryMock_PurgeDeletedClients_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *ClientRepositoryMock_GetClientsWithContact_Call) RunAndReturn(run func(context.Context) ([]*models.Client, error)) *ClientRepositoryMock_GetClientsWithContact_Call {
+func (_c *ClientRepositoryMock_PurgeDeletedClients_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *ClientRepositoryMock_PurgeDeletedClients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreClient provides a mock function with given fields: ctx, id
+func (_m *ClientRepositoryMock) RestoreClient(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreClient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClientRepositoryMock_RestoreClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreClient'
+type ClientRepositoryMock_RestoreClient_Call struct {
+	*mock.Call
+}
+
+// RestoreClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ClientRepositoryMock_Expecter) RestoreClient(ctx interface{}, id interface{}) *ClientRepositoryMock_RestoreClient_Call {
+	return &ClientRepositoryMock_RestoreClient_Call{Call: _e.mock.On("RestoreClient", ctx, id)}
+}
+
+func (_c *ClientRepositoryMock_RestoreClient_Call) Run(run func(ctx context.Context, id string)) *ClientRepositoryMock_RestoreClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ClientRepositoryMock_RestoreClient_Call) Return(_a0 error) *ClientRepositoryMock_RestoreClient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ClientRepositoryMock_RestoreClient_Call) RunAndReturn(run func(context.Context, string) error) *ClientRepositoryMock_RestoreClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchClient provides a mock function with given fields: ctx, id
+func (_m *ClientRepositoryMock) TouchClient(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchClient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClientRepositoryMock_TouchClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchClient'
+type ClientRepositoryMock_TouchClient_Call struct {
+	*mock.Call
+}
+
+// TouchClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ClientRepositoryMock_Expecter) TouchClient(ctx interface{}, id interface{}) *ClientRepositoryMock_TouchClient_Call {
+	return &ClientRepositoryMock_TouchClient_Call{Call: _e.mock.On("TouchClient", ctx, id)}
+}
+
+func (_c *ClientRepositoryMock_TouchClient_Call) Run(run func(ctx context.Context, id string)) *ClientRepositoryMock_TouchClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ClientRepositoryMock_TouchClient_Call) Return(_a0 error) *ClientRepositoryMock_TouchClient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ClientRepositoryMock_TouchClient_Call) RunAndReturn(run func(context.Context, string) error) *ClientRepositoryMock_TouchClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateClient provides a mock function with given fields: ctx, client
+func (_m *ClientRepositoryMock) UpdateClient(ctx context.Context, client *models.Client) error {
+	ret := _m.Called(ctx, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateClient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Client) error); ok {
+		r0 = rf(ctx, client)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClientRepositoryMock_UpdateClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateClient'
+type ClientRepositoryMock_UpdateClient_Call struct {
+	*mock.Call
+}
+
+// UpdateClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - client *models.Client
+func (_e *ClientRepositoryMock_Expecter) UpdateClient(ctx interface{}, client interface{}) *ClientRepositoryMock_UpdateClient_Call {
+	return &ClientRepositoryMock_UpdateClient_Call{Call: _e.mock.On("UpdateClient", ctx, client)}
+}
+
+func (_c *ClientRepositoryMock_UpdateClient_Call) Run(run func(ctx context.Context, client *models.Client)) *ClientRepositoryMock_UpdateClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Client))
+	})
+	return _c
+}
+
+func (_c *ClientRepositoryMock_UpdateClient_Call) Return(_a0 error) *ClientRepositoryMock_UpdateClient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ClientRepositoryMock_UpdateClient_Call) RunAndReturn(run func(context.Context, *models.Client) error) *ClientRepositoryMock_UpdateClient_Call {
 	_c.Call.Return(run)
 	return _c
 }
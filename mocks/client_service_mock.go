@@ -7,6 +7,8 @@ import (
 
 	models "github.com/g-villarinho/nubank-challenge/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // ClientServiceMock is an autogenerated mock type for the ClientService type
@@ -82,6 +84,114 @@ func (_c *ClientServiceMock_CreateClient_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// DeleteClient provides a mock function with given fields: ctx, id, version
+func (_m *ClientServiceMock) DeleteClient(ctx context.Context, id string, version int64) error {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteClient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClientServiceMock_DeleteClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteClient'
+type ClientServiceMock_DeleteClient_Call struct {
+	*mock.Call
+}
+
+// DeleteClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - version int64
+func (_e *ClientServiceMock_Expecter) DeleteClient(ctx interface{}, id interface{}, version interface{}) *ClientServiceMock_DeleteClient_Call {
+	return &ClientServiceMock_DeleteClient_Call{Call: _e.mock.On("DeleteClient", ctx, id, version)}
+}
+
+func (_c *ClientServiceMock_DeleteClient_Call) Run(run func(ctx context.Context, id string, version int64)) *ClientServiceMock_DeleteClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *ClientServiceMock_DeleteClient_Call) Return(_a0 error) *ClientServiceMock_DeleteClient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ClientServiceMock_DeleteClient_Call) RunAndReturn(run func(context.Context, string, int64) error) *ClientServiceMock_DeleteClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetClient provides a mock function with given fields: ctx, id, includeContacts
+func (_m *ClientServiceMock) GetClient(ctx context.Context, id string, includeContacts bool) (*models.ClientResponse, error) {
+	ret := _m.Called(ctx, id, includeContacts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClient")
+	}
+
+	var r0 *models.ClientResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*models.ClientResponse, error)); ok {
+		return rf(ctx, id, includeContacts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *models.ClientResponse); ok {
+		r0 = rf(ctx, id, includeContacts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ClientResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, id, includeContacts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClientServiceMock_GetClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClient'
+type ClientServiceMock_GetClient_Call struct {
+	*mock.Call
+}
+
+// GetClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - includeContacts bool
+func (_e *ClientServiceMock_Expecter) GetClient(ctx interface{}, id interface{}, includeContacts interface{}) *ClientServiceMock_GetClient_Call {
+	return &ClientServiceMock_GetClient_Call{Call: _e.mock.On("GetClient", ctx, id, includeContacts)}
+}
+
+func (_c *ClientServiceMock_GetClient_Call) Run(run func(ctx context.Context, id string, includeContacts bool)) *ClientServiceMock_GetClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *ClientServiceMock_GetClient_Call) Return(_a0 *models.ClientResponse, _a1 error) *ClientServiceMock_GetClient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ClientServiceMock_GetClient_Call) RunAndReturn(run func(context.Context, string, bool) (*models.ClientResponse, error)) *ClientServiceMock_GetClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetClientContactsByID provides a mock function with given fields: ctx, id
 func (_m *ClientServiceMock) GetClientContactsByID(ctx context.Context, id string) ([]models.ContactResponse, error) {
 	ret := _m.Called(ctx, id)
@@ -141,14 +251,73 @@ func (_c *ClientServiceMock_GetClientContactsByID_Call) RunAndReturn(run func(co
 	return _c
 }
 
-// GetClientsWithContact provides a mock function with given fields: ctx
-func (_m *ClientServiceMock) GetClientsWithContact(ctx context.Context) ([]models.ClientResponse, error) {
-	ret := _m.Called(ctx)
+// GetClientsWithContact provides a mock function with given fields: ctx, query
+func (_m *ClientServiceMock) GetClientsWithContact(ctx context.Context, query models.ListClientsQuery) (*models.ClientPageResponse, error) {
+	ret := _m.Called(ctx, query)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetClientsWithContact")
 	}
 
+	var r0 *models.ClientPageResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ListClientsQuery) (*models.ClientPageResponse, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.ListClientsQuery) *models.ClientPageResponse); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ClientPageResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.ListClientsQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClientServiceMock_GetClientsWithContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClientsWithContact'
+type ClientServiceMock_GetClientsWithContact_Call struct {
+	*mock.Call
+}
+
+// GetClientsWithContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query models.ListClientsQuery
+func (_e *ClientServiceMock_Expecter) GetClientsWithContact(ctx interface{}, query interface{}) *ClientServiceMock_GetClientsWithContact_Call {
+	return &ClientServiceMock_GetClientsWithContact_Call{Call: _e.mock.On("GetClientsWithContact", ctx, query)}
+}
+
+func (_c *ClientServiceMock_GetClientsWithContact_Call) Run(run func(ctx context.Context, query models.ListClientsQuery)) *ClientServiceMock_GetClientsWithContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.ListClientsQuery))
+	})
+	return _c
+}
+
+func (_c *ClientServiceMock_GetClientsWithContact_Call) Return(_a0 *models.ClientPageResponse, _a1 error) *ClientServiceMock_GetClientsWithContact_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ClientServiceMock_GetClientsWithContact_Call) RunAndReturn(run func(context.Context, models.ListClientsQuery) (*models.ClientPageResponse, error)) *ClientServiceMock_GetClientsWithContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletedClients provides a mock function with given fields: ctx
+func (_m *ClientServiceMock) GetDeletedClients(ctx context.Context) ([]models.ClientResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedClients")
+	}
+
 	var r0 []models.ClientResponse
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context) ([]models.ClientResponse, error)); ok {
@@ -171,30 +340,268 @@ func (_m *ClientServiceMock) GetClientsWithContact(ctx context.Context) ([]model
 	return r0, r1
 }
 
-// ClientServiceMock_GetClientsWithContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClientsWithContact'
-type ClientServiceMock_GetClientsWithContact_Call struct {
+// ClientServiceMock_GetDeletedClients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedClients'
+type ClientServiceMock_GetDeletedClients_Call struct {
 	*mock.Call
 }
 
-// GetClientsWithContact is a helper method to define mock.On call
+// GetDeletedClients is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *ClientServiceMock_Expecter) GetClientsWithContact(ctx interface{}) *ClientServiceMock_GetClientsWithContact_Call {
-	return &ClientServiceMock_GetClientsWithContact_Call{Call: _e.mock.On("GetClientsWithContact", ctx)}
+func (_e *ClientServiceMock_Expecter) GetDeletedClients(ctx interface{}) *ClientServiceMock_GetDeletedClients_Call {
+	return &ClientServiceMock_GetDeletedClients_Call{Call: _e.mock.On("GetDeletedClients", ctx)}
 }
 
-func (_c *ClientServiceMock_GetClientsWithContact_Call) Run(run func(ctx context.Context)) *ClientServiceMock_GetClientsWithContact_Call {
+func (_c *ClientServiceMock_GetDeletedClients_Call) Run(run func(ctx context.Context)) *ClientServiceMock_GetDeletedClients_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *ClientServiceMock_GetClientsWithContact_Call) Return(_a0 []models.ClientResponse, _a1 error) *ClientServiceMock_GetClientsWithContact_Call {
+func (_c *ClientServiceMock_GetDeletedClients_Call) Return(_a0 []models.ClientResponse, _a1 error) *ClientServiceMock_GetDeletedClients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ClientServiceMock_GetDeletedClients_Call) RunAndReturn(run func(context.Context) ([]models.ClientResponse, error)) *ClientServiceMock_GetDeletedClients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchClient provides a mock function with given fields: ctx, id, version, patch
+func (_m *ClientServiceMock) PatchClient(ctx context.Context, id string, version int64, patch []byte) (*models.ClientResponse, error) {
+	ret := _m.Called(ctx, id, version, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchClient")
+	}
+
+	var r0 *models.ClientResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, []byte) (*models.ClientResponse, error)); ok {
+		return rf(ctx, id, version, patch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, []byte) *models.ClientResponse); ok {
+		r0 = rf(ctx, id, version, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ClientResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, []byte) error); ok {
+		r1 = rf(ctx, id, version, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClientServiceMock_PatchClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchClient'
+type ClientServiceMock_PatchClient_Call struct {
+	*mock.Call
+}
+
+// PatchClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - version int64
+//   - patch []byte
+func (_e *ClientServiceMock_Expecter) PatchClient(ctx interface{}, id interface{}, version interface{}, patch interface{}) *ClientServiceMock_PatchClient_Call {
+	return &ClientServiceMock_PatchClient_Call{Call: _e.mock.On("PatchClient", ctx, id, version, patch)}
+}
+
+func (_c *ClientServiceMock_PatchClient_Call) Run(run func(ctx context.Context, id string, version int64, patch []byte)) *ClientServiceMock_PatchClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *ClientServiceMock_PatchClient_Call) Return(_a0 *models.ClientResponse, _a1 error) *ClientServiceMock_PatchClient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ClientServiceMock_PatchClient_Call) RunAndReturn(run func(context.Context, string, int64, []byte) (*models.ClientResponse, error)) *ClientServiceMock_PatchClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeDeletedClients provides a mock function with given fields: ctx, retention
+func (_m *ClientServiceMock) PurgeDeletedClients(ctx context.Context, retention time.Duration) (int64, error) {
+	ret := _m.Called(ctx, retention)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedClients")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) (int64, error)); ok {
+		return rf(ctx, retention)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) int64); ok {
+		r0 = rf(ctx, retention)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, retention)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClientServiceMock_PurgeDeletedClients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeletedClients'
+type ClientServiceMock_PurgeDeletedClients_Call struct {
+	*mock.Call
+}
+
+// PurgeDeletedClients is a helper method to define mock.On call
+//   - ctx context.Context
+//   - retention time.Duration
+func (_e *ClientServiceMock_Expecter) PurgeDeletedClients(ctx interface{}, retention interface{}) *ClientServiceMock_PurgeDeletedClients_Call {
+	return &ClientServiceMock_PurgeDeletedClients_Call{Call: _e.mock.On("PurgeDeletedClients", ctx, retention)}
+}
+
+func (_c *ClientServiceMock_PurgeDeletedClients_Call) Run(run func(ctx context.Context, retention time.Duration)) *ClientServiceMock_PurgeDeletedClients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *ClientServiceMock_PurgeDeletedClients_Call) Return(_a0 int64, _a1 error) *ClientServiceMock_PurgeDeletedClients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ClientServiceMock_PurgeDeletedClients_Call) RunAndReturn(run func(context.Context, time.Duration) (int64, error)) *ClientServiceMock_PurgeDeletedClients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreClient provides a mock function with given fields: ctx, id
+func (_m *ClientServiceMock) RestoreClient(ctx context.Context, id string) (*models.ClientResponse, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreClient")
+	}
+
+	var r0 *models.ClientResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.ClientResponse, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.ClientResponse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ClientResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClientServiceMock_RestoreClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreClient'
+type ClientServiceMock_RestoreClient_Call struct {
+	*mock.Call
+}
+
+// RestoreClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ClientServiceMock_Expecter) RestoreClient(ctx interface{}, id interface{}) *ClientServiceMock_RestoreClient_Call {
+	return &ClientServiceMock_RestoreClient_Call{Call: _e.mock.On("RestoreClient", ctx, id)}
+}
+
+func (_c *ClientServiceMock_RestoreClient_Call) Run(run func(ctx context.Context, id string)) *ClientServiceMock_RestoreClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ClientServiceMock_RestoreClient_Call) Return(_a0 *models.ClientResponse, _a1 error) *ClientServiceMock_RestoreClient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ClientServiceMock_RestoreClient_Call) RunAndReturn(run func(context.Context, string) (*models.ClientResponse, error)) *ClientServiceMock_RestoreClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateClient provides a mock function with given fields: ctx, id, version, name
+func (_m *ClientServiceMock) UpdateClient(ctx context.Context, id string, version int64, name string) (*models.ClientResponse, error) {
+	ret := _m.Called(ctx, id, version, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateClient")
+	}
+
+	var r0 *models.ClientResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string) (*models.ClientResponse, error)); ok {
+		return rf(ctx, id, version, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string) *models.ClientResponse); ok {
+		r0 = rf(ctx, id, version, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ClientResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, string) error); ok {
+		r1 = rf(ctx, id, version, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClientServiceMock_UpdateClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateClient'
+type ClientServiceMock_UpdateClient_Call struct {
+	*mock.Call
+}
+
+// UpdateClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - version int64
+//   - name string
+func (_e *ClientServiceMock_Expecter) UpdateClient(ctx interface{}, id interface{}, version interface{}, name interface{}) *ClientServiceMock_UpdateClient_Call {
+	return &ClientServiceMock_UpdateClient_Call{Call: _e.mock.On("UpdateClient", ctx, id, version, name)}
+}
+
+func (_c *ClientServiceMock_UpdateClient_Call) Run(run func(ctx context.Context, id string, version int64, name string)) *ClientServiceMock_UpdateClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *ClientServiceMock_UpdateClient_Call) Return(_a0 *models.ClientResponse, _a1 error) *ClientServiceMock_UpdateClient_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *ClientServiceMock_GetClientsWithContact_Call) RunAndReturn(run func(context.Context) ([]models.ClientResponse, error)) *ClientServiceMock_GetClientsWithContact_Call {
+func (_c *ClientServiceMock_UpdateClient_Call) RunAndReturn(run func(context.Context, string, int64, string) (*models.ClientResponse, error)) *ClientServiceMock_UpdateClient_Call {
 	_c.Call.Return(run)
 	return _c
 }
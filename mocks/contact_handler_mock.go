@@ -67,6 +67,282 @@ func (_c *ContactHandlerMock_CreateContact_Call) RunAndReturn(run func(echo.Cont
 	return _c
 }
 
+// DeleteContact provides a mock function with given fields: ectx
+func (_m *ContactHandlerMock) DeleteContact(ectx echo.Context) error {
+	ret := _m.Called(ectx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteContact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ectx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactHandlerMock_DeleteContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteContact'
+type ContactHandlerMock_DeleteContact_Call struct {
+	*mock.Call
+}
+
+// DeleteContact is a helper method to define mock.On call
+//   - ectx echo.Context
+func (_e *ContactHandlerMock_Expecter) DeleteContact(ectx interface{}) *ContactHandlerMock_DeleteContact_Call {
+	return &ContactHandlerMock_DeleteContact_Call{Call: _e.mock.On("DeleteContact", ectx)}
+}
+
+func (_c *ContactHandlerMock_DeleteContact_Call) Run(run func(ectx echo.Context)) *ContactHandlerMock_DeleteContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(echo.Context))
+	})
+	return _c
+}
+
+func (_c *ContactHandlerMock_DeleteContact_Call) Return(_a0 error) *ContactHandlerMock_DeleteContact_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactHandlerMock_DeleteContact_Call) RunAndReturn(run func(echo.Context) error) *ContactHandlerMock_DeleteContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetContact provides a mock function with given fields: ectx
+func (_m *ContactHandlerMock) GetContact(ectx echo.Context) error {
+	ret := _m.Called(ectx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ectx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactHandlerMock_GetContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContact'
+type ContactHandlerMock_GetContact_Call struct {
+	*mock.Call
+}
+
+// GetContact is a helper method to define mock.On call
+//   - ectx echo.Context
+func (_e *ContactHandlerMock_Expecter) GetContact(ectx interface{}) *ContactHandlerMock_GetContact_Call {
+	return &ContactHandlerMock_GetContact_Call{Call: _e.mock.On("GetContact", ectx)}
+}
+
+func (_c *ContactHandlerMock_GetContact_Call) Run(run func(ectx echo.Context)) *ContactHandlerMock_GetContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(echo.Context))
+	})
+	return _c
+}
+
+func (_c *ContactHandlerMock_GetContact_Call) Return(_a0 error) *ContactHandlerMock_GetContact_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactHandlerMock_GetContact_Call) RunAndReturn(run func(echo.Context) error) *ContactHandlerMock_GetContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchContact provides a mock function with given fields: ectx
+func (_m *ContactHandlerMock) PatchContact(ectx echo.Context) error {
+	ret := _m.Called(ectx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchContact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ectx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactHandlerMock_PatchContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchContact'
+type ContactHandlerMock_PatchContact_Call struct {
+	*mock.Call
+}
+
+// PatchContact is a helper method to define mock.On call
+//   - ectx echo.Context
+func (_e *ContactHandlerMock_Expecter) PatchContact(ectx interface{}) *ContactHandlerMock_PatchContact_Call {
+	return &ContactHandlerMock_PatchContact_Call{Call: _e.mock.On("PatchContact", ectx)}
+}
+
+func (_c *ContactHandlerMock_PatchContact_Call) Run(run func(ectx echo.Context)) *ContactHandlerMock_PatchContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(echo.Context))
+	})
+	return _c
+}
+
+func (_c *ContactHandlerMock_PatchContact_Call) Return(_a0 error) *ContactHandlerMock_PatchContact_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactHandlerMock_PatchContact_Call) RunAndReturn(run func(echo.Context) error) *ContactHandlerMock_PatchContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchContacts provides a mock function with given fields: ectx
+func (_m *ContactHandlerMock) SearchContacts(ectx echo.Context) error {
+	ret := _m.Called(ectx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchContacts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ectx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactHandlerMock_SearchContacts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchContacts'
+type ContactHandlerMock_SearchContacts_Call struct {
+	*mock.Call
+}
+
+// SearchContacts is a helper method to define mock.On call
+//   - ectx echo.Context
+func (_e *ContactHandlerMock_Expecter) SearchContacts(ectx interface{}) *ContactHandlerMock_SearchContacts_Call {
+	return &ContactHandlerMock_SearchContacts_Call{Call: _e.mock.On("SearchContacts", ectx)}
+}
+
+func (_c *ContactHandlerMock_SearchContacts_Call) Run(run func(ectx echo.Context)) *ContactHandlerMock_SearchContacts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(echo.Context))
+	})
+	return _c
+}
+
+func (_c *ContactHandlerMock_SearchContacts_Call) Return(_a0 error) *ContactHandlerMock_SearchContacts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactHandlerMock_SearchContacts_Call) RunAndReturn(run func(echo.Context) error) *ContactHandlerMock_SearchContacts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransferContact provides a mock function with given fields: ectx
+func (_m *ContactHandlerMock) TransferContact(ectx echo.Context) error {
+	ret := _m.Called(ectx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransferContact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ectx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactHandlerMock_TransferContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransferContact'
+type ContactHandlerMock_TransferContact_Call struct {
+	*mock.Call
+}
+
+// TransferContact is a helper method to define mock.On call
+//   - ectx echo.Context
+func (_e *ContactHandlerMock_Expecter) TransferContact(ectx interface{}) *ContactHandlerMock_TransferContact_Call {
+	return &ContactHandlerMock_TransferContact_Call{Call: _e.mock.On("TransferContact", ectx)}
+}
+
+func (_c *ContactHandlerMock_TransferContact_Call) Run(run func(ectx echo.Context)) *ContactHandlerMock_TransferContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(echo.Context))
+	})
+	return _c
+}
+
+func (_c *ContactHandlerMock_TransferContact_Call) Return(_a0 error) *ContactHandlerMock_TransferContact_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactHandlerMock_TransferContact_Call) RunAndReturn(run func(echo.Context) error) *ContactHandlerMock_TransferContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateContact provides a mock function with given fields: ectx
+func (_m *ContactHandlerMock) UpdateContact(ectx echo.Context) error {
+	ret := _m.Called(ectx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateContact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ectx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactHandlerMock_UpdateContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateContact'
+type ContactHandlerMock_UpdateContact_Call struct {
+	*mock.Call
+}
+
+// UpdateContact is a helper method to define mock.On call
+//   - ectx echo.Context
+func (_e *ContactHandlerMock_Expecter) UpdateContact(ectx interface{}) *ContactHandlerMock_UpdateContact_Call {
+	return &ContactHandlerMock_UpdateContact_Call{Call: _e.mock.On("UpdateContact", ectx)}
+}
+
+func (_c *ContactHandlerMock_UpdateContact_Call) Run(run func(ectx echo.Context)) *ContactHandlerMock_UpdateContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(echo.Context))
+	})
+	return _c
+}
+
+func (_c *ContactHandlerMock_UpdateContact_Call) Return(_a0 error) *ContactHandlerMock_UpdateContact_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactHandlerMock_UpdateContact_Call) RunAndReturn(run func(echo.Context) error) *ContactHandlerMock_UpdateContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewContactHandlerMock creates a new instance of ContactHandlerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewContactHandlerMock(t interface {
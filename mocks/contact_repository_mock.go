@@ -7,6 +7,8 @@ import (
 
 	models "github.com/g-villarinho/nubank-challenge/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // ContactRepositoryMock is an autogenerated mock type for the ContactRepository type
@@ -116,6 +118,161 @@ func (_c *ContactRepositoryMock_CreateContacts_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// DeleteContact provides a mock function with given fields: ctx, id, version
+func (_m *ContactRepositoryMock) DeleteContact(ctx context.Context, id string, version int64) error {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteContact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactRepositoryMock_DeleteContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteContact'
+type ContactRepositoryMock_DeleteContact_Call struct {
+	*mock.Call
+}
+
+// DeleteContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - version int64
+func (_e *ContactRepositoryMock_Expecter) DeleteContact(ctx interface{}, id interface{}, version interface{}) *ContactRepositoryMock_DeleteContact_Call {
+	return &ContactRepositoryMock_DeleteContact_Call{Call: _e.mock.On("DeleteContact", ctx, id, version)}
+}
+
+func (_c *ContactRepositoryMock_DeleteContact_Call) Run(run func(ctx context.Context, id string, version int64)) *ContactRepositoryMock_DeleteContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *ContactRepositoryMock_DeleteContact_Call) Return(_a0 error) *ContactRepositoryMock_DeleteContact_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactRepositoryMock_DeleteContact_Call) RunAndReturn(run func(context.Context, string, int64) error) *ContactRepositoryMock_DeleteContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteContactsByClientID provides a mock function with given fields: ctx, clientID, deletedAt
+func (_m *ContactRepositoryMock) DeleteContactsByClientID(ctx context.Context, clientID string, deletedAt time.Time) error {
+	ret := _m.Called(ctx, clientID, deletedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteContactsByClientID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, clientID, deletedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactRepositoryMock_DeleteContactsByClientID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteContactsByClientID'
+type ContactRepositoryMock_DeleteContactsByClientID_Call struct {
+	*mock.Call
+}
+
+// DeleteContactsByClientID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientID string
+//   - deletedAt time.Time
+func (_e *ContactRepositoryMock_Expecter) DeleteContactsByClientID(ctx interface{}, clientID interface{}, deletedAt interface{}) *ContactRepositoryMock_DeleteContactsByClientID_Call {
+	return &ContactRepositoryMock_DeleteContactsByClientID_Call{Call: _e.mock.On("DeleteContactsByClientID", ctx, clientID, deletedAt)}
+}
+
+func (_c *ContactRepositoryMock_DeleteContactsByClientID_Call) Run(run func(ctx context.Context, clientID string, deletedAt time.Time)) *ContactRepositoryMock_DeleteContactsByClientID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ContactRepositoryMock_DeleteContactsByClientID_Call) Return(_a0 error) *ContactRepositoryMock_DeleteContactsByClientID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactRepositoryMock_DeleteContactsByClientID_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *ContactRepositoryMock_DeleteContactsByClientID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetContactByID provides a mock function with given fields: ctx, id
+func (_m *ContactRepositoryMock) GetContactByID(ctx context.Context, id string) (*models.Contact, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContactByID")
+	}
+
+	var r0 *models.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Contact, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Contact); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Contact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactRepositoryMock_GetContactByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContactByID'
+type ContactRepositoryMock_GetContactByID_Call struct {
+	*mock.Call
+}
+
+// GetContactByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ContactRepositoryMock_Expecter) GetContactByID(ctx interface{}, id interface{}) *ContactRepositoryMock_GetContactByID_Call {
+	return &ContactRepositoryMock_GetContactByID_Call{Call: _e.mock.On("GetContactByID", ctx, id)}
+}
+
+func (_c *ContactRepositoryMock_GetContactByID_Call) Run(run func(ctx context.Context, id string)) *ContactRepositoryMock_GetContactByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ContactRepositoryMock_GetContactByID_Call) Return(_a0 *models.Contact, _a1 error) *ContactRepositoryMock_GetContactByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactRepositoryMock_GetContactByID_Call) RunAndReturn(run func(context.Context, string) (*models.Contact, error)) *ContactRepositoryMock_GetContactByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetContactsByClientID provides a mock function with given fields: ctx, clientID
 func (_m *ContactRepositoryMock) GetContactsByClientID(ctx context.Context, clientID string) ([]*models.Contact, error) {
 	ret := _m.Called(ctx, clientID)
@@ -175,6 +332,218 @@ func (_c *ContactRepositoryMock_GetContactsByClientID_Call) RunAndReturn(run fun
 	return _c
 }
 
+// PurgeDeletedContacts provides a mock function with given fields: ctx, before
+func (_m *ContactRepositoryMock) PurgeDeletedContacts(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedContacts")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactRepositoryMock_PurgeDeletedContacts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeletedContacts'
+type ContactRepositoryMock_PurgeDeletedContacts_Call struct {
+	*mock.Call
+}
+
+// PurgeDeletedContacts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *ContactRepositoryMock_Expecter) PurgeDeletedContacts(ctx interface{}, before interface{}) *ContactRepositoryMock_PurgeDeletedContacts_Call {
+	return &ContactRepositoryMock_PurgeDeletedContacts_Call{Call: _e.mock.On("PurgeDeletedContacts", ctx, before)}
+}
+
+func (_c *ContactRepositoryMock_PurgeDeletedContacts_Call) Run(run func(ctx context.Context, before time.Time)) *ContactRepositoryMock_PurgeDeletedContacts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ContactRepositoryMock_PurgeDeletedContacts_Call) Return(_a0 int64, _a1 error) *ContactRepositoryMock_PurgeDeletedContacts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactRepositoryMock_PurgeDeletedContacts_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *ContactRepositoryMock_PurgeDeletedContacts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreContactsByClientID provides a mock function with given fields: ctx, clientID, deletedAt
+func (_m *ContactRepositoryMock) RestoreContactsByClientID(ctx context.Context, clientID string, deletedAt time.Time) error {
+	ret := _m.Called(ctx, clientID, deletedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreContactsByClientID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, clientID, deletedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactRepositoryMock_RestoreContactsByClientID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreContactsByClientID'
+type ContactRepositoryMock_RestoreContactsByClientID_Call struct {
+	*mock.Call
+}
+
+// RestoreContactsByClientID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientID string
+//   - deletedAt time.Time
+func (_e *ContactRepositoryMock_Expecter) RestoreContactsByClientID(ctx interface{}, clientID interface{}, deletedAt interface{}) *ContactRepositoryMock_RestoreContactsByClientID_Call {
+	return &ContactRepositoryMock_RestoreContactsByClientID_Call{Call: _e.mock.On("RestoreContactsByClientID", ctx, clientID, deletedAt)}
+}
+
+func (_c *ContactRepositoryMock_RestoreContactsByClientID_Call) Run(run func(ctx context.Context, clientID string, deletedAt time.Time)) *ContactRepositoryMock_RestoreContactsByClientID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ContactRepositoryMock_RestoreContactsByClientID_Call) Return(_a0 error) *ContactRepositoryMock_RestoreContactsByClientID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactRepositoryMock_RestoreContactsByClientID_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *ContactRepositoryMock_RestoreContactsByClientID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchContacts provides a mock function with given fields: ctx, email, phone
+func (_m *ContactRepositoryMock) SearchContacts(ctx context.Context, email string, phone string) ([]*models.Contact, error) {
+	ret := _m.Called(ctx, email, phone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchContacts")
+	}
+
+	var r0 []*models.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.Contact, error)); ok {
+		return rf(ctx, email, phone)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*models.Contact); ok {
+		r0 = rf(ctx, email, phone)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Contact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, phone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactRepositoryMock_SearchContacts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchContacts'
+type ContactRepositoryMock_SearchContacts_Call struct {
+	*mock.Call
+}
+
+// SearchContacts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - phone string
+func (_e *ContactRepositoryMock_Expecter) SearchContacts(ctx interface{}, email interface{}, phone interface{}) *ContactRepositoryMock_SearchContacts_Call {
+	return &ContactRepositoryMock_SearchContacts_Call{Call: _e.mock.On("SearchContacts", ctx, email, phone)}
+}
+
+func (_c *ContactRepositoryMock_SearchContacts_Call) Run(run func(ctx context.Context, email string, phone string)) *ContactRepositoryMock_SearchContacts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ContactRepositoryMock_SearchContacts_Call) Return(_a0 []*models.Contact, _a1 error) *ContactRepositoryMock_SearchContacts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactRepositoryMock_SearchContacts_Call) RunAndReturn(run func(context.Context, string, string) ([]*models.Contact, error)) *ContactRepositoryMock_SearchContacts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateContact provides a mock function with given fields: ctx, contact
+func (_m *ContactRepositoryMock) UpdateContact(ctx context.Context, contact *models.Contact) error {
+	ret := _m.Called(ctx, contact)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateContact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Contact) error); ok {
+		r0 = rf(ctx, contact)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactRepositoryMock_UpdateContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateContact'
+type ContactRepositoryMock_UpdateContact_Call struct {
+	*mock.Call
+}
+
+// UpdateContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contact *models.Contact
+func (_e *ContactRepositoryMock_Expecter) UpdateContact(ctx interface{}, contact interface{}) *ContactRepositoryMock_UpdateContact_Call {
+	return &ContactRepositoryMock_UpdateContact_Call{Call: _e.mock.On("UpdateContact", ctx, contact)}
+}
+
+func (_c *ContactRepositoryMock_UpdateContact_Call) Run(run func(ctx context.Context, contact *models.Contact)) *ContactRepositoryMock_UpdateContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Contact))
+	})
+	return _c
+}
+
+func (_c *ContactRepositoryMock_UpdateContact_Call) Return(_a0 error) *ContactRepositoryMock_UpdateContact_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactRepositoryMock_UpdateContact_Call) RunAndReturn(run func(context.Context, *models.Contact) error) *ContactRepositoryMock_UpdateContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewContactRepositoryMock creates a new instance of ContactRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewContactRepositoryMock(t interface {
@@ -83,6 +83,356 @@ func (_c *ContactServiceMock_CreateContact_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// DeleteContact provides a mock function with given fields: ctx, id, version
+func (_m *ContactServiceMock) DeleteContact(ctx context.Context, id string, version int64) error {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteContact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactServiceMock_DeleteContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteContact'
+type ContactServiceMock_DeleteContact_Call struct {
+	*mock.Call
+}
+
+// DeleteContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - version int64
+func (_e *ContactServiceMock_Expecter) DeleteContact(ctx interface{}, id interface{}, version interface{}) *ContactServiceMock_DeleteContact_Call {
+	return &ContactServiceMock_DeleteContact_Call{Call: _e.mock.On("DeleteContact", ctx, id, version)}
+}
+
+func (_c *ContactServiceMock_DeleteContact_Call) Run(run func(ctx context.Context, id string, version int64)) *ContactServiceMock_DeleteContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *ContactServiceMock_DeleteContact_Call) Return(_a0 error) *ContactServiceMock_DeleteContact_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactServiceMock_DeleteContact_Call) RunAndReturn(run func(context.Context, string, int64) error) *ContactServiceMock_DeleteContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetContactByID provides a mock function with given fields: ctx, id
+func (_m *ContactServiceMock) GetContactByID(ctx context.Context, id string) (*models.ContactResponse, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContactByID")
+	}
+
+	var r0 *models.ContactResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.ContactResponse, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.ContactResponse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ContactResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactServiceMock_GetContactByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContactByID'
+type ContactServiceMock_GetContactByID_Call struct {
+	*mock.Call
+}
+
+// GetContactByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ContactServiceMock_Expecter) GetContactByID(ctx interface{}, id interface{}) *ContactServiceMock_GetContactByID_Call {
+	return &ContactServiceMock_GetContactByID_Call{Call: _e.mock.On("GetContactByID", ctx, id)}
+}
+
+func (_c *ContactServiceMock_GetContactByID_Call) Run(run func(ctx context.Context, id string)) *ContactServiceMock_GetContactByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ContactServiceMock_GetContactByID_Call) Return(_a0 *models.ContactResponse, _a1 error) *ContactServiceMock_GetContactByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactServiceMock_GetContactByID_Call) RunAndReturn(run func(context.Context, string) (*models.ContactResponse, error)) *ContactServiceMock_GetContactByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchContact provides a mock function with given fields: ctx, id, version, patch
+func (_m *ContactServiceMock) PatchContact(ctx context.Context, id string, version int64, patch []byte) (*models.ContactResponse, error) {
+	ret := _m.Called(ctx, id, version, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchContact")
+	}
+
+	var r0 *models.ContactResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, []byte) (*models.ContactResponse, error)); ok {
+		return rf(ctx, id, version, patch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, []byte) *models.ContactResponse); ok {
+		r0 = rf(ctx, id, version, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ContactResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, []byte) error); ok {
+		r1 = rf(ctx, id, version, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactServiceMock_PatchContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchContact'
+type ContactServiceMock_PatchContact_Call struct {
+	*mock.Call
+}
+
+// PatchContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - version int64
+//   - patch []byte
+func (_e *ContactServiceMock_Expecter) PatchContact(ctx interface{}, id interface{}, version interface{}, patch interface{}) *ContactServiceMock_PatchContact_Call {
+	return &ContactServiceMock_PatchContact_Call{Call: _e.mock.On("PatchContact", ctx, id, version, patch)}
+}
+
+func (_c *ContactServiceMock_PatchContact_Call) Run(run func(ctx context.Context, id string, version int64, patch []byte)) *ContactServiceMock_PatchContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *ContactServiceMock_PatchContact_Call) Return(_a0 *models.ContactResponse, _a1 error) *ContactServiceMock_PatchContact_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactServiceMock_PatchContact_Call) RunAndReturn(run func(context.Context, string, int64, []byte) (*models.ContactResponse, error)) *ContactServiceMock_PatchContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchContacts provides a mock function with given fields: ctx, email, phone
+func (_m *ContactServiceMock) SearchContacts(ctx context.Context, email string, phone string) ([]models.ContactResponse, error) {
+	ret := _m.Called(ctx, email, phone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchContacts")
+	}
+
+	var r0 []models.ContactResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]models.ContactResponse, error)); ok {
+		return rf(ctx, email, phone)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []models.ContactResponse); ok {
+		r0 = rf(ctx, email, phone)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ContactResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, phone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactServiceMock_SearchContacts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchContacts'
+type ContactServiceMock_SearchContacts_Call struct {
+	*mock.Call
+}
+
+// SearchContacts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - phone string
+func (_e *ContactServiceMock_Expecter) SearchContacts(ctx interface{}, email interface{}, phone interface{}) *ContactServiceMock_SearchContacts_Call {
+	return &ContactServiceMock_SearchContacts_Call{Call: _e.mock.On("SearchContacts", ctx, email, phone)}
+}
+
+func (_c *ContactServiceMock_SearchContacts_Call) Run(run func(ctx context.Context, email string, phone string)) *ContactServiceMock_SearchContacts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ContactServiceMock_SearchContacts_Call) Return(_a0 []models.ContactResponse, _a1 error) *ContactServiceMock_SearchContacts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactServiceMock_SearchContacts_Call) RunAndReturn(run func(context.Context, string, string) ([]models.ContactResponse, error)) *ContactServiceMock_SearchContacts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransferContact provides a mock function with given fields: ctx, id, clientId
+func (_m *ContactServiceMock) TransferContact(ctx context.Context, id string, clientId string) (*models.ContactResponse, error) {
+	ret := _m.Called(ctx, id, clientId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransferContact")
+	}
+
+	var r0 *models.ContactResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.ContactResponse, error)); ok {
+		return rf(ctx, id, clientId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.ContactResponse); ok {
+		r0 = rf(ctx, id, clientId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ContactResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, clientId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactServiceMock_TransferContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransferContact'
+type ContactServiceMock_TransferContact_Call struct {
+	*mock.Call
+}
+
+// TransferContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - clientId string
+func (_e *ContactServiceMock_Expecter) TransferContact(ctx interface{}, id interface{}, clientId interface{}) *ContactServiceMock_TransferContact_Call {
+	return &ContactServiceMock_TransferContact_Call{Call: _e.mock.On("TransferContact", ctx, id, clientId)}
+}
+
+func (_c *ContactServiceMock_TransferContact_Call) Run(run func(ctx context.Context, id string, clientId string)) *ContactServiceMock_TransferContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ContactServiceMock_TransferContact_Call) Return(_a0 *models.ContactResponse, _a1 error) *ContactServiceMock_TransferContact_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactServiceMock_TransferContact_Call) RunAndReturn(run func(context.Context, string, string) (*models.ContactResponse, error)) *ContactServiceMock_TransferContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateContact provides a mock function with given fields: ctx, id, version, phone, email
+func (_m *ContactServiceMock) UpdateContact(ctx context.Context, id string, version int64, phone string, email string) (*models.ContactResponse, error) {
+	ret := _m.Called(ctx, id, version, phone, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateContact")
+	}
+
+	var r0 *models.ContactResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string, string) (*models.ContactResponse, error)); ok {
+		return rf(ctx, id, version, phone, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string, string) *models.ContactResponse); ok {
+		r0 = rf(ctx, id, version, phone, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ContactResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, string, string) error); ok {
+		r1 = rf(ctx, id, version, phone, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactServiceMock_UpdateContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateContact'
+type ContactServiceMock_UpdateContact_Call struct {
+	*mock.Call
+}
+
+// UpdateContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - version int64
+//   - phone string
+//   - email string
+func (_e *ContactServiceMock_Expecter) UpdateContact(ctx interface{}, id interface{}, version interface{}, phone interface{}, email interface{}) *ContactServiceMock_UpdateContact_Call {
+	return &ContactServiceMock_UpdateContact_Call{Call: _e.mock.On("UpdateContact", ctx, id, version, phone, email)}
+}
+
+func (_c *ContactServiceMock_UpdateContact_Call) Run(run func(ctx context.Context, id string, version int64, phone string, email string)) *ContactServiceMock_UpdateContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *ContactServiceMock_UpdateContact_Call) Return(_a0 *models.ContactResponse, _a1 error) *ContactServiceMock_UpdateContact_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactServiceMock_UpdateContact_Call) RunAndReturn(run func(context.Context, string, int64, string, string) (*models.ContactResponse, error)) *ContactServiceMock_UpdateContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewContactServiceMock creates a new instance of ContactServiceMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewContactServiceMock(t interface {
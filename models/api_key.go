@@ -0,0 +1,127 @@
+package models
+
+import (
+	"database/sql"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderAPIKey = "X-API-Key"
+
+	// APIKeyPrefix identifica as chaves desta API, por exemplo em ferramentas de detecção de segredos
+	APIKeyPrefix = "nbk_"
+
+	// APIKeyDisplayLength é a quantidade de caracteres da chave guardados em claro para identificá-la
+	APIKeyDisplayLength = 12
+)
+
+const (
+	ScopeClientsRead    = "clients:read"
+	ScopeClientsWrite   = "clients:write"
+	ScopeContactsRead   = "contacts:read"
+	ScopeContactsWrite  = "contacts:write"
+	ScopeAuditRead      = "audit:read"
+	ScopeWebhooksManage = "webhooks:manage"
+
+	// ScopePlatform permite que uma credencial sem tenant escolha o tenant pelo header X-Tenant-ID
+	ScopePlatform = "platform"
+)
+
+// Scopes lista os escopos que podem ser concedidos a uma credencial
+var Scopes = []string{
+	ScopeClientsRead,
+	ScopeClientsWrite,
+	ScopeContactsRead,
+	ScopeContactsWrite,
+	ScopeAuditRead,
+	ScopeWebhooksManage,
+	ScopePlatform,
+}
+
+// APIKey é uma chave de acesso à API. Apenas o hash SHA-256 da chave é guardado; Prefix mantém
+// o início da chave para que ela possa ser reconhecida na listagem.
+type APIKey struct {
+	ID     string `gorm:"type:uuid;primaryKey"`
+	Name   string `gorm:"not null"`
+	Prefix string `gorm:"not null"`
+	Hash   string `gorm:"not null;uniqueIndex"`
+	Scopes string `gorm:"not null"`
+
+	// TenantID vincula a chave a um tenant; vazio permite escolher o tenant pelo header X-Tenant-ID
+	TenantID string `gorm:"not null;default:''"`
+
+	CreatedAt  time.Time    `gorm:"not null"`
+	LastUsedAt sql.NullTime `gorm:"default:null"`
+	RevokedAt  sql.NullTime `gorm:"default:null"`
+}
+
+type CreateAPIKeyPayload struct {
+	Name     string   `json:"name" binding:"required,max=100"`
+	Scopes   []string `json:"scopes" binding:"required,min=1,dive,scope"`
+	TenantID string   `json:"tenantId" binding:"omitempty,tenant"`
+}
+
+type APIKeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	TenantID   string     `json:"tenantId,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+// CreatedAPIKeyResponse é devolvido apenas na criação, única vez em que a chave aparece em claro
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+func (a *APIKey) GetScopes() []string {
+	if a.Scopes == "" {
+		return []string{}
+	}
+
+	return strings.Split(a.Scopes, ",")
+}
+
+func (a *APIKey) SetScopes(scopes []string) {
+	sorted := slices.Clone(scopes)
+	slices.Sort(sorted)
+
+	a.Scopes = strings.Join(slices.Compact(sorted), ",")
+}
+
+func (a *APIKey) ToPrincipal() *Principal {
+	return &Principal{
+		Method:   AuthMethodAPIKey,
+		ID:       a.ID,
+		Subject:  a.Name,
+		Scopes:   a.GetScopes(),
+		TenantID: a.TenantID,
+	}
+}
+
+func (a *APIKey) ToAPIKeyResponse() *APIKeyResponse {
+	response := &APIKeyResponse{
+		ID:        a.ID,
+		Name:      a.Name,
+		Prefix:    a.Prefix,
+		Scopes:    a.GetScopes(),
+		TenantID:  a.TenantID,
+		CreatedAt: a.CreatedAt,
+	}
+
+	if a.LastUsedAt.Valid {
+		response.LastUsedAt = &a.LastUsedAt.Time
+	}
+
+	if a.RevokedAt.Valid {
+		response.RevokedAt = &a.RevokedAt.Time
+	}
+
+	return response
+}
@@ -0,0 +1,195 @@
+package models
+
+import (
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	AuditEntityClient  = "client"
+	AuditEntityContact = "contact"
+)
+
+const (
+	AuditActionCreate   = "create"
+	AuditActionUpdate   = "update"
+	AuditActionDelete   = "delete"
+	AuditActionRestore  = "restore"
+	AuditActionTransfer = "transfer"
+)
+
+// HeaderActor identifica quem está realizando a requisição
+const HeaderActor = "X-Actor"
+
+// AnonymousActor é registrado quando a requisição não identifica quem a realizou
+const AnonymousActor = "anonymous"
+
+const AuditSortCreatedAt = "-created_at"
+
+// AuditLog registra uma alteração em um cliente ou contato. A tabela é somente de inserção:
+// as migrações impedem UPDATE, DELETE e TRUNCATE.
+type AuditLog struct {
+	ID         string `gorm:"type:uuid;primaryKey;index:idx_audit_logs_created_at_id,priority:2"`
+	EntityType string `gorm:"not null;index:idx_audit_logs_entity,priority:1"`
+	EntityID   string `gorm:"type:uuid;not null;index:idx_audit_logs_entity,priority:2"`
+
+	// TenantID é o tenant do cliente alterado; a política de row-level security esconde os
+	// registros dos outros tenants
+	TenantID string `gorm:"type:varchar(64);not null;default:'default';index"`
+
+	// ClientID é o cliente afetado pela alteração e permite montar o histórico do cliente
+	// incluindo as alterações em seus contatos
+	ClientID string `gorm:"type:uuid;not null;index"`
+
+	Action    string `gorm:"not null"`
+	Actor     string `gorm:"not null;index"`
+	RequestID string `gorm:"not null;default:''"`
+
+	Before []byte `gorm:"type:jsonb"`
+	After  []byte `gorm:"type:jsonb"`
+	Diff   []byte `gorm:"type:jsonb;not null"`
+
+	CreatedAt time.Time `gorm:"not null;index:idx_audit_logs_created_at_id,priority:1"`
+}
+
+// AuditEntry descreve uma alteração a ser registrada. Before é nil na criação e After é nil na remoção.
+type AuditEntry struct {
+	EntityType string
+	EntityID   string
+	ClientID   string
+	Action     string
+	Before     any
+	After      any
+}
+
+// ClientSnapshot é a representação do cliente, sem os contatos, guardada no registro de auditoria
+type ClientSnapshot struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Version   int64      `json:"version"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// ContactSnapshot é a representação do contato guardada no registro de auditoria. Como a tabela
+// é somente de inserção e o expurgo não a alcança, o email e o telefone não são guardados: no
+// lugar deles ficam os hashes SHA-256 dos valores normalizados, que mostram quando cada campo
+// mudou e permitem conferir um valor conhecido.
+type ContactSnapshot struct {
+	ID        string     `json:"id"`
+	ClientID  string     `json:"clientId,omitempty"`
+	EmailHash string     `json:"emailSha256"`
+	PhoneHash string     `json:"phoneSha256"`
+	Version   int64      `json:"version"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+type AuditQuery struct {
+	Cursor     string    `query:"cursor" json:"cursor"`
+	Limit      int       `query:"limit" json:"limit" binding:"omitempty,min=1"`
+	EntityType string    `query:"entityType" json:"entityType" binding:"omitempty,oneof=client contact"`
+	EntityID   string    `query:"entityId" json:"entityId" binding:"omitempty,uuid"`
+	ClientID   string    `query:"clientId" json:"clientId" binding:"omitempty,uuid"`
+	Action     string    `query:"action" json:"action" binding:"omitempty,oneof=create update delete restore transfer"`
+	Actor      string    `query:"actor" json:"actor"`
+	RequestID  string    `query:"requestId" json:"requestId"`
+	From       time.Time `query:"from" json:"from"`
+	To         time.Time `query:"to" json:"to" binding:"omitempty,gtfield=From"`
+}
+
+// AuditListOptions descreve uma página de registros de auditoria a ser buscada por keyset,
+// sempre do mais recente para o mais antigo
+type AuditListOptions struct {
+	Limit      int
+	Cursor     *Cursor
+	EntityType string
+	EntityID   string
+	ClientID   string
+	Action     string
+	Actor      string
+	RequestID  string
+	From       time.Time
+	To         time.Time
+}
+
+type AuditLogResponse struct {
+	ID         string              `json:"id"`
+	EntityType string              `json:"entityType" example:"client"`
+	EntityID   string              `json:"entityId"`
+	ClientID   string              `json:"clientId"`
+	Action     string              `json:"action" example:"update"`
+	Actor      string              `json:"actor" example:"backoffice"`
+	RequestID  string              `json:"requestId,omitempty"`
+	Before     jsoniter.RawMessage `json:"before" swaggertype:"object"`
+	After      jsoniter.RawMessage `json:"after" swaggertype:"object"`
+	Diff       jsoniter.RawMessage `json:"diff" swaggertype:"object"`
+	CreatedAt  time.Time           `json:"createdAt"`
+}
+
+type AuditPageResponse struct {
+	Data []AuditLogResponse `json:"data"`
+	Meta PageMeta           `json:"meta"`
+}
+
+func (q *AuditQuery) PageLimit() int {
+	if q.Limit <= 0 {
+		return DefaultPageLimit
+	}
+
+	return min(q.Limit, MaxPageLimit)
+}
+
+func (a *AuditLog) ToAuditLogResponse() *AuditLogResponse {
+	return &AuditLogResponse{
+		ID:         a.ID,
+		EntityType: a.EntityType,
+		EntityID:   a.EntityID,
+		ClientID:   a.ClientID,
+		Action:     a.Action,
+		Actor:      a.Actor,
+		RequestID:  a.RequestID,
+		Before:     rawJSON(a.Before),
+		After:      rawJSON(a.After),
+		Diff:       rawJSON(a.Diff),
+		CreatedAt:  a.CreatedAt,
+	}
+}
+
+// ToCursor gera o cursor que aponta para o registro na ordenação do mais recente para o mais antigo
+func (a *AuditLog) ToCursor() *Cursor {
+	return &Cursor{
+		Sort:  AuditSortCreatedAt,
+		Value: a.CreatedAt.UTC().Format(time.RFC3339Nano),
+		ID:    a.ID,
+	}
+}
+
+func (c *Client) ToSnapshot() *ClientSnapshot {
+	snapshot := &ClientSnapshot{
+		ID:        c.ID,
+		Name:      c.Name,
+		Version:   c.Version,
+		CreatedAt: c.CreatedAt,
+	}
+
+	if c.UpdatedAt.Valid {
+		snapshot.UpdatedAt = &c.UpdatedAt.Time
+	}
+
+	if c.DeletedAt.Valid {
+		snapshot.DeletedAt = &c.DeletedAt.Time
+	}
+
+	return snapshot
+}
+
+func rawJSON(data []byte) jsoniter.RawMessage {
+	if len(data) == 0 {
+		return jsoniter.RawMessage("null")
+	}
+
+	return data
+}
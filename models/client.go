@@ -2,40 +2,75 @@ package models
 
 import (
 	"database/sql"
-	"errors"
 	"time"
-)
 
-var (
-	ErrClientNotFound = errors.New("client not found")
+	"gorm.io/gorm"
 )
 
 type Client struct {
-	ID   string `gorm:"type:uuid;primaryKey"`
-	Name string `gorm:"not null"`
+	ID string `gorm:"type:uuid;primaryKey;index:idx_clients_tenant_name_id,priority:3;index:idx_clients_tenant_created_at_id,priority:3"`
+
+	// Name também é indexado com text_pattern_ops, para que o filtro por prefixo (LIKE 'Ga%') use
+	// o índice mesmo quando a collation do banco não é C
+	Name string `gorm:"not null;index:idx_clients_tenant_name_id,priority:2;index:idx_clients_tenant_name_prefix,priority:2,expression:name text_pattern_ops"`
+
+	// TenantID identifica a unidade de negócio dona do cliente. As linhas de outros tenants são
+	// filtradas pelos repositórios e barradas pelas políticas de row-level security do Postgres.
+	// Os índices da paginação começam pelo tenant, para que a listagem de um tenant não percorra
+	// os clientes dos demais.
+	TenantID string `gorm:"type:varchar(64);not null;default:'default';index:idx_clients_tenant_name_id,priority:1;index:idx_clients_tenant_created_at_id,priority:1;index:idx_clients_tenant_name_prefix,priority:1"`
+
+	// Version é incrementada a cada alteração do cliente ou de seus contatos e identifica a
+	// representação nos headers ETag e If-Match
+	Version int64 `gorm:"not null;default:1"`
 
-	Contacts  []Contact    `gorm:"foreignKey:ClientID"`
-	CreatedAt time.Time    `gorm:"not null"`
-	UpdatedAt sql.NullTime `gorm:"default:null"`
+	Contacts  []Contact      `gorm:"foreignKey:ClientID"`
+	CreatedAt time.Time      `gorm:"not null;index:idx_clients_tenant_created_at_id,priority:2"`
+	UpdatedAt sql.NullTime   `gorm:"default:null"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
 type CreateClientPayload struct {
 	Name     string                 `json:"name" binding:"required" example:"Gabriel Villarinho"`
-	Contacts []CreateContactPayload `json:"contacts" binding:"required"`
+	Contacts []ClientContactPayload `json:"contacts" binding:"required,dive"`
+}
+
+type UpdateClientPayload struct {
+	Name string `json:"name" binding:"required" example:"Gabriel Villarinho"`
 }
 
 type ClientResponse struct {
 	ID        string             `json:"id"`
 	Name      string             `json:"name"`
+	Version   int64              `json:"version"`
 	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt *time.Time         `json:"updated_at,omitempty"`
+	DeletedAt *time.Time         `json:"deleted_at,omitempty"`
 	Contacts  []*ContactResponse `json:"contacts"`
 }
 
 func (c *Client) ToClientResponse() *ClientResponse {
-	return &ClientResponse{
+	response := &ClientResponse{
 		ID:        c.ID,
 		Name:      c.Name,
+		Version:   c.Version,
 		CreatedAt: c.CreatedAt,
 		Contacts:  ToContactResponses(c.Contacts),
 	}
+
+	if c.UpdatedAt.Valid {
+		response.UpdatedAt = &c.UpdatedAt.Time
+	}
+
+	if c.DeletedAt.Valid {
+		response.DeletedAt = &c.DeletedAt.Time
+	}
+
+	return response
+}
+
+func (c *Client) ToUpdateClientPayload() *UpdateClientPayload {
+	return &UpdateClientPayload{
+		Name: c.Name,
+	}
 }
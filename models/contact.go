@@ -3,6 +3,8 @@ package models
 import (
 	"database/sql"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Contact struct {
@@ -10,11 +12,22 @@ type Contact struct {
 	Phone string `gorm:"not null"`
 	Email string `gorm:"not null"`
 
+	// Versões normalizadas de Phone e Email usadas na busca reversa por contato
+	NormalizedPhone string `gorm:"not null;default:'';index"`
+	NormalizedEmail string `gorm:"not null;default:'';index"`
+
+	Version int64 `gorm:"not null;default:1"`
+
+	// TenantID repete o tenant do cliente para que a política de row-level security dos
+	// contatos não dependa de uma junção com clients
+	TenantID string `gorm:"type:varchar(64);not null;default:'default';index"`
+
 	ClientID string `gorm:"type:uuid;not null"`
 	Client   Client `gorm:"foreignKey:ClientID"`
 
-	CreatedAt time.Time    `gorm:"not null"`
-	UpdatedAt sql.NullTime `gorm:"default:null"`
+	CreatedAt time.Time      `gorm:"not null"`
+	UpdatedAt sql.NullTime   `gorm:"default:null"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
 type CreateContactPayload struct {
@@ -23,23 +36,60 @@ type CreateContactPayload struct {
 	ClientID string `json:"clientId" binding:"required,uuid" example:"7a395834-0ed5-4954-8e1d-b63cd2fdb97a"`
 }
 
+type ClientContactPayload struct {
+	Phone string `json:"phone" binding:"required,e164" example:"+5521999999999"`
+	Email string `json:"email" binding:"required,email" example:"gabriel@gmail.com"`
+}
+
+type UpdateContactPayload struct {
+	Phone string `json:"phone" binding:"required,e164" example:"+5521999999999"`
+	Email string `json:"email" binding:"required,email" example:"gabriel@gmail.com"`
+}
+
+type TransferContactPayload struct {
+	ClientID string `json:"clientId" binding:"required,uuid" example:"7a395834-0ed5-4954-8e1d-b63cd2fdb97a"`
+}
+
+type ContactLookupQuery struct {
+	Email string `query:"email" json:"email" binding:"required_without=Phone"`
+	Phone string `query:"phone" json:"phone" binding:"required_without=Email"`
+}
+
 type ContactResponse struct {
-	ID        string    `json:"id"`
-	Phone     string    `json:"phone"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"createdAt"`
+	ID        string     `json:"id"`
+	Phone     string     `json:"phone"`
+	Email     string     `json:"email"`
+	ClientID  string     `json:"clientId,omitempty"`
+	Version   int64      `json:"version"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
 }
 
 func (c *Contact) ToContactResponse() *ContactResponse {
-	return &ContactResponse{
+	response := &ContactResponse{
 		ID:        c.ID,
 		Phone:     c.Phone,
 		Email:     c.Email,
+		ClientID:  c.ClientID,
+		Version:   c.Version,
 		CreatedAt: c.CreatedAt,
 	}
+
+	if c.UpdatedAt.Valid {
+		response.UpdatedAt = &c.UpdatedAt.Time
+	}
+
+	return response
+}
+
+func (c *Contact) ToUpdateContactPayload() *UpdateContactPayload {
+	return &UpdateContactPayload{
+		Phone: c.Phone,
+		Email: c.Email,
+	}
 }
 
-func ToContacts(payloads []CreateContactPayload) []*Contact {
+func ToContacts(payloads []ClientContactPayload) []*Contact {
 	contacts := make([]*Contact, len(payloads))
 	for i, p := range payloads {
 		contacts[i] = &Contact{
@@ -57,6 +107,7 @@ func ToContactResponses(contacts []Contact) []*ContactResponse {
 			ID:        c.ID,
 			Phone:     c.Phone,
 			Email:     c.Email,
+			Version:   c.Version,
 			CreatedAt: c.CreatedAt,
 		}
 	}
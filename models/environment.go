@@ -1,19 +1,143 @@
 package models
 
 type Environment struct {
-	Env      string `env:"ENV"`
-	Postgres Postgres
+	Env         string `env:"ENV"`
+	Postgres    Postgres
+	Purge       Purge
+	Idempotency Idempotency
+	Outbox      Outbox
+	Webhook     Webhook
+	JWT         JWT
+	RateLimit   RateLimit
+	Tracing     Tracing
+	Health      Health
+	Shutdown    Shutdown
+	Metrics     Metrics
+	HTTP        HTTP
 }
 
 type Postgres struct {
-	Host        string `env:"POSTGRES_HOST"`
-	Port        int    `env:"POSTGRES_PORT"`
-	User        string `env:"POSTGRES_USER"`
-	Password    string `env:"POSTGRES_PASSWORD"`
-	DBName      string `env:"POSTGRES_NAME"`
-	DBSSLMode   string `env:"POSTGRES_SSL_MODE"`
-	MaxConn     int    `env:"POSTGRES_MAX_CONN"`
-	MaxIdle     int    `env:"POSTGRES_MAX_IDLE"`
-	MaxLifeTime int    `env:"POSTGRES_MAX_LIFE_TIME"`
-	Timeout     int    `env:"POSTGRES_TIMEOUT"`
+	Host     string `env:"POSTGRES_HOST"`
+	Port     int    `env:"POSTGRES_PORT"`
+	User     string `env:"POSTGRES_USER"`
+	Password string `env:"POSTGRES_PASSWORD"`
+	// OwnerUser é o dono das tabelas, usado só pelas migrations. A aplicação se conecta com User,
+	// criado pelas migrations sem permissão para ignorar as políticas de row-level security.
+	OwnerUser     string `env:"POSTGRES_OWNER_USER"`
+	OwnerPassword string `env:"POSTGRES_OWNER_PASSWORD"`
+	// JobsUser é usado pelas rotinas que alcançam todos os tenants (expurgo, relay do outbox e
+	// dispatcher de webhooks). É criado pelas migrations com BYPASSRLS.
+	JobsUser     string `env:"POSTGRES_JOBS_USER"`
+	JobsPassword string `env:"POSTGRES_JOBS_PASSWORD"`
+	DBName       string `env:"POSTGRES_NAME"`
+	DBSSLMode    string `env:"POSTGRES_SSL_MODE"`
+	MaxConn      int    `env:"POSTGRES_MAX_CONN"`
+	MaxIdle      int    `env:"POSTGRES_MAX_IDLE"`
+	MaxLifeTime  int    `env:"POSTGRES_MAX_LIFE_TIME"`
+	Timeout      int    `env:"POSTGRES_TIMEOUT"`
+}
+
+type Purge struct {
+	RetentionDays int `env:"PURGE_RETENTION_DAYS,default=30"`
+}
+
+type Idempotency struct {
+	TTLHours int `env:"IDEMPOTENCY_TTL_HOURS,default=24"`
+}
+
+type Outbox struct {
+	// Publisher escolhe onde os eventos são publicados: memory ou logfile
+	Publisher      string `env:"OUTBOX_PUBLISHER,default=logfile"`
+	LogFile        string `env:"OUTBOX_LOG_FILE,default=outbox.log"`
+	PollIntervalMs int    `env:"OUTBOX_POLL_INTERVAL_MS,default=1000"`
+	BatchSize      int    `env:"OUTBOX_BATCH_SIZE,default=100"`
+	RetentionHours int    `env:"OUTBOX_RETENTION_HOURS,default=168"`
+	MaxAttempts    int    `env:"OUTBOX_MAX_ATTEMPTS,default=10"`
+}
+
+type Webhook struct {
+	MaxAttempts      int `env:"WEBHOOK_MAX_ATTEMPTS,default=8"`
+	RetryBaseSeconds int `env:"WEBHOOK_RETRY_BASE_SECONDS,default=30"`
+	RetryMaxSeconds  int `env:"WEBHOOK_RETRY_MAX_SECONDS,default=3600"`
+	TimeoutSeconds   int `env:"WEBHOOK_TIMEOUT_SECONDS,default=10"`
+	PollIntervalMs   int `env:"WEBHOOK_POLL_INTERVAL_MS,default=1000"`
+	BatchSize        int `env:"WEBHOOK_BATCH_SIZE,default=20"`
+}
+
+// JWT configura a autenticação por token Bearer. Sem JWT_JWKS_FILE e JWT_JWKS_URL apenas
+// chaves de API são aceitas; com um deles, JWT_ISSUER e JWT_AUDIENCE são obrigatórios.
+type JWT struct {
+	JWKSFile         string `env:"JWT_JWKS_FILE"`
+	JWKSURL          string `env:"JWT_JWKS_URL"`
+	Issuer           string `env:"JWT_ISSUER"`
+	Audience         string `env:"JWT_AUDIENCE"`
+	ClockSkewSeconds int    `env:"JWT_CLOCK_SKEW_SECONDS,default=60"`
+	JWKSCacheMinutes int    `env:"JWT_JWKS_CACHE_MINUTES,default=15"`
+}
+
+// RateLimit configura o limite de requisições por credencial em cada grupo de rotas: leitura,
+// escrita e administração (auditoria e webhooks). O grupo anon limita por IP antes da autenticação.
+type RateLimit struct {
+	Enabled bool `env:"RATE_LIMIT_ENABLED,default=true"`
+
+	// Store escolhe onde os baldes ficam: memory, por instância, ou postgres, compartilhado
+	// entre as instâncias
+	Store string `env:"RATE_LIMIT_STORE,default=memory"`
+
+	ReadPerMinute  int `env:"RATE_LIMIT_READ_PER_MINUTE,default=600"`
+	ReadBurst      int `env:"RATE_LIMIT_READ_BURST,default=100"`
+	WritePerMinute int `env:"RATE_LIMIT_WRITE_PER_MINUTE,default=120"`
+	WriteBurst     int `env:"RATE_LIMIT_WRITE_BURST,default=20"`
+	AdminPerMinute int `env:"RATE_LIMIT_ADMIN_PER_MINUTE,default=60"`
+	AdminBurst     int `env:"RATE_LIMIT_ADMIN_BURST,default=10"`
+	AnonPerMinute  int `env:"RATE_LIMIT_ANON_PER_MINUTE,default=1200"`
+	AnonBurst      int `env:"RATE_LIMIT_ANON_BURST,default=200"`
+}
+
+// Policies retorna a política de cada grupo de rotas
+func (r RateLimit) Policies() map[string]RateLimitPolicy {
+	return map[string]RateLimitPolicy{
+		RateLimitGroupRead:  {Burst: r.ReadBurst, PerMinute: r.ReadPerMinute},
+		RateLimitGroupWrite: {Burst: r.WriteBurst, PerMinute: r.WritePerMinute},
+		RateLimitGroupAdmin: {Burst: r.AdminBurst, PerMinute: r.AdminPerMinute},
+		RateLimitGroupAnon:  {Burst: r.AnonBurst, PerMinute: r.AnonPerMinute},
+	}
+}
+
+// Tracing configura a exportação dos spans do OpenTelemetry. Com o exportador none os spans
+// não são gravados, mas o traceparent recebido ainda é repassado nas chamadas de saída.
+type Tracing struct {
+	// Exporter escolhe para onde os spans vão: none, stdout ou otlp
+	Exporter     string  `env:"TRACING_EXPORTER,default=none"`
+	ServiceName  string  `env:"TRACING_SERVICE_NAME,default=nubank-challenge"`
+	SampleRatio  float64 `env:"TRACING_SAMPLE_RATIO,default=1"`
+	OTLPEndpoint string  `env:"TRACING_OTLP_ENDPOINT,default=localhost:4318"`
+	OTLPInsecure bool    `env:"TRACING_OTLP_INSECURE,default=true"`
+}
+
+// Health configura o /readyz. Ao receber o sinal de desligamento a instância passa a responder
+// draining e espera DrainSeconds antes de parar de aceitar conexões, para que o balanceador a
+// retire do grupo sem derrubar requisições.
+type Health struct {
+	CheckTimeoutMs int `env:"HEALTH_CHECK_TIMEOUT_MS,default=1000"`
+	DrainSeconds   int `env:"HEALTH_DRAIN_SECONDS,default=5"`
+}
+
+// Shutdown limita quanto o desligamento espera as requisições em andamento e o ciclo em
+// andamento dos workers antes de fechar as conexões com o banco
+type Shutdown struct {
+	TimeoutSeconds int `env:"SHUTDOWN_TIMEOUT_SECONDS,default=20"`
+}
+
+// Metrics protege o /metrics. O coletor envia Token no header Authorization (Bearer); sem ele
+// configurado, a rota não é exposta.
+type Metrics struct {
+	Token string `env:"METRICS_TOKEN"`
+}
+
+// HTTP configura de onde vem o IP do cliente, usado no limite anônimo e nos logs. Sem
+// TrustedProxies o IP é o da conexão; com ele, é o primeiro endereço do X-Forwarded-For, lido da
+// direita, que não pertence a um dos proxies confiáveis (CIDRs ou IPs separados por vírgula).
+type HTTP struct {
+	TrustedProxies []string `env:"HTTP_TRUSTED_PROXIES,separator=,"`
 }
@@ -0,0 +1,31 @@
+package models
+
+import "errors"
+
+var (
+	ErrValidation      = errors.New("validation failed")
+	ErrInvalidPayload  = errors.New("invalid payload")
+	ErrClientNotFound  = errors.New("client not found")
+	ErrContactNotFound = errors.New("contact not found")
+	ErrConflict        = errors.New("resource conflict")
+
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrAPIKeyNotFound          = errors.New("api key not found")
+
+	ErrUnauthorized = errors.New("authentication required")
+	ErrForbidden    = errors.New("insufficient scope")
+
+	ErrAuthenticationUnavailable = errors.New("authentication is temporarily unavailable")
+
+	ErrTenantForbidden = errors.New("credential cannot access this tenant")
+	ErrTenantRequired  = errors.New("tenant is required")
+
+	ErrPreconditionFailed   = errors.New("resource was modified since it was read")
+	ErrPreconditionRequired = errors.New("if-match header is required")
+
+	ErrTooManyRequests = errors.New("rate limit exceeded")
+
+	ErrIdempotencyKeyReused     = errors.New("idempotency key reused with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("idempotency key request still in progress")
+)
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	EventClientCreated      = "ClientCreated"
+	EventClientUpdated      = "ClientUpdated"
+	EventClientDeleted      = "ClientDeleted"
+	EventClientRestored     = "ClientRestored"
+	EventContactAdded       = "ContactAdded"
+	EventContactUpdated     = "ContactUpdated"
+	EventContactRemoved     = "ContactRemoved"
+	EventContactTransferred = "ContactTransferred"
+)
+
+// DomainEvent é um fato ocorrido em um cliente ou em seus contatos. AggregateID é o cliente ao
+// qual o fato pertence: os eventos de um mesmo cliente são entregues na ordem em que ocorreram.
+type DomainEvent interface {
+	EventType() string
+	AggregateID() string
+}
+
+type ClientCreated struct {
+	Client *ClientSnapshot `json:"client"`
+}
+
+type ClientUpdated struct {
+	Client *ClientSnapshot `json:"client"`
+}
+
+type ClientDeleted struct {
+	ClientID  string    `json:"clientId"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+type ClientRestored struct {
+	Client   *ClientSnapshot    `json:"client"`
+	Contacts []*ContactResponse `json:"contacts"`
+}
+
+type ContactAdded struct {
+	Contact *ContactResponse `json:"contact"`
+}
+
+type ContactUpdated struct {
+	Contact *ContactResponse `json:"contact"`
+}
+
+type ContactRemoved struct {
+	ContactID string `json:"contactId"`
+	ClientID  string `json:"clientId"`
+}
+
+// ContactTransferred pertence ao cliente de destino, para que seja entregue antes das
+// alterações seguintes do contato
+type ContactTransferred struct {
+	Contact      *ContactResponse `json:"contact"`
+	FromClientID string           `json:"fromClientId"`
+	ToClientID   string           `json:"toClientId"`
+}
+
+func (e ClientCreated) EventType() string        { return EventClientCreated }
+func (e ClientCreated) AggregateID() string      { return e.Client.ID }
+func (e ClientUpdated) EventType() string        { return EventClientUpdated }
+func (e ClientUpdated) AggregateID() string      { return e.Client.ID }
+func (e ClientDeleted) EventType() string        { return EventClientDeleted }
+func (e ClientDeleted) AggregateID() string      { return e.ClientID }
+func (e ClientRestored) EventType() string       { return EventClientRestored }
+func (e ClientRestored) AggregateID() string     { return e.Client.ID }
+func (e ContactAdded) EventType() string         { return EventContactAdded }
+func (e ContactAdded) AggregateID() string       { return e.Contact.ClientID }
+func (e ContactUpdated) EventType() string       { return EventContactUpdated }
+func (e ContactUpdated) AggregateID() string     { return e.Contact.ClientID }
+func (e ContactRemoved) EventType() string       { return EventContactRemoved }
+func (e ContactRemoved) AggregateID() string     { return e.ClientID }
+func (e ContactTransferred) EventType() string   { return EventContactTransferred }
+func (e ContactTransferred) AggregateID() string { return e.ToClientID }
+
+// OutboxEvent é um evento gravado na mesma transação da alteração que o originou e ainda não
+// necessariamente publicado. Sequence define a ordem de publicação. Depois de OUTBOX_MAX_ATTEMPTS
+// falhas o evento recebe DeadAt e deixa de ser publicado, liberando os eventos seguintes do cliente.
+type OutboxEvent struct {
+	ID          string `gorm:"type:uuid;primaryKey"`
+	Sequence    int64  `gorm:"autoIncrement;not null;uniqueIndex"`
+	Type        string `gorm:"not null"`
+	AggregateID string `gorm:"type:uuid;not null;index"`
+	TenantID    string `gorm:"type:varchar(64);not null;default:'default';index"`
+	Payload     []byte `gorm:"type:jsonb;not null"`
+
+	Attempts  int    `gorm:"not null;default:0"`
+	LastError string `gorm:"not null;default:''"`
+
+	CreatedAt   time.Time    `gorm:"not null"`
+	PublishedAt sql.NullTime `gorm:"default:null;index"`
+	DeadAt      sql.NullTime `gorm:"default:null;index"`
+}
+
+// EventMessage é a mensagem entregue aos publishers. ID é estável entre reentregas e pode ser
+// usado pelos consumidores para descartar duplicatas. TenantID é o tenant do cliente do evento.
+type EventMessage struct {
+	ID          string              `json:"id"`
+	Sequence    int64               `json:"sequence"`
+	Type        string              `json:"type"`
+	AggregateID string              `json:"aggregateId"`
+	TenantID    string              `json:"tenantId"`
+	Payload     jsoniter.RawMessage `json:"payload"`
+	OccurredAt  time.Time           `json:"occurredAt"`
+}
+
+func (e *OutboxEvent) ToEventMessage() *EventMessage {
+	return &EventMessage{
+		ID:          e.ID,
+		Sequence:    e.Sequence,
+		Type:        e.Type,
+		AggregateID: e.AggregateID,
+		TenantID:    e.TenantID,
+		Payload:     rawJSON(e.Payload),
+		OccurredAt:  e.CreatedAt,
+	}
+}
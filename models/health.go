@@ -0,0 +1,25 @@
+package models
+
+const (
+	HealthStatusUp       = "up"
+	HealthStatusDown     = "down"
+	HealthStatusDraining = "draining"
+)
+
+// HealthDependencyPostgres identifica a verificação do banco na resposta do /readyz
+const HealthDependencyPostgres = "postgres"
+
+type HealthResponse struct {
+	Status string                 `json:"status" example:"up"`
+	Checks map[string]HealthCheck `json:"checks,omitempty"`
+}
+
+type HealthCheck struct {
+	Status    string  `json:"status" example:"up"`
+	LatencyMs float64 `json:"latencyMs" example:"1.25"`
+}
+
+// IsUp informa se a instância pode receber tráfego
+func (h *HealthResponse) IsUp() bool {
+	return h.Status == HealthStatusUp
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// HeaderIdempotentReplayed indica que a resposta foi reproduzida a partir de uma chave já utilizada
+const HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyKey guarda a impressão digital da requisição feita com uma chave e a resposta
+// devolvida para ela. Enquanto StatusCode for zero a requisição original ainda está em andamento.
+type IdempotencyKey struct {
+	Scope       string `gorm:"primaryKey"`
+	Key         string `gorm:"primaryKey"`
+	Fingerprint string `gorm:"not null"`
+
+	StatusCode   int    `gorm:"not null;default:0"`
+	ContentType  string `gorm:"not null;default:''"`
+	ResponseBody []byte
+
+	CreatedAt time.Time `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+}
+
+// IdempotentResponse é a resposta devolvida à requisição original, guardada para as repetições
+type IdempotentResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.StatusCode != 0
+}
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+const (
+	ClientSortName      = "name"
+	ClientSortCreatedAt = "created_at"
+)
+
+type ListClientsQuery struct {
+	Cursor      string    `query:"cursor" json:"cursor"`
+	Limit       int       `query:"limit" json:"limit" binding:"omitempty,min=1"`
+	Sort        string    `query:"sort" json:"sort" binding:"omitempty,oneof=name -name created_at -created_at"`
+	Name        string    `query:"name" json:"name"`
+	CreatedFrom time.Time `query:"createdFrom" json:"createdFrom"`
+	CreatedTo   time.Time `query:"createdTo" json:"createdTo" binding:"omitempty,gtfield=CreatedFrom"`
+
+	ContactEmail string `query:"contactEmail" json:"contactEmail"`
+	ContactPhone string `query:"contactPhone" json:"contactPhone"`
+}
+
+// ClientListOptions descreve uma página a ser buscada por keyset a partir de um cursor já decodificado
+type ClientListOptions struct {
+	Limit       int
+	Sort        string
+	Cursor      *Cursor
+	NamePrefix  string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+
+	// ClientIDs restringe a busca a esses clientes quando não for nil
+	ClientIDs []string
+}
+
+type Cursor struct {
+	Sort     string `json:"s"`
+	Value    string `json:"v"`
+	ID       string `json:"id"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+type PageMeta struct {
+	Limit      int    `json:"limit" example:"20"`
+	HasNext    bool   `json:"hasNext" example:"true"`
+	HasPrev    bool   `json:"hasPrev" example:"false"`
+	NextCursor string `json:"nextCursor,omitempty" example:"eyJzIjoibmFtZSIsInYiOiJHYWJyaWVsIiwiaWQiOiI3YTM5NTgzNCJ9"`
+	PrevCursor string `json:"prevCursor,omitempty"`
+}
+
+type ClientPageResponse struct {
+	Data []ClientResponse `json:"data"`
+	Meta PageMeta         `json:"meta"`
+}
+
+// SortOrDefault retorna a ordenação pedida ou a ordenação padrão por data de criação
+func (q *ListClientsQuery) SortOrDefault() string {
+	if q.Sort == "" {
+		return ClientSortCreatedAt
+	}
+
+	return q.Sort
+}
+
+func (q *ListClientsQuery) PageLimit() int {
+	if q.Limit <= 0 {
+		return DefaultPageLimit
+	}
+
+	return min(q.Limit, MaxPageLimit)
+}
+
+func (c *Cursor) Encode() string {
+	data, _ := jsoniter.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(value string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, NewValidationError("cursor", "is invalid")
+	}
+
+	var cursor Cursor
+	if err := jsoniter.Unmarshal(data, &cursor); err != nil {
+		return nil, NewValidationError("cursor", "is invalid")
+	}
+
+	// O ID vai para a comparação com a coluna uuid; um valor qualquer faria o Postgres falhar com 500
+	if err := uuid.Validate(cursor.ID); err != nil {
+		return nil, NewValidationError("cursor", "is invalid")
+	}
+
+	return &cursor, nil
+}
+
+// ToCursor gera o cursor que aponta para o cliente na ordenação informada
+func (c *Client) ToCursor(sort string, backward bool) *Cursor {
+	cursor := &Cursor{
+		Sort:     sort,
+		ID:       c.ID,
+		Backward: backward,
+	}
+
+	switch strings.TrimPrefix(sort, "-") {
+	case ClientSortName:
+		cursor.Value = c.Name
+	default:
+		cursor.Value = c.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	return cursor
+}
@@ -0,0 +1,29 @@
+package models
+
+import "slices"
+
+const (
+	AuthMethodAPIKey = "api-key"
+	AuthMethodJWT    = "jwt"
+)
+
+// Principal é a credencial autenticada na requisição e os escopos concedidos a ela. TenantID
+// vazio só é aceito em credenciais com ScopePlatform, que escolhem o tenant pelo header X-Tenant-ID.
+type Principal struct {
+	Method string
+
+	// ID identifica a credencial de forma única: o ID da chave de API ou o sub do JWT
+	ID       string
+	Subject  string
+	Scopes   []string
+	TenantID string
+}
+
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+// Actor identifica a credencial na auditoria, por exemplo "api-key:backoffice" ou "jwt:billing-service"
+func (p *Principal) Actor() string {
+	return p.Method + ":" + p.Subject
+}
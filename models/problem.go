@@ -0,0 +1,14 @@
+package models
+
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// ProblemDetails segue o formato definido na RFC 7807
+type ProblemDetails struct {
+	Type          string       `json:"type" example:"/problems/client-not-found"`
+	Title         string       `json:"title" example:"Client not found"`
+	Status        int          `json:"status" example:"404"`
+	Detail        string       `json:"detail,omitempty" example:"client not found"`
+	Instance      string       `json:"instance,omitempty" example:"/clients/7a395834-0ed5-4954-8e1d-b63cd2fdb97a/contacts"`
+	Errors        []FieldError `json:"errors,omitempty"`
+	CorrelationID string       `json:"correlationId,omitempty" example:"Xv1b6Jm0gkTQnKXz6HcRzv4u1v8JmN6p"`
+}
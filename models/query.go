@@ -0,0 +1,44 @@
+package models
+
+import (
+	"slices"
+	"strings"
+)
+
+const IncludeContacts = "contacts"
+
+// ClientFields lista os campos de ClientResponse que podem ser pedidos em ?fields=
+var ClientFields = []string{"id", "name", "version", "created_at", "updated_at", "deleted_at"}
+
+type GetClientQuery struct {
+	Include string `query:"include" json:"include" binding:"omitempty,oneof=contacts"`
+	Fields  string `query:"fields" json:"fields"`
+}
+
+func (q *GetClientQuery) IncludeContacts() bool {
+	return q.Include == IncludeContacts
+}
+
+// SelectedFields retorna os campos pedidos em ?fields=, ou todos os campos do cliente quando
+// o parâmetro não é informado. Os contatos só entram quando pedidos em ?include=contacts.
+func (q *GetClientQuery) SelectedFields() ([]string, error) {
+	fields := slices.Clone(ClientFields)
+
+	if strings.TrimSpace(q.Fields) != "" {
+		fields = nil
+		for _, field := range strings.Split(q.Fields, ",") {
+			field = strings.TrimSpace(field)
+			if !slices.Contains(ClientFields, field) {
+				return nil, NewValidationError("fields", "must be a list of: "+strings.Join(ClientFields, ", "))
+			}
+
+			fields = append(fields, field)
+		}
+	}
+
+	if q.IncludeContacts() {
+		fields = append(fields, IncludeContacts)
+	}
+
+	return fields, nil
+}
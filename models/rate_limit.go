@@ -0,0 +1,91 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+)
+
+// Grupos de rotas com limites próprios
+const (
+	RateLimitGroupRead  = "read"
+	RateLimitGroupWrite = "write"
+	RateLimitGroupAdmin = "admin"
+
+	// RateLimitGroupAnon limita por IP as requisições sem credencial ou com credenciais inválidas.
+	// É verificado antes da autenticação, e as requisições autenticadas não são cobradas.
+	RateLimitGroupAnon = "anon"
+)
+
+// RateLimitPolicy é um balde de tokens que guarda até Burst tokens e recebe PerMinute tokens
+// por minuto. Cada requisição consome um token.
+type RateLimitPolicy struct {
+	Burst     int
+	PerMinute int
+}
+
+// RateLimitBucket guarda o balde de uma credencial ou IP no Postgres, compartilhado entre as
+// instâncias da API. Allowed registra se a última requisição consumiu um token.
+type RateLimitBucket struct {
+	Key       string    `gorm:"primaryKey"`
+	Tokens    float64   `gorm:"not null"`
+	Allowed   bool      `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null;index"`
+}
+
+// RateLimitResult é o estado do balde depois de uma requisição, usado nos headers RateLimit-*
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+
+	// Reset é o tempo até o balde voltar a ficar cheio
+	Reset time.Duration
+
+	// RetryAfter é o tempo até o próximo token, quando a requisição foi negada
+	RetryAfter time.Duration
+}
+
+// Rate retorna quantos tokens o balde recebe por segundo
+func (p RateLimitPolicy) Rate() float64 {
+	return float64(p.PerMinute) / 60
+}
+
+// Refill retorna os tokens do balde depois de elapsed sem requisições, limitados a Burst
+func (p RateLimitPolicy) Refill(tokens float64, elapsed time.Duration) float64 {
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	return math.Min(float64(p.Burst), tokens+elapsed.Seconds()*p.Rate())
+}
+
+// Result descreve o balde com tokens restantes depois da requisição
+func (p RateLimitPolicy) Result(tokens float64, allowed bool) *RateLimitResult {
+	result := &RateLimitResult{
+		Allowed:   allowed,
+		Limit:     p.Burst,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     p.until(float64(p.Burst) - tokens),
+	}
+
+	if !allowed {
+		result.RetryAfter = p.until(1 - tokens)
+	}
+
+	return result
+}
+
+// until retorna o tempo até o balde receber mais tokens
+func (p RateLimitPolicy) until(tokens float64) time.Duration {
+	if tokens <= 0 || p.PerMinute <= 0 {
+		return 0
+	}
+
+	return time.Duration(math.Ceil(tokens / p.Rate() * float64(time.Second)))
+}
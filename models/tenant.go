@@ -0,0 +1,26 @@
+package models
+
+import "regexp"
+
+// HeaderTenant escolhe o tenant da requisição quando a credencial tem o escopo ScopePlatform
+const HeaderTenant = "X-Tenant-ID"
+
+const (
+	// DefaultTenant é o tenant das linhas criadas antes da separação por unidade de negócio
+	DefaultTenant = "default"
+
+	// AllTenants dispensa o filtro por tenant nos repositórios. É usado apenas por rotinas
+	// internas, como o expurgo, o relay do outbox e o dispatcher de webhooks, que conectam com
+	// POSTGRES_JOBS_USER, e nunca aceito no header.
+	AllTenants = "*"
+
+	// TenantSetting é a configuração da transação lida pelas políticas de row-level security
+	TenantSetting = "app.tenant_id"
+)
+
+var tenantPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// IsValidTenant informa se o ID do tenant tem até 64 letras minúsculas, números, "-" ou "_"
+func IsValidTenant(tenant string) bool {
+	return tenantPattern.MatchString(tenant)
+}
@@ -0,0 +1,31 @@
+package models
+
+import "strings"
+
+type FieldError struct {
+	Field   string `json:"field" example:"contacts[0].phone"`
+	Message string `json:"message" example:"must be a valid E.164 phone number"`
+}
+
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func NewValidationError(field string, message string) *ValidationError {
+	return &ValidationError{
+		Fields: []FieldError{{Field: field, Message: message}},
+	}
+}
+
+func (v *ValidationError) Error() string {
+	fields := make([]string, len(v.Fields))
+	for i, f := range v.Fields {
+		fields[i] = f.Field + " " + f.Message
+	}
+
+	return "validation failed: " + strings.Join(fields, ", ")
+}
+
+func (v *ValidationError) Unwrap() error {
+	return ErrValidation
+}
@@ -0,0 +1,224 @@
+package models
+
+import (
+	"database/sql"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderWebhookID        = "Webhook-Id"
+	HeaderWebhookTimestamp = "Webhook-Timestamp"
+	HeaderWebhookSignature = "Webhook-Signature"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryDead      = "dead"
+)
+
+// WebhookEventTypes lista os eventos de domínio que podem ser assinados
+var WebhookEventTypes = []string{
+	EventClientCreated,
+	EventClientUpdated,
+	EventClientDeleted,
+	EventClientRestored,
+	EventContactAdded,
+	EventContactUpdated,
+	EventContactRemoved,
+	EventContactTransferred,
+}
+
+// WebhookSubscription é a inscrição de um parceiro para receber os eventos informados em URL.
+// Secret assina as entregas e nunca é devolvido pela API. A inscrição recebe apenas os eventos
+// do próprio tenant.
+type WebhookSubscription struct {
+	ID         string `gorm:"type:uuid;primaryKey"`
+	TenantID   string `gorm:"type:varchar(64);not null;default:'default';index"`
+	URL        string `gorm:"not null"`
+	EventTypes string `gorm:"not null"`
+	Secret     string `gorm:"not null"`
+	Active     bool   `gorm:"not null;default:true"`
+
+	CreatedAt time.Time    `gorm:"not null"`
+	UpdatedAt sql.NullTime `gorm:"default:null"`
+}
+
+// WebhookDelivery é a entrega de um evento para uma inscrição. Uma entrega pendente é tentada
+// em NextAttemptAt; depois de esgotar as tentativas ela vai para a lista de dead letters.
+// Attempts conta todas as tentativas; um reenvio manual guarda a contagem em
+// AttemptsBeforeRedelivery e abre um novo ciclo de WEBHOOK_MAX_ATTEMPTS tentativas.
+type WebhookDelivery struct {
+	ID             string              `gorm:"type:uuid;primaryKey"`
+	SubscriptionID string              `gorm:"type:uuid;not null;uniqueIndex:idx_webhook_deliveries_subscription_event,priority:1"`
+	Subscription   WebhookSubscription `gorm:"foreignKey:SubscriptionID;constraint:OnDelete:CASCADE"`
+	EventID        string              `gorm:"type:uuid;not null;uniqueIndex:idx_webhook_deliveries_subscription_event,priority:2"`
+	TenantID       string              `gorm:"type:varchar(64);not null;default:'default';index"`
+	EventType      string              `gorm:"not null"`
+	Payload        []byte              `gorm:"type:jsonb;not null"`
+
+	Status                   string    `gorm:"not null;index:idx_webhook_deliveries_status_next_attempt,priority:1"`
+	Attempts                 int       `gorm:"not null;default:0"`
+	AttemptsBeforeRedelivery int       `gorm:"not null;default:0"`
+	NextAttemptAt            time.Time `gorm:"not null;index:idx_webhook_deliveries_status_next_attempt,priority:2"`
+	LastStatusCode           int       `gorm:"not null;default:0"`
+	LastError                string    `gorm:"not null;default:''"`
+
+	AttemptLogs []WebhookAttempt `gorm:"foreignKey:DeliveryID;constraint:OnDelete:CASCADE"`
+
+	CreatedAt   time.Time    `gorm:"not null;index"`
+	DeliveredAt sql.NullTime `gorm:"default:null"`
+}
+
+// WebhookAttempt registra cada tentativa de entrega, com a resposta do parceiro
+type WebhookAttempt struct {
+	ID         string `gorm:"type:uuid;primaryKey"`
+	DeliveryID string `gorm:"type:uuid;not null;index"`
+	TenantID   string `gorm:"type:varchar(64);not null;default:'default';index"`
+	Attempt    int    `gorm:"not null"`
+	StatusCode int    `gorm:"not null;default:0"`
+	Error      string `gorm:"not null;default:''"`
+	DurationMs int64  `gorm:"not null"`
+
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+type CreateWebhookPayload struct {
+	URL        string   `json:"url" binding:"required,https_url" example:"https://partner.example.com/webhooks"`
+	EventTypes []string `json:"eventTypes" binding:"required,min=1,dive,webhook_event" example:"ClientCreated,ContactAdded"`
+	Secret     string   `json:"secret" binding:"required,min=16" example:"whsec_5f2b7c9d1e3a4b6c"`
+	Active     *bool    `json:"active" example:"true"`
+}
+
+// UpdateWebhookPayload substitui a inscrição. Quando Secret é omitido o segredo atual é mantido.
+type UpdateWebhookPayload struct {
+	URL        string   `json:"url" binding:"required,https_url" example:"https://partner.example.com/webhooks"`
+	EventTypes []string `json:"eventTypes" binding:"required,min=1,dive,webhook_event" example:"ClientCreated,ContactAdded"`
+	Secret     string   `json:"secret" binding:"omitempty,min=16" example:"whsec_5f2b7c9d1e3a4b6c"`
+	Active     *bool    `json:"active" binding:"required" example:"true"`
+}
+
+type WebhookDeliveryQuery struct {
+	Status string `query:"status" json:"status" binding:"omitempty,oneof=pending delivered dead"`
+	Limit  int    `query:"limit" json:"limit" binding:"omitempty,min=1"`
+}
+
+// WebhookDeliveryListOptions filtra as entregas, das mais recentes para as mais antigas
+type WebhookDeliveryListOptions struct {
+	SubscriptionID string
+	Status         string
+	Limit          int
+}
+
+type WebhookResponse struct {
+	ID         string     `json:"id"`
+	URL        string     `json:"url"`
+	EventTypes []string   `json:"eventTypes"`
+	Active     bool       `json:"active"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  *time.Time `json:"updatedAt,omitempty"`
+}
+
+type WebhookAttemptResponse struct {
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"statusCode,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"durationMs"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             string                   `json:"id"`
+	SubscriptionID string                   `json:"subscriptionId"`
+	EventID        string                   `json:"eventId"`
+	EventType      string                   `json:"eventType"`
+	Status         string                   `json:"status" example:"dead"`
+	Attempts       int                      `json:"attempts"`
+	NextAttemptAt  *time.Time               `json:"nextAttemptAt,omitempty"`
+	LastStatusCode int                      `json:"lastStatusCode,omitempty"`
+	LastError      string                   `json:"lastError,omitempty"`
+	CreatedAt      time.Time                `json:"createdAt"`
+	DeliveredAt    *time.Time               `json:"deliveredAt,omitempty"`
+	AttemptLogs    []WebhookAttemptResponse `json:"attemptLogs"`
+}
+
+func (q *WebhookDeliveryQuery) PageLimit() int {
+	if q.Limit <= 0 {
+		return DefaultPageLimit
+	}
+
+	return min(q.Limit, MaxPageLimit)
+}
+
+func (w *WebhookSubscription) Events() []string {
+	if w.EventTypes == "" {
+		return []string{}
+	}
+
+	return strings.Split(w.EventTypes, ",")
+}
+
+func (w *WebhookSubscription) SetEvents(eventTypes []string) {
+	events := slices.Clone(eventTypes)
+	slices.Sort(events)
+
+	w.EventTypes = strings.Join(slices.Compact(events), ",")
+}
+
+// Subscribes informa se a inscrição está ativa e assinou o tipo de evento
+func (w *WebhookSubscription) Subscribes(eventType string) bool {
+	return w.Active && slices.Contains(w.Events(), eventType)
+}
+
+func (w *WebhookSubscription) ToWebhookResponse() *WebhookResponse {
+	response := &WebhookResponse{
+		ID:         w.ID,
+		URL:        w.URL,
+		EventTypes: w.Events(),
+		Active:     w.Active,
+		CreatedAt:  w.CreatedAt,
+	}
+
+	if w.UpdatedAt.Valid {
+		response.UpdatedAt = &w.UpdatedAt.Time
+	}
+
+	return response
+}
+
+func (d *WebhookDelivery) ToWebhookDeliveryResponse() *WebhookDeliveryResponse {
+	response := &WebhookDeliveryResponse{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+		AttemptLogs:    make([]WebhookAttemptResponse, 0, len(d.AttemptLogs)),
+	}
+
+	if d.Status == WebhookDeliveryPending {
+		response.NextAttemptAt = &d.NextAttemptAt
+	}
+
+	if d.DeliveredAt.Valid {
+		response.DeliveredAt = &d.DeliveredAt.Time
+	}
+
+	for _, attempt := range d.AttemptLogs {
+		response.AttemptLogs = append(response.AttemptLogs, WebhookAttemptResponse{
+			Attempt:    attempt.Attempt,
+			StatusCode: attempt.StatusCode,
+			Error:      attempt.Error,
+			DurationMs: attempt.DurationMs,
+			CreatedAt:  attempt.CreatedAt,
+		})
+	}
+
+	return response
+}
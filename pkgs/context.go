@@ -0,0 +1,76 @@
+package pkgs
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+)
+
+type actorKey struct{}
+
+type requestIDKey struct{}
+
+type principalKey struct{}
+
+type tenantKey struct{}
+
+type loggerKey struct{}
+
+// WithActor guarda no contexto quem está realizando a operação
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext retorna quem está realizando a operação, ou "" se não houver
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// WithRequestID guarda no contexto o ID da requisição que originou a operação
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext retorna o ID da requisição que originou a operação, ou "" se não houver
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// WithPrincipal guarda no contexto a credencial autenticada na requisição
+func WithPrincipal(ctx context.Context, principal *models.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext retorna a credencial autenticada na requisição, ou nil se não houver
+func PrincipalFromContext(ctx context.Context) *models.Principal {
+	principal, _ := ctx.Value(principalKey{}).(*models.Principal)
+	return principal
+}
+
+// WithTenant guarda no contexto o tenant cujos dados a operação pode acessar
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext retorna o tenant cujos dados a operação pode acessar, ou "" se não houver
+func TenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
+
+// WithLogger guarda no contexto o logger da requisição, já com o ID da requisição
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFromContext retorna o logger da requisição, ou o logger padrão fora de uma requisição
+func LoggerFromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+
+	return slog.Default()
+}
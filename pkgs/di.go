@@ -1,14 +1,81 @@
 package pkgs
 
-import "github.com/samber/do"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/samber/do"
+)
+
+var (
+	ErrMissingDependency = errors.New("missing dependency")
+	ErrDependencyCycle   = errors.New("dependency cycle")
+)
+
+// Hook é executado no Start ou no Shutdown do container
+type Hook func(ctx context.Context) error
+
+// Healthchecker é implementado pelos serviços que verificam um recurso externo. Os serviços
+// registrados com um tipo que o implementa são devolvidos pelo Healthchecks do container.
+type Healthchecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+var healthcheckerType = reflect.TypeFor[Healthchecker]()
 
 type Di struct {
 	injector *do.Injector
+	registry *registry
+
+	// parent é o container de origem de um Scope, consultado quando o serviço não foi
+	// registrado no próprio container
+	parent *Di
+
+	// path guarda os serviços em construção até este ponto, para detectar ciclos. Só é
+	// preenchido no Di recebido pela função do Provide enquanto ela executa.
+	path []string
+}
+
+type registry struct {
+	mu        sync.Mutex
+	names     []string
+	providers map[string]*provider
+	starts    []namedHook
+	stops     []namedHook
+}
+
+// provider guarda como registrar o serviço num injector, para que Clone possa registrá-lo de
+// novo num container sem instâncias. Também repassa à função do Provide a cadeia de quem
+// invocou o serviço: o samber/do não repassa o chamador, então a cadeia é guardada aqui,
+// protegida por um lock por serviço.
+type provider struct {
+	register func(d *Di, p *provider)
+	invoke   func(d *Di) error
+
+	// check é nil quando o tipo registrado não implementa Healthchecker
+	check func(d *Di, ctx context.Context) error
+
+	mu   sync.Mutex
+	path []string
+}
+
+type namedHook struct {
+	name string
+	hook Hook
 }
 
 func NewDi() *Di {
 	return &Di{
 		injector: do.New(),
+		registry: &registry{
+			providers: make(map[string]*provider),
+		},
 	}
 }
 
@@ -18,8 +85,47 @@ func NewDi() *Di {
 //
 // pkgs.Provide(di, services.NewHealthcheckService)
 func Provide[T any](d *Di, fn func(d *Di) (T, error)) {
-	do.Provide(d.injector, func(_ *do.Injector) (T, error) {
-		return fn(d)
+	ProvideNamed(d, serviceName[T](), fn)
+}
+
+// ProvideNamed registra a função com um nome, para que várias implementações da mesma
+// interface convivam no container. O valor é obtido com InvokeNamed.
+//
+// Exemplo:
+//
+// pkgs.ProvideNamed(di, models.HealthDependencyPostgres, repositories.NewHealthRepository)
+func ProvideNamed[T any](d *Di, name string, fn func(d *Di) (T, error)) {
+	if d.registry.provider(name) != nil {
+		panic(fmt.Errorf("DI: service `%s` has already been declared", name))
+	}
+
+	d.register(name, newProvider(name, fn))
+}
+
+// Override troca a função registrada para o tipo, por exemplo por um mock num teste. Os
+// serviços já construídos continuam com o valor anterior, então deve ser chamado antes do
+// primeiro Invoke, normalmente num Clone do container da aplicação.
+//
+// Exemplo:
+//
+// pkgs.Override(di, func(di *pkgs.Di) (repositories.ClientRepository, error) { return clientRepo, nil })
+func Override[T any](d *Di, fn func(d *Di) (T, error)) {
+	OverrideNamed(d, serviceName[T](), fn)
+}
+
+// OverrideNamed troca a função registrada com o nome
+func OverrideNamed[T any](d *Di, name string, fn func(d *Di) (T, error)) {
+	d.register(name, newProvider(name, fn))
+}
+
+// OverrideValue troca o serviço do tipo por um valor pronto
+//
+// Exemplo:
+//
+// pkgs.OverrideValue[repositories.ClientRepository](di, clientRepo)
+func OverrideValue[T any](d *Di, value T) {
+	Override(d, func(*Di) (T, error) {
+		return value, nil
 	})
 }
 
@@ -29,5 +135,286 @@ func Provide[T any](d *Di, fn func(d *Di) (T, error)) {
 //
 // hc, err := pkgs.Invoke[handlers.HealthCheckHandler](di)
 func Invoke[T any](d *Di) (T, error) {
-	return do.Invoke[T](d.injector)
+	return InvokeNamed[T](d, serviceName[T]())
+}
+
+// InvokeNamed retorna o valor registrado com ProvideNamed. Num Scope, os serviços que não foram
+// registrados no próprio container vêm do container de origem.
+//
+// Exemplo:
+//
+// hr, err := pkgs.InvokeNamed[repositories.HealthRepository](di, models.HealthDependencyPostgres)
+func InvokeNamed[T any](d *Di, name string) (T, error) {
+	var empty T
+
+	if i := slices.Index(d.path, name); i >= 0 {
+		cycle := append(slices.Clone(d.path[i:]), name)
+		return empty, fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, " -> "))
+	}
+
+	p := d.registry.provider(name)
+	if p == nil {
+		if d.parent != nil {
+			parent := *d.parent
+			parent.path = d.path
+			return InvokeNamed[T](&parent, name)
+		}
+
+		if len(d.path) > 0 {
+			return empty, fmt.Errorf("%w: %s required by %s", ErrMissingDependency, name, d.path[len(d.path)-1])
+		}
+
+		return empty, fmt.Errorf("%w: %s", ErrMissingDependency, name)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.path = d.path
+	return do.InvokeNamed[T](d.injector, name)
+}
+
+// Clone cria um container com os mesmos serviços registrados, mas sem as instâncias já
+// construídas e sem os hooks, que pertencem aos recursos do container original. Com Override,
+// permite montar o grafo real da aplicação trocando apenas algumas dependências.
+//
+// Exemplo:
+//
+// di := base.Clone()
+// pkgs.OverrideValue[repositories.ClientRepository](di, clientRepo)
+func (d *Di) Clone() *Di {
+	clone := &Di{
+		injector: do.New(),
+		registry: &registry{
+			providers: make(map[string]*provider),
+		},
+		parent: d.parent,
+	}
+
+	d.registry.mu.Lock()
+	names := slices.Clone(d.registry.names)
+	providers := make([]*provider, len(names))
+	for i, name := range names {
+		providers[i] = d.registry.providers[name]
+	}
+	d.registry.mu.Unlock()
+
+	for i, name := range names {
+		clone.register(name, &provider{
+			register: providers[i].register,
+			invoke:   providers[i].invoke,
+			check:    providers[i].check,
+		})
+	}
+
+	return clone
+}
+
+// Scope cria um container filho, por exemplo para uma requisição. Os serviços registrados no
+// filho são construídos nele e podem depender dos serviços do container de origem, que
+// continuam compartilhados. Os serviços já construídos no container de origem não enxergam os
+// registros do filho.
+//
+// Exemplo:
+//
+// scope := di.Scope()
+// pkgs.OverrideValue[*models.Principal](scope, principal)
+func (d *Di) Scope() *Di {
+	return &Di{
+		injector: do.New(),
+		registry: &registry{
+			providers: make(map[string]*provider),
+		},
+		parent: d,
+	}
+}
+
+// Validate instancia todos os serviços registrados, na ordem do registro, para que dependências
+// ausentes ou circulares sejam encontradas na inicialização e não na primeira requisição.
+// Devolve o erro de cada serviço que não pôde ser construído.
+//
+// Exemplo:
+//
+// if err := di.Validate(); err != nil { ... }
+func (d *Di) Validate() error {
+	d.registry.mu.Lock()
+	names := slices.Clone(d.registry.names)
+	invokers := make([]func(d *Di) error, len(names))
+	for i, name := range names {
+		invokers[i] = d.registry.providers[name].invoke
+	}
+	d.registry.mu.Unlock()
+
+	var errs []error
+	for i, name := range names {
+		if err := invokers[i](d); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Healthchecks retorna a verificação de cada serviço registrado com um tipo que implementa
+// Healthchecker, pelo nome do registro. O serviço é construído na primeira verificação. Num
+// Scope, inclui as verificações do container de origem.
+func (d *Di) Healthchecks() map[string]func(ctx context.Context) error {
+	checks := make(map[string]func(ctx context.Context) error)
+	if d.parent != nil {
+		checks = d.parent.Healthchecks()
+	}
+
+	d.registry.mu.Lock()
+	defer d.registry.mu.Unlock()
+
+	for name, p := range d.registry.providers {
+		if p.check == nil {
+			continue
+		}
+
+		checks[name] = func(ctx context.Context) error {
+			return p.check(d, ctx)
+		}
+	}
+
+	return checks
+}
+
+// OnStart registra um hook executado pelo Start, na ordem do registro
+//
+// Exemplo:
+//
+// di.OnStart("workers", func(ctx context.Context) error { ... })
+func (d *Di) OnStart(name string, hook Hook) {
+	d.registry.mu.Lock()
+	defer d.registry.mu.Unlock()
+
+	d.registry.starts = append(d.registry.starts, namedHook{name: name, hook: hook})
+}
+
+// OnShutdown registra um hook executado pelo Shutdown. Registrado dentro da função passada ao
+// Provide, depois de invocar as dependências, o hook é executado antes dos hooks delas.
+//
+// Exemplo:
+//
+// di.OnShutdown("tracing", shutdownTracing)
+func (d *Di) OnShutdown(name string, hook Hook) {
+	d.registry.mu.Lock()
+	defer d.registry.mu.Unlock()
+
+	d.registry.stops = append(d.registry.stops, namedHook{name: name, hook: hook})
+}
+
+// RegisterCloser registra um recurso para ser fechado no Shutdown
+//
+// Exemplo:
+//
+// di.RegisterCloser("postgres", sqlDB)
+func (d *Di) RegisterCloser(name string, closer io.Closer) {
+	d.OnShutdown(name, func(context.Context) error {
+		return closer.Close()
+	})
+}
+
+// Start executa os hooks do OnStart na ordem do registro e para no primeiro erro. Os hooks
+// registrados durante a construção dos serviços só existem depois do Validate ou do Invoke.
+func (d *Di) Start(ctx context.Context) error {
+	d.registry.mu.Lock()
+	starts := slices.Clone(d.registry.starts)
+	d.registry.mu.Unlock()
+
+	for _, start := range starts {
+		if err := start.hook(ctx); err != nil {
+			return fmt.Errorf("start %s: %w", start.name, err)
+		}
+	}
+
+	return nil
+}
+
+// Shutdown executa os hooks do OnShutdown na ordem inversa do registro, de modo que quem
+// depende de um recurso é encerrado antes dele. Um erro não interrompe os demais hooks; todos
+// os erros são devolvidos juntos. Chamar Shutdown de novo não executa os hooks outra vez.
+func (d *Di) Shutdown(ctx context.Context) error {
+	d.registry.mu.Lock()
+	stops := d.registry.stops
+	d.registry.stops = nil
+	d.registry.mu.Unlock()
+
+	var errs []error
+	for i := len(stops) - 1; i >= 0; i-- {
+		if err := stops[i].hook(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("shutdown %s: %w", stops[i].name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// register guarda o provider, mantendo a posição do nome quando ele já existia, e o registra
+// no injector do container
+func (d *Di) register(name string, p *provider) {
+	d.registry.mu.Lock()
+	if _, ok := d.registry.providers[name]; !ok {
+		d.registry.names = append(d.registry.names, name)
+	}
+	d.registry.providers[name] = p
+	d.registry.mu.Unlock()
+
+	p.register(d, p)
+}
+
+func (r *registry) provider(name string) *provider {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providers[name]
+}
+
+func newProvider[T any](name string, fn func(d *Di) (T, error)) *provider {
+	p := &provider{
+		register: func(d *Di, p *provider) {
+			do.OverrideNamed(d.injector, name, func(_ *do.Injector) (T, error) {
+				scope := &Di{
+					injector: d.injector,
+					registry: d.registry,
+					parent:   d.parent,
+					path:     append(slices.Clone(p.path), name),
+				}
+
+				// O Di guardado pelo serviço não deve carregar a cadeia da construção, para
+				// que as invocações feitas depois não sejam confundidas com um ciclo
+				defer func() { scope.path = nil }()
+
+				return fn(scope)
+			})
+		},
+		invoke: func(d *Di) error {
+			_, err := InvokeNamed[T](d, name)
+			return err
+		},
+	}
+
+	if reflect.TypeFor[T]().Implements(healthcheckerType) {
+		p.check = func(d *Di, ctx context.Context) error {
+			service, err := InvokeNamed[T](d, name)
+			if err != nil {
+				return err
+			}
+
+			return any(service).(Healthchecker).HealthCheck(ctx)
+		}
+	}
+
+	return p
+}
+
+// serviceName segue a convenção do samber/do: o tipo do valor, ou o ponteiro para a interface
+func serviceName[T any]() string {
+	var t T
+	if name := fmt.Sprintf("%T", t); name != "<nil>" {
+		return name
+	}
+
+	return fmt.Sprintf("%T", new(T))
 }
@@ -0,0 +1,348 @@
+package pkgs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type closerFunc func() error
+
+func (f closerFunc) Close() error {
+	return f()
+}
+
+type storage struct {
+	err error
+}
+
+func (s *storage) HealthCheck(ctx context.Context) error {
+	return s.err
+}
+
+type repository struct {
+	di      *Di
+	storage *storage
+}
+
+type service struct {
+	repository *repository
+}
+
+func newRepository(di *Di) (*repository, error) {
+	s, err := Invoke[*storage](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository{di: di, storage: s}, nil
+}
+
+func newService(di *Di) (*service, error) {
+	r, err := Invoke[*repository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service{repository: r}, nil
+}
+
+func TestDi_ProvideNamed(t *testing.T) {
+	t.Run("should keep one instance per name", func(t *testing.T) {
+		di := NewDi()
+
+		ProvideNamed(di, "primary", func(di *Di) (*storage, error) {
+			return &storage{}, nil
+		})
+		ProvideNamed(di, "replica", func(di *Di) (*storage, error) {
+			return &storage{err: errors.New("replica lag")}, nil
+		})
+
+		primary, err := InvokeNamed[*storage](di, "primary")
+		require.NoError(t, err)
+		replica, err := InvokeNamed[*storage](di, "replica")
+		require.NoError(t, err)
+
+		assert.NoError(t, primary.err)
+		assert.EqualError(t, replica.err, "replica lag")
+	})
+}
+
+func TestDi_Validate(t *testing.T) {
+	t.Run("should build every provided service", func(t *testing.T) {
+		di := NewDi()
+		built := 0
+
+		Provide(di, func(di *Di) (*storage, error) {
+			built++
+			return &storage{}, nil
+		})
+		Provide(di, newRepository)
+
+		err := di.Validate()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, built)
+	})
+
+	t.Run("should report a missing dependency and who requires it", func(t *testing.T) {
+		di := NewDi()
+
+		Provide(di, newService)
+
+		err := di.Validate()
+
+		assert.ErrorIs(t, err, ErrMissingDependency)
+		assert.EqualError(t, err, "*pkgs.service: missing dependency: *pkgs.repository required by *pkgs.service")
+	})
+
+	t.Run("should report a dependency cycle", func(t *testing.T) {
+		di := NewDi()
+
+		Provide(di, newService)
+		Provide(di, newRepository)
+		Provide(di, func(di *Di) (*storage, error) {
+			_, err := Invoke[*service](di)
+			return &storage{}, err
+		})
+
+		err := di.Validate()
+
+		assert.ErrorIs(t, err, ErrDependencyCycle)
+		assert.Contains(t, err.Error(), "*pkgs.service: dependency cycle: *pkgs.service -> *pkgs.repository -> *pkgs.storage -> *pkgs.service")
+	})
+
+	t.Run("should not report a cycle for invocations after the construction", func(t *testing.T) {
+		di := NewDi()
+
+		Provide(di, func(di *Di) (*storage, error) {
+			return &storage{}, nil
+		})
+		Provide(di, newRepository)
+
+		r, err := Invoke[*repository](di)
+		require.NoError(t, err)
+
+		_, err = Invoke[*repository](r.di)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestDi_Healthchecks(t *testing.T) {
+	t.Run("should discover the services that implement Healthchecker", func(t *testing.T) {
+		di := NewDi()
+
+		ProvideNamed(di, "postgres", func(di *Di) (*storage, error) {
+			return &storage{err: errors.New("connection refused")}, nil
+		})
+		Provide(di, newRepository)
+
+		checks := di.Healthchecks()
+
+		require.Len(t, checks, 1)
+		assert.EqualError(t, checks["postgres"](context.Background()), "connection refused")
+	})
+}
+
+func TestDi_Start(t *testing.T) {
+	t.Run("should run the hooks in order and stop at the first error", func(t *testing.T) {
+		di := NewDi()
+		var started []string
+
+		for _, name := range []string{"tracing", "workers", "scheduler"} {
+			di.OnStart(name, func(context.Context) error {
+				started = append(started, name)
+				if name == "workers" {
+					return errors.New("publisher unavailable")
+				}
+				return nil
+			})
+		}
+
+		err := di.Start(context.Background())
+
+		assert.EqualError(t, err, "start workers: publisher unavailable")
+		assert.Equal(t, []string{"tracing", "workers"}, started)
+	})
+}
+
+func TestDi_Shutdown(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should close dependents before their dependencies", func(t *testing.T) {
+		di := NewDi()
+		var closed []string
+
+		Provide(di, func(di *Di) (*storage, error) {
+			di.RegisterCloser("storage", closerFunc(func() error {
+				closed = append(closed, "storage")
+				return nil
+			}))
+			return &storage{}, nil
+		})
+		Provide(di, func(di *Di) (*repository, error) {
+			r, err := newRepository(di)
+			if err != nil {
+				return nil, err
+			}
+
+			di.OnShutdown("repository", func(context.Context) error {
+				closed = append(closed, "repository")
+				return nil
+			})
+			return r, nil
+		})
+
+		_, err := Invoke[*repository](di)
+		require.NoError(t, err)
+
+		err = di.Shutdown(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"repository", "storage"}, closed)
+	})
+
+	t.Run("should run every hook and join the errors", func(t *testing.T) {
+		di := NewDi()
+		var closed []string
+
+		di.RegisterCloser("postgres", closerFunc(func() error {
+			closed = append(closed, "postgres")
+			return errors.New("connection busy")
+		}))
+		di.OnShutdown("workers", func(context.Context) error {
+			closed = append(closed, "workers")
+			return context.DeadlineExceeded
+		})
+
+		err := di.Shutdown(ctx)
+
+		assert.EqualError(t, err, "shutdown workers: context deadline exceeded\nshutdown postgres: connection busy")
+		assert.Equal(t, []string{"workers", "postgres"}, closed)
+	})
+
+	t.Run("should not run the hooks twice", func(t *testing.T) {
+		di := NewDi()
+		calls := 0
+
+		di.RegisterCloser("postgres", closerFunc(func() error {
+			calls++
+			return nil
+		}))
+
+		assert.NoError(t, di.Shutdown(ctx))
+		assert.NoError(t, di.Shutdown(ctx))
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestDi_Override(t *testing.T) {
+	t.Run("should build the dependents with the overridden value", func(t *testing.T) {
+		di := NewDi()
+		fake := &storage{err: errors.New("fake")}
+
+		Provide(di, func(di *Di) (*storage, error) {
+			return nil, errors.New("no database in tests")
+		})
+		Provide(di, newRepository)
+
+		OverrideValue(di, fake)
+		r, err := Invoke[*repository](di)
+
+		require.NoError(t, err)
+		assert.Same(t, fake, r.storage)
+	})
+}
+
+func TestDi_Clone(t *testing.T) {
+	t.Run("should build new instances without changing the original container", func(t *testing.T) {
+		base := NewDi()
+		original := &storage{}
+		fake := &storage{}
+
+		Provide(base, func(di *Di) (*storage, error) {
+			return original, nil
+		})
+		Provide(base, newRepository)
+
+		_, err := Invoke[*repository](base)
+		require.NoError(t, err)
+
+		di := base.Clone()
+		OverrideValue(di, fake)
+
+		cloned, err := Invoke[*repository](di)
+		require.NoError(t, err)
+		r, err := Invoke[*repository](base)
+		require.NoError(t, err)
+
+		assert.Same(t, fake, cloned.storage)
+		assert.Same(t, original, r.storage)
+		assert.NotSame(t, r, cloned)
+	})
+
+	t.Run("should not copy the hooks of the original container", func(t *testing.T) {
+		base := NewDi()
+		calls := 0
+
+		base.RegisterCloser("postgres", closerFunc(func() error {
+			calls++
+			return nil
+		}))
+
+		assert.NoError(t, base.Clone().Shutdown(context.Background()))
+		assert.Equal(t, 0, calls)
+	})
+}
+
+func TestDi_Scope(t *testing.T) {
+	t.Run("should build the scoped services over the shared ones", func(t *testing.T) {
+		di := NewDi()
+		built := 0
+
+		Provide(di, func(di *Di) (*storage, error) {
+			built++
+			return &storage{}, nil
+		})
+
+		first := di.Scope()
+		Provide(first, newRepository)
+		second := di.Scope()
+		Provide(second, newRepository)
+
+		r1, err := Invoke[*repository](first)
+		require.NoError(t, err)
+		r2, err := Invoke[*repository](second)
+		require.NoError(t, err)
+
+		assert.NotSame(t, r1, r2)
+		assert.Same(t, r1.storage, r2.storage)
+		assert.Equal(t, 1, built)
+
+		_, err = Invoke[*repository](di)
+		assert.ErrorIs(t, err, ErrMissingDependency)
+	})
+
+	t.Run("should include the health checks of the parent container", func(t *testing.T) {
+		di := NewDi()
+
+		ProvideNamed(di, "postgres", func(di *Di) (*storage, error) {
+			return &storage{}, nil
+		})
+
+		scope := di.Scope()
+		ProvideNamed(scope, "replica", func(di *Di) (*storage, error) {
+			return &storage{err: errors.New("replica lag")}, nil
+		})
+
+		checks := scope.Healthchecks()
+
+		require.Len(t, checks, 2)
+		assert.NoError(t, checks["postgres"](context.Background()))
+		assert.EqualError(t, checks["replica"](context.Background()), "replica lag")
+	})
+}
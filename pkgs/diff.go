@@ -0,0 +1,67 @@
+package pkgs
+
+import (
+	"fmt"
+	"reflect"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// FieldChange descreve o valor de um campo antes e depois de uma alteração
+type FieldChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// Diff compara as representações em JSON de dois valores e retorna os campos de primeiro nível
+// que mudaram. Um valor nil é tratado como objeto vazio, então na criação todos os campos
+// aparecem com From nil e na remoção com To nil.
+//
+// Exemplo:
+//
+// changes, err := pkgs.Diff(before, after) // {"name": {"from": "Gabriel", "to": "Gabriel V."}}
+func Diff(before any, after any) (map[string]FieldChange, error) {
+	from, err := toObject(before)
+	if err != nil {
+		return nil, fmt.Errorf("decode before: %w", err)
+	}
+
+	to, err := toObject(after)
+	if err != nil {
+		return nil, fmt.Errorf("decode after: %w", err)
+	}
+
+	changes := make(map[string]FieldChange)
+
+	for field, value := range from {
+		if next, ok := to[field]; !ok || !reflect.DeepEqual(value, next) {
+			changes[field] = FieldChange{From: value, To: to[field]}
+		}
+	}
+
+	for field, value := range to {
+		if _, ok := from[field]; !ok {
+			changes[field] = FieldChange{To: value}
+		}
+	}
+
+	return changes, nil
+}
+
+func toObject(value any) (map[string]any, error) {
+	object := map[string]any{}
+	if value == nil || reflect.ValueOf(value).Kind() == reflect.Pointer && reflect.ValueOf(value).IsNil() {
+		return object, nil
+	}
+
+	data, err := jsoniter.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := jsoniter.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+
+	return object, nil
+}
@@ -0,0 +1,64 @@
+package pkgs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	type value struct {
+		ID       string  `json:"id"`
+		Name     string  `json:"name"`
+		Optional *string `json:"optional,omitempty"`
+	}
+
+	t.Run("should return only the changed fields", func(t *testing.T) {
+		changes, err := Diff(&value{ID: "1", Name: "Gabriel"}, &value{ID: "1", Name: "Gabriel V."})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]FieldChange{
+			"name": {From: "Gabriel", To: "Gabriel V."},
+		}, changes)
+	})
+
+	t.Run("should report every field as added when before is nil", func(t *testing.T) {
+		var before *value
+
+		changes, err := Diff(before, &value{ID: "1", Name: "Gabriel"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]FieldChange{
+			"id":   {To: "1"},
+			"name": {To: "Gabriel"},
+		}, changes)
+	})
+
+	t.Run("should report every field as removed when after is nil", func(t *testing.T) {
+		changes, err := Diff(&value{ID: "1", Name: "Gabriel"}, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]FieldChange{
+			"id":   {From: "1"},
+			"name": {From: "Gabriel"},
+		}, changes)
+	})
+
+	t.Run("should report fields that appear or disappear", func(t *testing.T) {
+		optional := "x"
+
+		changes, err := Diff(&value{ID: "1", Optional: &optional}, &value{ID: "1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]FieldChange{
+			"optional": {From: "x"},
+		}, changes)
+	})
+
+	t.Run("should return an empty diff for equal values", func(t *testing.T) {
+		changes, err := Diff(&value{ID: "1"}, &value{ID: "1"})
+
+		assert.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+}
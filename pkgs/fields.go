@@ -0,0 +1,34 @@
+package pkgs
+
+import (
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// SelectFields serializa o valor e mantém apenas os campos informados, usando os nomes em JSON.
+// Campos ausentes no valor, como os marcados com omitempty, são ignorados.
+//
+// Exemplo:
+//
+// sparse, err := pkgs.SelectFields(response, []string{"id", "name"})
+func SelectFields(value any, fields []string) (map[string]any, error) {
+	data, err := jsoniter.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("encode value: %w", err)
+	}
+
+	var object map[string]any
+	if err := jsoniter.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("decode object: %w", err)
+	}
+
+	selected := make(map[string]any, len(fields))
+	for _, field := range fields {
+		if v, ok := object[field]; ok {
+			selected[field] = v
+		}
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,44 @@
+package pkgs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectFields(t *testing.T) {
+	type nested struct {
+		ID string `json:"id"`
+	}
+
+	type value struct {
+		ID       string   `json:"id"`
+		Name     string   `json:"name"`
+		Optional *string  `json:"optional,omitempty"`
+		Items    []nested `json:"items"`
+	}
+
+	t.Run("should keep only the requested fields", func(t *testing.T) {
+		selected, err := SelectFields(value{ID: "1", Name: "Gabriel", Items: []nested{{ID: "2"}}}, []string{"id", "items"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"id":    "1",
+			"items": []any{map[string]any{"id": "2"}},
+		}, selected)
+	})
+
+	t.Run("should ignore fields absent from the value", func(t *testing.T) {
+		selected, err := SelectFields(value{ID: "1", Name: "Gabriel"}, []string{"name", "optional"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"name": "Gabriel"}, selected)
+	})
+
+	t.Run("should return error when value is not an object", func(t *testing.T) {
+		selected, err := SelectFields([]string{"a"}, []string{"id"})
+
+		assert.Error(t, err)
+		assert.Nil(t, selected)
+	})
+}
@@ -0,0 +1,238 @@
+package pkgs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	JWTAlgRS256 = "RS256"
+	JWTAlgES256 = "ES256"
+)
+
+var (
+	ErrInvalidToken  = errors.New("invalid token")
+	ErrTokenExpired  = errors.New("token expired")
+	ErrTokenNotYet   = errors.New("token not valid yet")
+	ErrUnknownJWTKey = errors.New("unknown signing key")
+)
+
+// JWKS é um conjunto de chaves públicas (RFC 7517) indexado pelo kid
+type JWKS struct {
+	keys map[string]crypto.PublicKey
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// JWTClaims são as claims registradas (RFC 7519) usadas na autorização. Os escopos podem vir
+// em scope, separados por espaço (RFC 8693), ou em scp, como lista. tenant_id vincula o token
+// a um tenant.
+type JWTClaims struct {
+	jwt.RegisteredClaims
+
+	Scope    string   `json:"scope"`
+	Scp      []string `json:"scp"`
+	TenantID string   `json:"tenant_id"`
+}
+
+// JWTValidation é o que ParseJWT confere nas claims além da assinatura. Skew é a diferença
+// tolerada entre os relógios na expiração e no início da validade.
+type JWTValidation struct {
+	Issuer   string
+	Audience string
+	Skew     time.Duration
+}
+
+// ParseJWKS lê um documento JWKS. Apenas chaves RSA e EC P-256 de assinatura são mantidas.
+//
+// Exemplo:
+//
+// jwks, err := pkgs.ParseJWKS(data)
+func ParseJWKS(data []byte) (*JWKS, error) {
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	if err := jsoniter.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("decode jwks: %w", err)
+	}
+
+	jwks := &JWKS{keys: make(map[string]crypto.PublicKey, len(document.Keys))}
+	for _, jwk := range document.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("decode key %q: %w", jwk.Kid, err)
+		}
+
+		if key != nil {
+			jwks.keys[jwk.Kid] = key
+		}
+	}
+
+	return jwks, nil
+}
+
+// Key retorna a chave pública com o kid informado
+func (j *JWKS) Key(kid string) (crypto.PublicKey, bool) {
+	if j == nil {
+		return nil, false
+	}
+
+	key, ok := j.keys[kid]
+	return key, ok
+}
+
+// Len retorna a quantidade de chaves do conjunto
+func (j *JWKS) Len() int {
+	if j == nil {
+		return 0
+	}
+
+	return len(j.keys)
+}
+
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("decode n: %w", err)
+		}
+
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("decode e: %w", err)
+		}
+
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("exponent too large")
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, nil
+		}
+
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("decode x: %w", err)
+		}
+
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("decode y: %w", err)
+		}
+
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+		if !key.Curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on curve P-256")
+		}
+
+		return key, nil
+	default:
+		return nil, nil
+	}
+}
+
+// ParseJWT confere a assinatura RS256 ou ES256 do token com a chave retornada por keyFunc para
+// o kid do cabeçalho, o emissor, a audiência e a validade, e devolve as claims. Tokens inválidos
+// resultam em ErrInvalidToken; o erro de keyFunc é devolvido como veio.
+//
+// Exemplo:
+//
+// claims, err := pkgs.ParseJWT(token, validation, func(kid string) (crypto.PublicKey, error) { ... })
+func ParseJWT(token string, validation JWTValidation, keyFunc func(kid string) (crypto.PublicKey, error)) (*JWTClaims, error) {
+	// Sem emissor ou audiência a biblioteca deixaria de compará-los
+	if validation.Issuer == "" || validation.Audience == "" {
+		return nil, errors.New("jwt issuer and audience are required")
+	}
+
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{JWTAlgRS256, JWTAlgES256}),
+		jwt.WithIssuer(validation.Issuer),
+		jwt.WithAudience(validation.Audience),
+		jwt.WithLeeway(validation.Skew),
+		jwt.WithExpirationRequired(),
+	)
+
+	var keyErr error
+	var claims JWTClaims
+	_, err := parser.ParseWithClaims(token, &claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+
+		var key crypto.PublicKey
+		key, keyErr = keyFunc(kid)
+		return key, keyErr
+	})
+
+	switch {
+	case keyErr != nil:
+		return nil, keyErr
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, ErrTokenExpired)
+	case errors.Is(err, jwt.ErrTokenNotValidYet):
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, ErrTokenNotYet)
+	case err != nil:
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	return &claims, nil
+}
+
+// Validate é chamado pela biblioteca depois das claims registradas e exige o sub, que identifica
+// a credencial
+func (c *JWTClaims) Validate() error {
+	if c.Subject == "" {
+		return errors.New("missing sub")
+	}
+
+	return nil
+}
+
+// Scopes retorna os escopos concedidos ao token
+func (c *JWTClaims) Scopes() []string {
+	if len(c.Scp) > 0 {
+		return c.Scp
+	}
+
+	return strings.Fields(c.Scope)
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, errors.New("empty value")
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,210 @@
+package pkgs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signTestJWT(t *testing.T, alg string, kid string, key crypto.Signer, claims map[string]any) string {
+	t.Helper()
+
+	header, err := jsoniter.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	require.NoError(t, err)
+
+	payload, err := jsoniter.Marshal(claims)
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		require.NoError(t, err)
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func testJWKS(t *testing.T, rsaKey *rsa.PrivateKey, ecKey *ecdsa.PrivateKey) []byte {
+	t.Helper()
+
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+	data, err := jsoniter.Marshal(map[string]any{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": encode(rsaKey.N.Bytes()), "e": encode(big.NewInt(int64(rsaKey.E)).Bytes())},
+		{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": encode(ecKey.X.FillBytes(make([]byte, 32))), "y": encode(ecKey.Y.FillBytes(make([]byte, 32)))},
+		{"kty": "RSA", "kid": "enc-1", "use": "enc", "n": encode(rsaKey.N.Bytes()), "e": "AQAB"},
+	}})
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestParseJWT(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwks, err := ParseJWKS(testJWKS(t, rsaKey, ecKey))
+	require.NoError(t, err)
+
+	keyFunc := func(kid string) (crypto.PublicKey, error) {
+		key, ok := jwks.Key(kid)
+		if !ok {
+			return nil, ErrUnknownJWTKey
+		}
+		return key, nil
+	}
+
+	validation := JWTValidation{Issuer: "issuer", Audience: "api", Skew: time.Minute}
+
+	newClaims := func() map[string]any {
+		return map[string]any{"iss": "issuer", "sub": "billing", "aud": []string{"other", "api"}, "exp": time.Now().Add(time.Hour).Unix(), "scope": "clients:read clients:write"}
+	}
+
+	t.Run("should keep only signing keys", func(t *testing.T) {
+		assert.Equal(t, 2, jwks.Len())
+	})
+
+	t.Run("should verify RS256 and ES256 tokens", func(t *testing.T) {
+		for _, token := range []string{
+			signTestJWT(t, JWTAlgRS256, "rsa-1", rsaKey, newClaims()),
+			signTestJWT(t, JWTAlgES256, "ec-1", ecKey, newClaims()),
+		} {
+			parsed, err := ParseJWT(token, validation, keyFunc)
+
+			require.NoError(t, err)
+			assert.Equal(t, "billing", parsed.Subject)
+			assert.Equal(t, []string{"other", "api"}, []string(parsed.Audience))
+			assert.Equal(t, []string{"clients:read", "clients:write"}, parsed.Scopes())
+		}
+	})
+
+	t.Run("should accept aud as a single string", func(t *testing.T) {
+		claims := newClaims()
+		claims["aud"] = "api"
+
+		parsed, err := ParseJWT(signTestJWT(t, JWTAlgRS256, "rsa-1", rsaKey, claims), validation, keyFunc)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"api"}, []string(parsed.Audience))
+	})
+
+	t.Run("should reject a tampered token", func(t *testing.T) {
+		token := signTestJWT(t, JWTAlgRS256, "rsa-1", rsaKey, newClaims())
+		other := signTestJWT(t, JWTAlgRS256, "rsa-1", rsaKey, map[string]any{"sub": "admin"})
+
+		forged := other[:strings.LastIndex(other, ".")] + token[strings.LastIndex(token, "."):]
+
+		_, err := ParseJWT(forged, validation, keyFunc)
+
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("should reject a key that does not match the alg", func(t *testing.T) {
+		token := signTestJWT(t, JWTAlgES256, "rsa-1", ecKey, newClaims())
+
+		_, err := ParseJWT(token, validation, keyFunc)
+
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("should reject unsupported algorithms", func(t *testing.T) {
+		header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+		payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin"}`))
+
+		_, err := ParseJWT(header+"."+payload+".", validation, keyFunc)
+
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("should return the key lookup error for unknown kids", func(t *testing.T) {
+		token := signTestJWT(t, JWTAlgRS256, "rsa-2", rsaKey, newClaims())
+
+		_, err := ParseJWT(token, validation, keyFunc)
+
+		assert.ErrorIs(t, err, ErrUnknownJWTKey)
+	})
+
+	t.Run("should tolerate clock skew on expiry and not before", func(t *testing.T) {
+		claims := newClaims()
+		claims["exp"] = time.Now().Add(-30 * time.Second).Unix()
+		claims["nbf"] = time.Now().Add(30 * time.Second).Unix()
+
+		_, err := ParseJWT(signTestJWT(t, JWTAlgRS256, "rsa-1", rsaKey, claims), validation, keyFunc)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject expired tokens beyond the skew", func(t *testing.T) {
+		claims := newClaims()
+		claims["exp"] = time.Now().Add(-2 * time.Minute).Unix()
+
+		_, err := ParseJWT(signTestJWT(t, JWTAlgRS256, "rsa-1", rsaKey, claims), validation, keyFunc)
+
+		assert.ErrorIs(t, err, ErrTokenExpired)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("should reject tokens not valid yet", func(t *testing.T) {
+		claims := newClaims()
+		claims["nbf"] = time.Now().Add(2 * time.Minute).Unix()
+
+		_, err := ParseJWT(signTestJWT(t, JWTAlgRS256, "rsa-1", rsaKey, claims), validation, keyFunc)
+
+		assert.ErrorIs(t, err, ErrTokenNotYet)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("should reject another issuer or audience", func(t *testing.T) {
+		token := signTestJWT(t, JWTAlgRS256, "rsa-1", rsaKey, newClaims())
+
+		_, err := ParseJWT(token, JWTValidation{Issuer: "someone-else", Audience: "api"}, keyFunc)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+
+		_, err = ParseJWT(token, JWTValidation{Issuer: "issuer", Audience: "payments"}, keyFunc)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("should require issuer and audience to compare", func(t *testing.T) {
+		token := signTestJWT(t, JWTAlgRS256, "rsa-1", rsaKey, newClaims())
+
+		_, err := ParseJWT(token, JWTValidation{Audience: "api"}, keyFunc)
+		assert.Error(t, err)
+
+		_, err = ParseJWT(token, JWTValidation{Issuer: "issuer"}, keyFunc)
+		assert.Error(t, err)
+	})
+
+	t.Run("should require exp and sub", func(t *testing.T) {
+		claims := newClaims()
+		delete(claims, "exp")
+		_, err := ParseJWT(signTestJWT(t, JWTAlgRS256, "rsa-1", rsaKey, claims), validation, keyFunc)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+
+		claims = newClaims()
+		delete(claims, "sub")
+		_, err = ParseJWT(signTestJWT(t, JWTAlgRS256, "rsa-1", rsaKey, claims), validation, keyFunc)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
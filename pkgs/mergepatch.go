@@ -0,0 +1,53 @@
+package pkgs
+
+import (
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+const MIMEApplicationMergePatchJSON = "application/merge-patch+json"
+
+// MergePatch aplica um JSON Merge Patch (RFC 7396) sobre o documento original
+//
+// Exemplo:
+//
+// merged, err := pkgs.MergePatch([]byte(`{"name":"Gabriel"}`), []byte(`{"name":"Caio"}`))
+func MergePatch(original []byte, patch []byte) ([]byte, error) {
+	var patchValue any
+	if err := jsoniter.Unmarshal(patch, &patchValue); err != nil {
+		return nil, fmt.Errorf("decode patch: %w", err)
+	}
+
+	var originalValue any
+	if len(original) > 0 {
+		if err := jsoniter.Unmarshal(original, &originalValue); err != nil {
+			return nil, fmt.Errorf("decode original: %w", err)
+		}
+	}
+
+	return jsoniter.Marshal(mergePatch(originalValue, patchValue))
+}
+
+func mergePatch(original any, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	originalObject, ok := original.(map[string]any)
+	if !ok {
+		originalObject = make(map[string]any, len(patchObject))
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(originalObject, key)
+			continue
+		}
+
+		originalObject[key] = mergePatch(originalObject[key], value)
+	}
+
+	return originalObject
+}
@@ -0,0 +1,40 @@
+package pkgs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePatch(t *testing.T) {
+	cases := []struct {
+		name     string
+		original string
+		patch    string
+		expected string
+	}{
+		{name: "should replace a member", original: `{"a":"b"}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		{name: "should add a member", original: `{"a":"b"}`, patch: `{"b":"c"}`, expected: `{"a":"b","b":"c"}`},
+		{name: "should remove a member with null", original: `{"a":"b","b":"c"}`, patch: `{"a":null}`, expected: `{"b":"c"}`},
+		{name: "should replace arrays entirely", original: `{"a":["b"]}`, patch: `{"a":["c","d"]}`, expected: `{"a":["c","d"]}`},
+		{name: "should merge nested objects", original: `{"a":{"b":"c","d":"e"}}`, patch: `{"a":{"d":null,"f":"g"}}`, expected: `{"a":{"b":"c","f":"g"}}`},
+		{name: "should replace a non object original", original: `["a"]`, patch: `{"a":"b"}`, expected: `{"a":"b"}`},
+		{name: "should replace the document with a non object patch", original: `{"a":"b"}`, patch: `"c"`, expected: `"c"`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			merged, err := MergePatch([]byte(tc.original), []byte(tc.patch))
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(merged))
+		})
+	}
+
+	t.Run("should return error on invalid patch", func(t *testing.T) {
+		merged, err := MergePatch([]byte(`{"a":"b"}`), []byte(`{invalid`))
+
+		assert.Error(t, err)
+		assert.Nil(t, merged)
+	})
+}
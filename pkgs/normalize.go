@@ -0,0 +1,57 @@
+package pkgs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"unicode"
+)
+
+// NormalizePhone remove espaços, hífens, parênteses e demais separadores de um telefone,
+// mantendo apenas os dígitos e o sinal de + inicial
+//
+// Exemplo:
+//
+// pkgs.NormalizePhone("+55 (21) 99999-9999") // "+5521999999999"
+func NormalizePhone(phone string) string {
+	phone = strings.TrimSpace(phone)
+
+	var b strings.Builder
+	b.Grow(len(phone))
+
+	if strings.HasPrefix(phone, "+") {
+		b.WriteByte('+')
+	}
+
+	for _, r := range phone {
+		if unicode.IsDigit(r) && r <= unicode.MaxASCII {
+			b.WriteRune(r)
+		}
+	}
+
+	if b.Len() == 1 && strings.HasPrefix(phone, "+") {
+		return ""
+	}
+
+	return b.String()
+}
+
+// NormalizeEmail remove espaços nas pontas e converte o email para minúsculas
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// HashPII retorna o hash SHA-256, em hexadecimal, de um email ou telefone já normalizado, ou ""
+// quando o valor é vazio
+//
+// Exemplo:
+//
+// pkgs.HashPII(pkgs.NormalizeEmail(" Gabriel@Email.com ")) // hash de "gabriel@email.com"
+func HashPII(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
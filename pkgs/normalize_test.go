@@ -0,0 +1,41 @@
+package pkgs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	cases := []struct {
+		name     string
+		phone    string
+		expected string
+	}{
+		{name: "should keep an e164 phone untouched", phone: "+5521999999999", expected: "+5521999999999"},
+		{name: "should remove spaces and hyphens", phone: "+55 21 99999-9999", expected: "+5521999999999"},
+		{name: "should remove parentheses and dots", phone: " +55 (21) 99999.9999 ", expected: "+5521999999999"},
+		{name: "should keep phones without country prefix as digits", phone: "21 99999-9999", expected: "21999999999"},
+		{name: "should return empty when there are no digits", phone: "+ -", expected: ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NormalizePhone(tc.phone))
+		})
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	assert.Equal(t, "gabriel@gmail.com", NormalizeEmail("  Gabriel@GMail.com "))
+}
+
+func TestHashPII(t *testing.T) {
+	t.Run("should return the sha-256 of the value in hex", func(t *testing.T) {
+		assert.Equal(t, "c26da3d3ade1d486814c093a6a649f475a23f6d6ea47fa825f711178163ac012", HashPII("gabriel@email.com"))
+	})
+
+	t.Run("should return empty for an empty value", func(t *testing.T) {
+		assert.Empty(t, HashPII(""))
+	})
+}
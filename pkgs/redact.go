@@ -0,0 +1,130 @@
+package pkgs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+const (
+	RedactedEmail = "[redacted-email]"
+	RedactedPhone = "[redacted-phone]"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+	// phonePattern reconhece telefones com DDI, como "+55 21 99999-9999" e "+5521999999999", com
+	// DDD entre parênteses, como "(21) 99999-9999", ou com DDD e separadores, como "21 99999-9999".
+	// Sequências de dígitos sem esses formatos, como timestamps, não são telefones.
+	phonePattern = regexp.MustCompile(`\+\d{1,3}[\s.-]?(?:\(\d{2,3}\)|\d{2,3})[\s.-]?\d{4,5}[\s.-]?\d{4}|\(\d{2,3}\)\s?\d{4,5}[\s.-]?\d{4}|\d{2}[\s.-]\d{4,5}[\s.-]\d{4}`)
+)
+
+// RedactPII troca os emails e telefones do texto por marcadores
+func RedactPII(value string) string {
+	value = emailPattern.ReplaceAllString(value, RedactedEmail)
+	return redactPhones(value)
+}
+
+// redactPhones troca os telefones por marcadores, ignorando os que fazem parte de um
+// identificador maior, como os grupos de um UUID ou um hash em hexadecimal
+func redactPhones(value string) string {
+	var b strings.Builder
+	last := 0
+
+	for _, loc := range phonePattern.FindAllStringIndex(value, -1) {
+		start, end := loc[0], loc[1]
+		if !isPhoneBoundary(value, start-1) || !isPhoneBoundary(value, end) {
+			continue
+		}
+
+		b.WriteString(value[last:start])
+		b.WriteString(RedactedPhone)
+		last = end
+	}
+
+	if last == 0 {
+		return value
+	}
+
+	b.WriteString(value[last:])
+	return b.String()
+}
+
+// isPhoneBoundary informa se o caractere na posição i pode ficar ao lado de um telefone. Letras,
+// dígitos, "_" e "-" indicam que os dígitos continuam um identificador.
+func isPhoneBoundary(value string, i int) bool {
+	if i < 0 || i >= len(value) {
+		return true
+	}
+
+	c := value[i]
+	return !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == '-')
+}
+
+// RedactHandler remove emails e telefones da mensagem e dos atributos de cada registro antes
+// de repassá-lo ao handler seguinte, para que dados pessoais não cheguem aos logs mesmo quando
+// aparecem em um erro ou em um payload logado
+type RedactHandler struct {
+	next slog.Handler
+}
+
+func NewRedactHandler(next slog.Handler) *RedactHandler {
+	return &RedactHandler{next: next}
+}
+
+func (h *RedactHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *RedactHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, RedactPII(record.Message), record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(attr))
+		return true
+	})
+
+	return h.next.Handle(ctx, redacted)
+}
+
+func (h *RedactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		redacted[i] = redactAttr(attr)
+	}
+
+	return &RedactHandler{next: h.next.WithAttrs(redacted)}
+}
+
+func (h *RedactHandler) WithGroup(name string) slog.Handler {
+	return &RedactHandler{next: h.next.WithGroup(name)}
+}
+
+// redactAttr redige os textos do atributo. Valores que não são textos nem números, como erros
+// e structs, são convertidos em texto antes, já que podem carregar dados pessoais.
+func redactAttr(attr slog.Attr) slog.Attr {
+	value := attr.Value.Resolve()
+
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, RedactPII(value.String()))
+	case slog.KindGroup:
+		group := value.Group()
+		redacted := make([]any, len(group))
+		for i, member := range group {
+			redacted[i] = redactAttr(member)
+		}
+
+		return slog.Group(attr.Key, redacted...)
+	case slog.KindAny:
+		if value.Any() == nil {
+			return attr
+		}
+
+		return slog.String(attr.Key, RedactPII(fmt.Sprint(value.Any())))
+	default:
+		return slog.Attr{Key: attr.Key, Value: value}
+	}
+}
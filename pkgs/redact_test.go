@@ -0,0 +1,59 @@
+package pkgs
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactPII(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "email", value: "duplicated Gabriel.V+nu@Gmail.com", expected: "duplicated [redacted-email]"},
+		{name: "phone with separators", value: "phone +55 21 99999-9999 taken", expected: "phone [redacted-phone] taken"},
+		{name: "phone in e164", value: "+5521999999999", expected: "[redacted-phone]"},
+		{name: "phone with area code in parentheses", value: "(21) 99999-9999", expected: "[redacted-phone]"},
+		{name: "phone with area code and separators", value: "call 21 99999-9999 now", expected: "call [redacted-phone] now"},
+		{name: "uuid", value: "client 3b8e1d2f-7c4a-4f1e-9a6b-5d2c8e7f1a90", expected: "client 3b8e1d2f-7c4a-4f1e-9a6b-5d2c8e7f1a90"},
+		{name: "uuid ending in digits", value: "client 3b8e1d2f-7c4a-4f1e-9a6b-123456789012", expected: "client 3b8e1d2f-7c4a-4f1e-9a6b-123456789012"},
+		{name: "uuid made of digits", value: "event 12345678-1234-1234-1234-123456789012", expected: "event 12345678-1234-1234-1234-123456789012"},
+		{name: "unix timestamp", value: "signature t=1760780000,v1=9f86d081", expected: "signature t=1760780000,v1=9f86d081"},
+		{name: "timestamp in milliseconds", value: "occurred 1760780000123", expected: "occurred 1760780000123"},
+		{name: "date", value: "created at 2026-01-01T12:00:00Z", expected: "created at 2026-01-01T12:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run("should redact "+tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RedactPII(tt.value))
+		})
+	}
+}
+
+func TestRedactHandler(t *testing.T) {
+	t.Run("should redact the message, the attributes and the errors of every record", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(NewRedactHandler(slog.NewTextHandler(&buf, nil))).
+			With(slog.String("contact", "gabriel@gmail.com"))
+
+		logger.Error("error to create contact +5521999999999",
+			slog.Any("error", errors.New("duplicated email gabriel@gmail.com")),
+			slog.Group("payload", slog.String("phone", "+55 21 99999-9999")),
+			slog.Int("attempt", 2),
+		)
+
+		out := buf.String()
+		assert.NotContains(t, out, "gabriel@gmail.com")
+		assert.NotContains(t, out, "99999")
+		assert.Contains(t, out, `msg="error to create contact [redacted-phone]"`)
+		assert.Contains(t, out, "contact=[redacted-email]")
+		assert.Contains(t, out, `error="duplicated email [redacted-email]"`)
+		assert.Contains(t, out, "payload.phone=[redacted-phone]")
+		assert.Contains(t, out, "attempt=2")
+	})
+}
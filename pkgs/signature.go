@@ -0,0 +1,84 @@
+package pkgs
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrSignatureExpired = errors.New("signature timestamp outside tolerance")
+)
+
+// SignPayload assina o corpo com HMAC-SHA256 sobre "<timestamp>.<corpo>" e retorna o valor do
+// header de assinatura no formato "t=<timestamp unix>,v1=<hmac em hexadecimal>"
+//
+// Exemplo:
+//
+// req.Header.Set("Webhook-Signature", pkgs.SignPayload(secret, time.Now(), body))
+func SignPayload(secret string, timestamp time.Time, body []byte) string {
+	unix := timestamp.Unix()
+	return fmt.Sprintf("t=%d,v1=%s", unix, computeSignature(secret, unix, body))
+}
+
+// VerifySignature confere um header gerado por SignPayload, rejeitando assinaturas cujo
+// timestamp esteja a mais de tolerance do horário atual
+//
+// Exemplo:
+//
+// err := pkgs.VerifySignature(secret, req.Header.Get("Webhook-Signature"), body, 5*time.Minute)
+func VerifySignature(secret string, header string, body []byte, tolerance time.Duration) error {
+	var (
+		unix       int64
+		signatures []string
+		err        error
+	)
+
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return ErrInvalidSignature
+		}
+
+		switch key {
+		case "t":
+			if unix, err = strconv.ParseInt(value, 10, 64); err != nil {
+				return ErrInvalidSignature
+			}
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	if unix == 0 || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+
+	if age := time.Since(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return ErrSignatureExpired
+	}
+
+	expected := computeSignature(secret, unix, body)
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
+
+func computeSignature(secret string, unix int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(unix, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,49 @@
+package pkgs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignPayload(t *testing.T) {
+	t.Run("should sign timestamp and body with HMAC-SHA256", func(t *testing.T) {
+		header := SignPayload("secret", time.Unix(1745143200, 0), []byte(`{"id":"1"}`))
+
+		assert.Equal(t, "t=1745143200,v1=5070ae810159edaef544c163b221c753b8919b568ee3b228c74b627c5350748e", header)
+	})
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+
+	t.Run("should accept a signature made with the same secret", func(t *testing.T) {
+		header := SignPayload("secret", time.Now(), body)
+
+		assert.NoError(t, VerifySignature("secret", header, body, time.Minute))
+	})
+
+	t.Run("should reject a signature made with another secret", func(t *testing.T) {
+		header := SignPayload("other", time.Now(), body)
+
+		assert.ErrorIs(t, VerifySignature("secret", header, body, time.Minute), ErrInvalidSignature)
+	})
+
+	t.Run("should reject a tampered body", func(t *testing.T) {
+		header := SignPayload("secret", time.Now(), body)
+
+		assert.ErrorIs(t, VerifySignature("secret", header, []byte(`{"id":"2"}`), time.Minute), ErrInvalidSignature)
+	})
+
+	t.Run("should reject an old signature", func(t *testing.T) {
+		header := SignPayload("secret", time.Now().Add(-time.Hour), body)
+
+		assert.ErrorIs(t, VerifySignature("secret", header, body, time.Minute), ErrSignatureExpired)
+	})
+
+	t.Run("should reject a malformed header", func(t *testing.T) {
+		assert.ErrorIs(t, VerifySignature("secret", "v1=abc", body, time.Minute), ErrInvalidSignature)
+		assert.ErrorIs(t, VerifySignature("secret", "garbage", body, time.Minute), ErrInvalidSignature)
+	})
+}
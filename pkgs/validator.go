@@ -0,0 +1,129 @@
+package pkgs
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/go-playground/validator/v10"
+)
+
+type Validator struct {
+	validate *validator.Validate
+}
+
+// NewValidator cria um validador que aplica as regras declaradas nas tags `binding`
+// dos payloads, usando o nome do campo em JSON nas mensagens de erro
+//
+// Exemplo:
+//
+// e.Validator = pkgs.NewValidator()
+func NewValidator() *Validator {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate.SetTagName("binding")
+	_ = validate.RegisterValidation("tenant", func(fl validator.FieldLevel) bool {
+		return models.IsValidTenant(fl.Field().String())
+	})
+	_ = validate.RegisterValidation("https_url", func(fl validator.FieldLevel) bool {
+		u, err := url.Parse(fl.Field().String())
+		return err == nil && u.Scheme == "https" && u.Hostname() != ""
+	})
+	_ = validate.RegisterValidation("scope", func(fl validator.FieldLevel) bool {
+		return slices.Contains(models.Scopes, fl.Field().String())
+	})
+	_ = validate.RegisterValidation("webhook_event", func(fl validator.FieldLevel) bool {
+		return slices.Contains(models.WebhookEventTypes, fl.Field().String())
+	})
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+
+		if name == "" {
+			return field.Name
+		}
+
+		return name
+	})
+
+	return &Validator{
+		validate: validate,
+	}
+}
+
+// Validate valida o payload e retorna um *models.ValidationError com todos os campos inválidos
+func (v *Validator) Validate(i any) error {
+	err := v.validate.Struct(i)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return fmt.Errorf("validate payload: %w", err)
+	}
+
+	fields := make([]models.FieldError, len(validationErrors))
+	for i, fe := range validationErrors {
+		fields[i] = models.FieldError{
+			Field:   fieldPath(fe),
+			Message: fieldMessage(fe),
+		}
+	}
+
+	return &models.ValidationError{Fields: fields}
+}
+
+func fieldPath(fe validator.FieldError) string {
+	// Namespace vem no formato "CreateClientPayload.contacts[0].phone"
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+
+	return path
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "e164":
+		return "must be a valid E.164 phone number"
+	case "email":
+		return "must be a valid email"
+	case "uuid":
+		return "must be a valid UUID"
+	case "min":
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "required_without":
+		return fmt.Sprintf("is required when %s is not present", lowerFirst(fe.Param()))
+	case "tenant":
+		return "must have up to 64 lowercase letters, digits, hyphens or underscores"
+	case "https_url":
+		return "must be a valid https URL"
+	case "scope":
+		return fmt.Sprintf("must be one of: %s", strings.Join(models.Scopes, ", "))
+	case "webhook_event":
+		return fmt.Sprintf("must be one of: %s", strings.Join(models.WebhookEventTypes, ", "))
+	case "gtfield":
+		return fmt.Sprintf("must be greater than %s", lowerFirst(fe.Param()))
+	default:
+		return fmt.Sprintf("failed on %s validation", fe.Tag())
+	}
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -0,0 +1,29 @@
+package publishers
+
+import (
+	"context"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+)
+
+// FanoutPublisher entrega o evento para todos os publishers, em ordem. Para no primeiro erro,
+// então os publishers devem tolerar receber de novo um evento que já aceitaram.
+type FanoutPublisher struct {
+	publishers []Publisher
+}
+
+func NewFanoutPublisher(publishers ...Publisher) *FanoutPublisher {
+	return &FanoutPublisher{
+		publishers: publishers,
+	}
+}
+
+func (f *FanoutPublisher) Publish(ctx context.Context, message *models.EventMessage) error {
+	for _, publisher := range f.publishers {
+		if err := publisher.Publish(ctx, message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package publishers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+)
+
+// LogFilePublisher acrescenta cada evento publicado como uma linha JSON ao final de um arquivo
+type LogFilePublisher struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewLogFilePublisher(path string) *LogFilePublisher {
+	return &LogFilePublisher{path: path}
+}
+
+func (l *LogFilePublisher) Publish(ctx context.Context, message *models.EventMessage) error {
+	line, err := jsoniter.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("encode event %s: %w", message.ID, err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", l.path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write event %s: %w", message.ID, err)
+	}
+
+	return nil
+}
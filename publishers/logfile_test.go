@@ -0,0 +1,62 @@
+package publishers
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+)
+
+func TestLogFilePublisher_Publish(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should append one JSON line per event", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "outbox.log")
+		publisher := NewLogFilePublisher(path)
+
+		occurredAt := time.Date(2025, 4, 20, 10, 0, 0, 0, time.UTC)
+		for i, id := range []string{"event-1", "event-2"} {
+			err := publisher.Publish(ctx, &models.EventMessage{
+				ID:          id,
+				Sequence:    int64(i + 1),
+				Type:        models.EventClientCreated,
+				AggregateID: "client-123",
+				TenantID:    "default",
+				Payload:     jsoniter.RawMessage(`{"client":{"id":"client-123"}}`),
+				OccurredAt:  occurredAt,
+			})
+			require.NoError(t, err)
+		}
+
+		file, err := os.Open(path)
+		require.NoError(t, err)
+		defer file.Close()
+
+		var lines []string
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+
+		assert.Equal(t, []string{
+			`{"id":"event-1","sequence":1,"type":"ClientCreated","aggregateId":"client-123","tenantId":"default","payload":{"client":{"id":"client-123"}},"occurredAt":"2025-04-20T10:00:00Z"}`,
+			`{"id":"event-2","sequence":2,"type":"ClientCreated","aggregateId":"client-123","tenantId":"default","payload":{"client":{"id":"client-123"}},"occurredAt":"2025-04-20T10:00:00Z"}`,
+		}, lines)
+	})
+
+	t.Run("should return error if the file cannot be opened", func(t *testing.T) {
+		publisher := NewLogFilePublisher(filepath.Join(t.TempDir(), "missing", "outbox.log"))
+
+		err := publisher.Publish(ctx, &models.EventMessage{ID: "event-1"})
+
+		assert.ErrorContains(t, err, "open")
+	})
+}
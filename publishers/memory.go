@@ -0,0 +1,35 @@
+package publishers
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+)
+
+// MemoryPublisher guarda os eventos publicados em memória. Útil para desenvolvimento local e testes.
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	messages []*models.EventMessage
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (m *MemoryPublisher) Publish(ctx context.Context, message *models.EventMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+// Messages retorna os eventos publicados até agora, na ordem de publicação
+func (m *MemoryPublisher) Messages() []*models.EventMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.messages)
+}
@@ -0,0 +1,43 @@
+package publishers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/g-villarinho/nubank-challenge/configs"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/repositories"
+)
+
+const (
+	PublisherMemory  = "memory"
+	PublisherLogFile = "logfile"
+)
+
+// Publisher entrega os eventos do outbox para os consumidores. Um evento pode ser entregue mais
+// de uma vez; um erro faz com que ele seja tentado de novo no próximo ciclo do relay.
+type Publisher interface {
+	Publish(ctx context.Context, message *models.EventMessage) error
+}
+
+// NewPublisher cria o publisher escolhido em OUTBOX_PUBLISHER e repassa cada evento também
+// para as inscrições de webhook
+func NewPublisher(di *pkgs.Di) (Publisher, error) {
+	webhookRepository, err := pkgs.Invoke[repositories.WebhookRepository](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke repositories.webhook: %w", err)
+	}
+
+	var publisher Publisher
+	switch configs.Env.Outbox.Publisher {
+	case PublisherMemory:
+		publisher = NewMemoryPublisher()
+	case PublisherLogFile:
+		publisher = NewLogFilePublisher(configs.Env.Outbox.LogFile)
+	default:
+		return nil, fmt.Errorf("unknown outbox publisher %q", configs.Env.Outbox.Publisher)
+	}
+
+	return NewFanoutPublisher(publisher, NewWebhookPublisher(webhookRepository)), nil
+}
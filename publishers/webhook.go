@@ -0,0 +1,57 @@
+package publishers
+
+import (
+	"context"
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/repositories"
+)
+
+// WebhookPublisher enfileira uma entrega para cada inscrição ativa do tenant do evento que
+// assinou o seu tipo.
+// O envio é feito depois pelo dispatcher de webhooks, com retentativas independentes por inscrição.
+type WebhookPublisher struct {
+	wr repositories.WebhookRepository
+}
+
+func NewWebhookPublisher(webhookRepository repositories.WebhookRepository) *WebhookPublisher {
+	return &WebhookPublisher{
+		wr: webhookRepository,
+	}
+}
+
+func (w *WebhookPublisher) Publish(ctx context.Context, message *models.EventMessage) error {
+	subscriptions, err := w.wr.GetActiveSubscriptions(ctx, message.TenantID, message.Type)
+	if err != nil {
+		return fmt.Errorf("get active webhook subscriptions for %s: %w", message.Type, err)
+	}
+
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	payload, err := jsoniter.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("encode event %s: %w", message.ID, err)
+	}
+
+	deliveries := make([]*models.WebhookDelivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		deliveries = append(deliveries, &models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        message.ID,
+			TenantID:       subscription.TenantID,
+			EventType:      message.Type,
+			Payload:        payload,
+		})
+	}
+
+	if err := w.wr.CreateDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("create webhook deliveries for event %s: %w", message.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package publishers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/g-villarinho/nubank-challenge/mocks"
+	"github.com/g-villarinho/nubank-challenge/models"
+)
+
+func TestWebhookPublisher_Publish(t *testing.T) {
+	ctx := context.Background()
+	message := &models.EventMessage{
+		ID:          "event-1",
+		Type:        models.EventClientCreated,
+		AggregateID: "client-123",
+		TenantID:    "tenant-a",
+		Payload:     jsoniter.RawMessage(`{"client":{"id":"client-123"}}`),
+	}
+
+	t.Run("should enqueue one delivery per matching subscription", func(t *testing.T) {
+		webhookRepo := new(mocks.WebhookRepositoryMock)
+		publisher := NewWebhookPublisher(webhookRepo)
+
+		webhookRepo.On("GetActiveSubscriptions", ctx, "tenant-a", models.EventClientCreated).Return([]*models.WebhookSubscription{
+			{ID: "webhook-1", TenantID: "tenant-a"},
+			{ID: "webhook-2", TenantID: "tenant-a"},
+		}, nil)
+		webhookRepo.
+			On("CreateDeliveries", ctx, mock.MatchedBy(func(deliveries []*models.WebhookDelivery) bool {
+				return len(deliveries) == 2 &&
+					deliveries[0].SubscriptionID == "webhook-1" &&
+					deliveries[1].SubscriptionID == "webhook-2" &&
+					deliveries[0].TenantID == "tenant-a" &&
+					deliveries[0].EventID == "event-1" &&
+					jsoniter.Get(deliveries[0].Payload, "aggregateId").ToString() == "client-123"
+			})).
+			Return(nil)
+
+		err := publisher.Publish(ctx, message)
+
+		assert.NoError(t, err)
+		webhookRepo.AssertExpectations(t)
+	})
+
+	t.Run("should do nothing without subscriptions", func(t *testing.T) {
+		webhookRepo := new(mocks.WebhookRepositoryMock)
+		publisher := NewWebhookPublisher(webhookRepo)
+
+		webhookRepo.On("GetActiveSubscriptions", ctx, "tenant-a", models.EventClientCreated).Return(nil, nil)
+
+		err := publisher.Publish(ctx, message)
+
+		assert.NoError(t, err)
+		webhookRepo.AssertNotCalled(t, "CreateDeliveries", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error if deliveries cannot be enqueued", func(t *testing.T) {
+		webhookRepo := new(mocks.WebhookRepositoryMock)
+		publisher := NewWebhookPublisher(webhookRepo)
+
+		webhookRepo.On("GetActiveSubscriptions", ctx, "tenant-a", models.EventClientCreated).Return([]*models.WebhookSubscription{{ID: "webhook-1"}}, nil)
+		webhookRepo.On("CreateDeliveries", ctx, mock.Anything).Return(errors.New("db failure"))
+
+		err := publisher.Publish(ctx, message)
+
+		assert.EqualError(t, err, "create webhook deliveries for event event-1: db failure")
+	})
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/configs"
+	"github.com/g-villarinho/nubank-challenge/dependencies"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/repositories"
+	"github.com/g-villarinho/nubank-challenge/services"
+	"github.com/g-villarinho/nubank-challenge/storages"
+	"gorm.io/gorm"
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run executa cada etapa do expurgo. O container é desligado mesmo quando uma etapa falha, para
+// que o pool de conexões com o Postgres seja sempre fechado.
+func run() (err error) {
+	if err := configs.LoadEnv(); err != nil {
+		return fmt.Errorf("load env: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	// O expurgo alcança todos os tenants, o que as políticas de row-level security só permitem
+	// ao papel das rotinas internas
+	db, err := storages.NewPostgresJobsStorage(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to database: %w", err)
+	}
+
+	di, err := newDi(db)
+	if err != nil {
+		return fmt.Errorf("build dependencies: %w", err)
+	}
+
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if shutdownErr := di.Shutdown(shutdownCtx); shutdownErr != nil {
+			err = errors.Join(err, fmt.Errorf("shutdown dependencies: %w", shutdownErr))
+		}
+	}()
+
+	if err := di.Validate(); err != nil {
+		return fmt.Errorf("validate dependencies: %w", err)
+	}
+
+	clientService, err := pkgs.Invoke[services.ClientService](di)
+	if err != nil {
+		return fmt.Errorf("invoke services.client: %w", err)
+	}
+
+	retention := time.Duration(configs.Env.Purge.RetentionDays) * 24 * time.Hour
+
+	// O expurgo alcança os clientes removidos de todos os tenants
+	purged, err := clientService.PurgeDeletedClients(pkgs.WithTenant(ctx, models.AllTenants), retention)
+	if err != nil {
+		return fmt.Errorf("purge deleted clients: %w", err)
+	}
+
+	log.Printf("purged %d clients deleted more than %d days ago", purged, configs.Env.Purge.RetentionDays)
+
+	idempotencyService, err := pkgs.Invoke[services.IdempotencyService](di)
+	if err != nil {
+		return fmt.Errorf("invoke services.idempotency: %w", err)
+	}
+
+	expired, err := idempotencyService.PurgeExpiredKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("purge expired idempotency keys: %w", err)
+	}
+
+	log.Printf("purged %d expired idempotency keys", expired)
+
+	outboxService, err := pkgs.Invoke[services.OutboxService](di)
+	if err != nil {
+		return fmt.Errorf("invoke services.outbox: %w", err)
+	}
+
+	events, err := outboxService.PurgeFinishedEvents(pkgs.WithTenant(ctx, models.AllTenants))
+	if err != nil {
+		return fmt.Errorf("purge finished outbox events: %w", err)
+	}
+
+	log.Printf("purged %d outbox events finished more than %d hours ago", events, configs.Env.Outbox.RetentionHours)
+
+	webhookService, err := pkgs.Invoke[services.WebhookService](di)
+	if err != nil {
+		return fmt.Errorf("invoke services.webhook: %w", err)
+	}
+
+	deliveries, err := webhookService.PurgeFinishedDeliveries(pkgs.WithTenant(ctx, models.AllTenants))
+	if err != nil {
+		return fmt.Errorf("purge finished webhook deliveries: %w", err)
+	}
+
+	log.Printf("purged %d webhook deliveries finished more than %d hours ago", deliveries, configs.Env.Outbox.RetentionHours)
+
+	rateLimitRepository, err := pkgs.Invoke[repositories.RateLimitRepository](di)
+	if err != nil {
+		return fmt.Errorf("invoke repositories.rate_limit: %w", err)
+	}
+
+	// Um balde parado há mais de um dia já voltou a ficar cheio e pode ser recriado
+	stale, err := rateLimitRepository.PurgeStaleBuckets(ctx, time.Now().UTC().Add(-24*time.Hour))
+	if err != nil {
+		return fmt.Errorf("purge stale rate limit buckets: %w", err)
+	}
+
+	log.Printf("purged %d rate limit buckets idle for more than 24 hours", stale)
+
+	return nil
+}
+
+// newDi monta o container do expurgo sobre db com os mesmos providers da API
+func newDi(db *gorm.DB) (*pkgs.Di, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("get database pool: %w", err)
+	}
+
+	di := pkgs.NewDi()
+	di.RegisterCloser("postgres", sqlDB)
+
+	pkgs.Provide(di, func(di *pkgs.Di) (*gorm.DB, error) {
+		return db, nil
+	})
+	dependencies.Provide(di)
+
+	return di, nil
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, key *models.APIKey) error
+	GetAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error)
+	GetAPIKeys(ctx context.Context) ([]*models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string, revokedAt time.Time) (bool, error)
+	TouchAPIKey(ctx context.Context, id string, usedAt time.Time) error
+}
+
+type apiKeyRepository struct {
+	di *pkgs.Di
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(di *pkgs.Di) (APIKeyRepository, error) {
+	db, err := pkgs.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke gorm.DB: %w", err)
+	}
+
+	return &apiKeyRepository{
+		di: di,
+		db: db,
+	}, nil
+}
+
+func (a *apiKeyRepository) CreateAPIKey(ctx context.Context, key *models.APIKey) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return fmt.Errorf("generate uuid: %w", err)
+	}
+
+	key.ID = id.String()
+	key.CreatedAt = time.Now().UTC()
+
+	if err := conn(ctx, a.db).Create(key).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *apiKeyRepository) GetAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	var key models.APIKey
+
+	if err := conn(ctx, a.db).Where("hash = ?", hash).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func (a *apiKeyRepository) GetAPIKeys(ctx context.Context) ([]*models.APIKey, error) {
+	var keys []*models.APIKey
+
+	if err := conn(ctx, a.db).Order("created_at ASC, id ASC").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey revoga a chave e retorna false se ela não existir ou já estiver revogada
+func (a *apiKeyRepository) RevokeAPIKey(ctx context.Context, id string, revokedAt time.Time) (bool, error) {
+	result := conn(ctx, a.db).
+		Model(&models.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		UpdateColumn("revoked_at", revokedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (a *apiKeyRepository) TouchAPIKey(ctx context.Context, id string, usedAt time.Time) error {
+	return conn(ctx, a.db).
+		Model(&models.APIKey{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", usedAt).Error
+}
@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyRepository_GetAPIKeyByHash(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return nil if no key has the hash", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &apiKeyRepository{db: db}
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "api_keys" WHERE hash = $1`)).
+			WithArgs("hash", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		key, err := repo.GetAPIKeyByHash(ctx, "hash")
+
+		assert.NoError(t, err)
+		assert.Nil(t, key)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAPIKeyRepository_RevokeAPIKey(t *testing.T) {
+	ctx := context.Background()
+	revokedAt := time.Date(2025, 4, 20, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should revoke only keys that are still active", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &apiKeyRepository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "api_keys" SET "revoked_at"=$1 WHERE id = $2 AND revoked_at IS NULL`)).
+			WithArgs(revokedAt, "key-1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		revoked, err := repo.RevokeAPIKey(ctx, "key-1", revokedAt)
+
+		assert.NoError(t, err)
+		assert.False(t, revoked)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
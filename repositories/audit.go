@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AuditRepository interface {
+	CreateAuditLog(ctx context.Context, log *models.AuditLog) error
+	GetAuditLogs(ctx context.Context, opts models.AuditListOptions) ([]*models.AuditLog, bool, error)
+}
+
+type auditRepository struct {
+	di *pkgs.Di
+	db *gorm.DB
+}
+
+func NewAuditRepository(di *pkgs.Di) (AuditRepository, error) {
+	db, err := pkgs.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke gorm.DB: %w", err)
+	}
+
+	return &auditRepository{
+		di: di,
+		db: db,
+	}, nil
+}
+
+func (a *auditRepository) CreateAuditLog(ctx context.Context, log *models.AuditLog) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return fmt.Errorf("generate uuid: %w", err)
+	}
+
+	log.ID = id.String()
+	log.TenantID = pkgs.TenantFromContext(ctx)
+	log.CreatedAt = time.Now().UTC()
+
+	return tenantConn(ctx, a.db, func(db *gorm.DB) error {
+		return db.Create(log).Error
+	})
+}
+
+// GetAuditLogs busca uma página de registros de auditoria por keyset, do mais recente para o
+// mais antigo, retornando também se há mais registros depois dela
+func (a *auditRepository) GetAuditLogs(ctx context.Context, opts models.AuditListOptions) ([]*models.AuditLog, bool, error) {
+	var cursorCreatedAt any
+	if opts.Cursor != nil {
+		value, err := cursorValue(models.ClientSortCreatedAt, opts.Cursor.Value)
+		if err != nil {
+			return nil, false, err
+		}
+
+		cursorCreatedAt = value
+	}
+
+	var logs []*models.AuditLog
+	err := tenantConn(ctx, a.db, func(db *gorm.DB) error {
+		query := db.Model(&models.AuditLog{})
+
+		if opts.EntityType != "" {
+			query = query.Where("entity_type = ?", opts.EntityType)
+		}
+
+		if opts.EntityID != "" {
+			query = query.Where("entity_id = ?", opts.EntityID)
+		}
+
+		if opts.ClientID != "" {
+			query = query.Where("client_id = ?", opts.ClientID)
+		}
+
+		if opts.Action != "" {
+			query = query.Where("action = ?", opts.Action)
+		}
+
+		if opts.Actor != "" {
+			query = query.Where("actor = ?", opts.Actor)
+		}
+
+		if opts.RequestID != "" {
+			query = query.Where("request_id = ?", opts.RequestID)
+		}
+
+		if !opts.From.IsZero() {
+			query = query.Where("created_at >= ?", opts.From)
+		}
+
+		if !opts.To.IsZero() {
+			query = query.Where("created_at < ?", opts.To)
+		}
+
+		if opts.Cursor != nil {
+			query = query.Where("(created_at, id) < (?, ?)", cursorCreatedAt, opts.Cursor.ID)
+		}
+
+		return query.
+			Order("created_at DESC, id DESC").
+			Limit(opts.Limit + 1).
+			Find(&logs).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(logs) > opts.Limit
+	if hasMore {
+		logs = logs[:opts.Limit]
+	}
+
+	return logs, hasMore, nil
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditRepository_CreateAuditLog(t *testing.T) {
+	ctx := pkgs.WithTenant(context.Background(), "tenant-a")
+
+	t.Run("should insert the audit log with a new id", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &auditRepository{db: db}
+
+		log := &models.AuditLog{
+			EntityType: models.AuditEntityClient,
+			EntityID:   "7a395834-0ed5-4954-8e1d-b63cd2fdb97a",
+			ClientID:   "7a395834-0ed5-4954-8e1d-b63cd2fdb97a",
+			Action:     models.AuditActionUpdate,
+			Actor:      "backoffice",
+			RequestID:  "request-1",
+			Before:     []byte(`{"name":"Gabriel"}`),
+			After:      []byte(`{"name":"Gabriel V."}`),
+			Diff:       []byte(`{"name":{"from":"Gabriel","to":"Gabriel V."}}`),
+		}
+
+		expectTenantTx(mock, "tenant-a")
+		mock.ExpectExec(`INSERT INTO "audit_logs"`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.CreateAuditLog(ctx, log)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, log.ID)
+		assert.Equal(t, "tenant-a", log.TenantID)
+		assert.False(t, log.CreatedAt.IsZero())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAuditRepository_GetAuditLogs(t *testing.T) {
+	ctx := pkgs.WithTenant(context.Background(), "tenant-a")
+	createdAt := time.Date(2025, 4, 20, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should filter and page from the most recent log", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &auditRepository{db: db}
+
+		cursor := &models.Cursor{Sort: models.AuditSortCreatedAt, Value: createdAt.Format(time.RFC3339Nano), ID: "log-3"}
+
+		expectTenantTx(mock, "tenant-a")
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "audit_logs" WHERE client_id = $1 AND action = $2 AND (created_at, id) < ($3, $4) AND tenant_id = $5 ORDER BY created_at DESC, id DESC LIMIT $6`)).
+			WithArgs("client-1", models.AuditActionUpdate, createdAt, "log-3", "tenant-a", 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "client_id", "created_at"}).
+				AddRow("log-2", "client-1", createdAt.Add(-time.Minute)).
+				AddRow("log-1", "client-1", createdAt.Add(-2*time.Minute)).
+				AddRow("log-0", "client-1", createdAt.Add(-3*time.Minute)))
+		mock.ExpectCommit()
+
+		logs, hasMore, err := repo.GetAuditLogs(ctx, models.AuditListOptions{
+			Limit:    2,
+			Cursor:   cursor,
+			ClientID: "client-1",
+			Action:   models.AuditActionUpdate,
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, hasMore)
+		assert.Len(t, logs, 2)
+		assert.Equal(t, "log-2", logs[0].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should return validation error on invalid cursor value", func(t *testing.T) {
+		db, _ := newMockDB(t)
+		repo := &auditRepository{db: db}
+
+		_, _, err := repo.GetAuditLogs(ctx, models.AuditListOptions{
+			Limit:  2,
+			Cursor: &models.Cursor{Sort: models.AuditSortCreatedAt, Value: "invalid", ID: "log-3"},
+		})
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+	})
+}
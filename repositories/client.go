@@ -2,7 +2,10 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/g-villarinho/nubank-challenge/models"
@@ -13,9 +16,16 @@ import (
 
 type ClientRepository interface {
 	CreateClient(ctx context.Context, client *models.Client) error
-	GetClientsWithContact(ctx context.Context) ([]*models.Client, error)
+	GetClientsWithContact(ctx context.Context, opts models.ClientListOptions) ([]*models.Client, bool, error)
 	GetClientWitContactsByID(ctx context.Context, id string) (*models.Client, error)
 	GetClientByID(ctx context.Context, id string) (*models.Client, error)
+	UpdateClient(ctx context.Context, client *models.Client) error
+	TouchClient(ctx context.Context, id string) error
+	DeleteClient(ctx context.Context, id string, version int64, deletedAt time.Time) error
+	GetDeletedClients(ctx context.Context) ([]*models.Client, error)
+	GetDeletedClientByID(ctx context.Context, id string) (*models.Client, error)
+	RestoreClient(ctx context.Context, id string) error
+	PurgeDeletedClients(ctx context.Context, before time.Time) (int64, error)
 }
 
 type clientRepository struct {
@@ -42,19 +52,96 @@ func (c *clientRepository) CreateClient(ctx context.Context, client *models.Clie
 	}
 
 	client.ID = id.String()
+	client.TenantID = pkgs.TenantFromContext(ctx)
+	client.Version = 1
 	client.CreatedAt = time.Now().UTC()
 
-	if err := c.db.WithContext(ctx).Create(client).Error; err != nil {
-		return err
+	return tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		return db.Create(client).Error
+	})
+}
+
+// GetClientsWithContact busca uma página de clientes por keyset, retornando também se há mais
+// registros na direção do cursor. A página é sempre devolvida na ordenação pedida.
+func (c *clientRepository) GetClientsWithContact(ctx context.Context, opts models.ClientListOptions) ([]*models.Client, bool, error) {
+	field := strings.TrimPrefix(opts.Sort, "-")
+	if field != models.ClientSortName && field != models.ClientSortCreatedAt {
+		return nil, false, fmt.Errorf("unsupported sort field %q", field)
 	}
 
-	return nil
-}
+	descending := strings.HasPrefix(opts.Sort, "-")
+	backward := opts.Cursor != nil && opts.Cursor.Backward
+	if backward {
+		descending = !descending
+	}
 
-func (c *clientRepository) GetClientsWithContact(ctx context.Context) ([]*models.Client, error) {
 	var clients []*models.Client
+	err := tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		query := db.Preload("Contacts")
+
+		if opts.ClientIDs != nil {
+			query = query.Where("id IN ?", opts.ClientIDs)
+		}
+
+		if opts.NamePrefix != "" {
+			query = query.Where("name LIKE ?", escapeLike(opts.NamePrefix)+"%")
+		}
+
+		if !opts.CreatedFrom.IsZero() {
+			query = query.Where("created_at >= ?", opts.CreatedFrom)
+		}
+
+		if !opts.CreatedTo.IsZero() {
+			query = query.Where("created_at < ?", opts.CreatedTo)
+		}
+
+		if opts.Cursor != nil {
+			value, err := cursorValue(field, opts.Cursor.Value)
+			if err != nil {
+				return err
+			}
+
+			operator := ">"
+			if descending {
+				operator = "<"
+			}
+
+			query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", field, operator), value, opts.Cursor.ID)
+		}
+
+		direction := "ASC"
+		if descending {
+			direction = "DESC"
+		}
+
+		return query.
+			Order(fmt.Sprintf("%s %s, id %s", field, direction, direction)).
+			Limit(opts.Limit + 1).
+			Find(&clients).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(clients) > opts.Limit
+	if hasMore {
+		clients = clients[:opts.Limit]
+	}
+
+	if backward {
+		slices.Reverse(clients)
+	}
+
+	return clients, hasMore, nil
+}
+
+func (c *clientRepository) GetClientWitContactsByID(ctx context.Context, id string) (*models.Client, error) {
+	var client models.Client
 
-	if err := c.db.WithContext(ctx).Preload("Contacts").Find(&clients).Error; err != nil {
+	err := tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		return db.Preload("Contacts").First(&client, "id = ?", id).Error
+	})
+	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -62,13 +149,16 @@ func (c *clientRepository) GetClientsWithContact(ctx context.Context) ([]*models
 		return nil, err
 	}
 
-	return clients, nil
+	return &client, nil
 }
 
-func (c *clientRepository) GetClientWitContactsByID(ctx context.Context, id string) (*models.Client, error) {
+func (c *clientRepository) GetClientByID(ctx context.Context, id string) (*models.Client, error) {
 	var client models.Client
 
-	if err := c.db.WithContext(ctx).Preload("Contacts").First(&client, "id = ?", id).Error; err != nil {
+	err := tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		return db.First(&client, "id = ?", id).Error
+	})
+	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -79,10 +169,110 @@ func (c *clientRepository) GetClientWitContactsByID(ctx context.Context, id stri
 	return &client, nil
 }
 
-func (c *clientRepository) GetClientByID(ctx context.Context, id string) (*models.Client, error) {
+// UpdateClient grava o cliente somente se ele ainda estiver na versão lida, incrementando-a.
+// Retorna models.ErrPreconditionFailed se outra requisição alterou o cliente antes.
+func (c *clientRepository) UpdateClient(ctx context.Context, client *models.Client) error {
+	updatedAt := sql.NullTime{Time: time.Now().UTC(), Valid: true}
+
+	err := tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		result := db.
+			Model(&models.Client{}).
+			Where("id = ? AND version = ?", client.ID, client.Version).
+			UpdateColumns(map[string]any{
+				"name":       client.Name,
+				"version":    gorm.Expr("version + 1"),
+				"updated_at": updatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return models.ErrPreconditionFailed
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	client.Version++
+	client.UpdatedAt = updatedAt
+
+	return nil
+}
+
+// TouchClient incrementa a versão do cliente quando um de seus contatos é alterado
+func (c *clientRepository) TouchClient(ctx context.Context, id string) error {
+	return tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		result := db.
+			Model(&models.Client{}).
+			Where("id = ?", id).
+			UpdateColumn("version", gorm.Expr("version + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return models.ErrClientNotFound
+		}
+
+		return nil
+	})
+}
+
+func (c *clientRepository) DeleteClient(ctx context.Context, id string, version int64, deletedAt time.Time) error {
+	return tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		result := db.
+			Model(&models.Client{}).
+			Where("id = ? AND version = ?", id, version).
+			UpdateColumns(map[string]any{
+				"version":    gorm.Expr("version + 1"),
+				"deleted_at": deletedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return models.ErrPreconditionFailed
+		}
+
+		return nil
+	})
+}
+
+func (c *clientRepository) GetDeletedClients(ctx context.Context) ([]*models.Client, error) {
+	var clients []*models.Client
+
+	err := tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		return db.
+			Unscoped().
+			Preload("Contacts", func(db *gorm.DB) *gorm.DB {
+				return db.Unscoped()
+			}).
+			Where("deleted_at IS NOT NULL").
+			Order("deleted_at DESC").
+			Find(&clients).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return clients, nil
+}
+
+func (c *clientRepository) GetDeletedClientByID(ctx context.Context, id string) (*models.Client, error) {
 	var client models.Client
 
-	if err := c.db.WithContext(ctx).First(&client, "id = ?", id).Error; err != nil {
+	err := tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		return db.
+			Unscoped().
+			Where("deleted_at IS NOT NULL").
+			First(&client, "id = ?", id).Error
+	})
+	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -92,3 +282,61 @@ func (c *clientRepository) GetClientByID(ctx context.Context, id string) (*model
 
 	return &client, nil
 }
+
+func (c *clientRepository) RestoreClient(ctx context.Context, id string) error {
+	return tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		result := db.
+			Unscoped().
+			Model(&models.Client{}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			UpdateColumns(map[string]any{
+				"version":    gorm.Expr("version + 1"),
+				"deleted_at": nil,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return models.ErrClientNotFound
+		}
+
+		return nil
+	})
+}
+
+func (c *clientRepository) PurgeDeletedClients(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+
+	err := tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		result := db.
+			Unscoped().
+			Where("deleted_at < ?", before).
+			Delete(&models.Client{})
+
+		purged = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, nil
+}
+
+func cursorValue(field string, value string) (any, error) {
+	if field != models.ClientSortCreatedAt {
+		return value, nil
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, models.NewValidationError("cursor", "is invalid")
+	}
+
+	return createdAt, nil
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -0,0 +1,264 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientRepository_GetClientsWithContact(t *testing.T) {
+	ctx := pkgs.WithTenant(context.Background(), "tenant-a")
+	createdAt := time.Date(2025, 4, 20, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should return the first page excluding deleted clients", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &clientRepository{db: db}
+
+		expectTenantTx(mock, "tenant-a")
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "clients" WHERE tenant_id = $1 AND "clients"."deleted_at" IS NULL ORDER BY created_at ASC, id ASC LIMIT $2`)).
+			WithArgs("tenant-a", 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at"}).
+				AddRow("client-1", "Ana", createdAt).
+				AddRow("client-2", "Bruno", createdAt).
+				AddRow("client-3", "Caio", createdAt))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "contacts" WHERE "contacts"."client_id" IN ($1,$2,$3) AND "contacts"."deleted_at" IS NULL`)).
+			WithArgs("client-1", "client-2", "client-3").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "client_id", "phone", "email"}))
+		mock.ExpectCommit()
+
+		clients, hasMore, err := repo.GetClientsWithContact(ctx, models.ClientListOptions{
+			Limit: 2,
+			Sort:  models.ClientSortCreatedAt,
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, hasMore)
+		assert.Len(t, clients, 2)
+		assert.Equal(t, "client-2", clients[1].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should seek after the cursor with filters applied", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &clientRepository{db: db}
+		createdFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		createdTo := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		expectTenantTx(mock, "tenant-a")
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "clients" WHERE name LIKE $1 AND created_at >= $2 AND created_at < $3 AND (name, id) > ($4, $5) AND tenant_id = $6 AND "clients"."deleted_at" IS NULL ORDER BY name ASC, id ASC LIMIT $7`)).
+			WithArgs(`Ga\_b%`, createdFrom, createdTo, "Gabriel", "client-1", "tenant-a", 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at"}).
+				AddRow("client-2", "Gabriela", createdAt))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "contacts" WHERE "contacts"."client_id" = $1 AND "contacts"."deleted_at" IS NULL`)).
+			WithArgs("client-2").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "client_id", "phone", "email"}))
+		mock.ExpectCommit()
+
+		clients, hasMore, err := repo.GetClientsWithContact(ctx, models.ClientListOptions{
+			Limit:       2,
+			Sort:        models.ClientSortName,
+			Cursor:      &models.Cursor{Sort: models.ClientSortName, Value: "Gabriel", ID: "client-1"},
+			NamePrefix:  "Ga_b",
+			CreatedFrom: createdFrom,
+			CreatedTo:   createdTo,
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, hasMore)
+		assert.Len(t, clients, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should seek backwards in reverse order and return the page in the requested order", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &clientRepository{db: db}
+		cursorAt := createdAt.Add(time.Hour)
+
+		expectTenantTx(mock, "tenant-a")
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "clients" WHERE (created_at, id) > ($1, $2) AND tenant_id = $3 AND "clients"."deleted_at" IS NULL ORDER BY created_at ASC, id ASC LIMIT $4`)).
+			WithArgs(cursorAt, "client-3", "tenant-a", 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at"}).
+				AddRow("client-4", "Davi", createdAt.Add(2*time.Hour)).
+				AddRow("client-5", "Eva", createdAt.Add(3*time.Hour)).
+				AddRow("client-6", "Fabio", createdAt.Add(4*time.Hour)))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "contacts" WHERE "contacts"."client_id" IN ($1,$2,$3) AND "contacts"."deleted_at" IS NULL`)).
+			WithArgs("client-4", "client-5", "client-6").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "client_id", "phone", "email"}))
+		mock.ExpectCommit()
+
+		clients, hasMore, err := repo.GetClientsWithContact(ctx, models.ClientListOptions{
+			Limit: 2,
+			Sort:  "-" + models.ClientSortCreatedAt,
+			Cursor: &models.Cursor{
+				Sort:     "-" + models.ClientSortCreatedAt,
+				Value:    cursorAt.Format(time.RFC3339Nano),
+				ID:       "client-3",
+				Backward: true,
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, hasMore)
+		assert.Equal(t, []string{"client-5", "client-4"}, []string{clients[0].ID, clients[1].ID})
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should restrict the page to the given client ids", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &clientRepository{db: db}
+
+		expectTenantTx(mock, "tenant-a")
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "clients" WHERE id IN ($1,$2) AND tenant_id = $3 AND "clients"."deleted_at" IS NULL ORDER BY created_at ASC, id ASC LIMIT $4`)).
+			WithArgs("client-1", "client-2", "tenant-a", 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at"}).
+				AddRow("client-1", "Ana", createdAt))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "contacts" WHERE "contacts"."client_id" = $1 AND "contacts"."deleted_at" IS NULL`)).
+			WithArgs("client-1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "client_id", "phone", "email"}))
+		mock.ExpectCommit()
+
+		clients, hasMore, err := repo.GetClientsWithContact(ctx, models.ClientListOptions{
+			Limit:     2,
+			Sort:      models.ClientSortCreatedAt,
+			ClientIDs: []string{"client-1", "client-2"},
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, hasMore)
+		assert.Len(t, clients, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should reject unsupported sort fields", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &clientRepository{db: db}
+
+		clients, hasMore, err := repo.GetClientsWithContact(ctx, models.ClientListOptions{
+			Limit: 2,
+			Sort:  "id; DROP TABLE clients",
+		})
+
+		assert.Error(t, err)
+		assert.False(t, hasMore)
+		assert.Nil(t, clients)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestClientRepository_UpdateClient(t *testing.T) {
+	ctx := pkgs.WithTenant(context.Background(), "tenant-a")
+
+	t.Run("should update the client and increment its version", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &clientRepository{db: db}
+
+		expectTenantTx(mock, "tenant-a")
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "clients" SET "name"=$1,"updated_at"=$2,"version"=version + 1 WHERE (id = $3 AND version = $4) AND tenant_id = $5 AND "clients"."deleted_at" IS NULL`)).
+			WithArgs("Caio", sqlmock.AnyArg(), "client-123", int64(3), "tenant-a").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		client := &models.Client{ID: "client-123", Name: "Caio", Version: 3}
+		err := repo.UpdateClient(ctx, client)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), client.Version)
+		assert.True(t, client.UpdatedAt.Valid)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should fail the precondition when the version changed", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &clientRepository{db: db}
+
+		expectTenantTx(mock, "tenant-a")
+		mock.ExpectExec(`UPDATE "clients"`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		client := &models.Client{ID: "client-123", Name: "Caio", Version: 3}
+		err := repo.UpdateClient(ctx, client)
+
+		assert.ErrorIs(t, err, models.ErrPreconditionFailed)
+		assert.Equal(t, int64(3), client.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestClientRepository_DeleteClient(t *testing.T) {
+	ctx := pkgs.WithTenant(context.Background(), "tenant-a")
+
+	t.Run("should not soft delete a client twice", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &clientRepository{db: db}
+		deletedAt := time.Now().UTC()
+
+		expectTenantTx(mock, "tenant-a")
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "clients" SET "deleted_at"=$1,"version"=version + 1 WHERE (id = $2 AND version = $3) AND tenant_id = $4 AND "clients"."deleted_at" IS NULL`)).
+			WithArgs(deletedAt, "client-123", int64(1), "tenant-a").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.DeleteClient(ctx, "client-123", 1, deletedAt)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.ErrorIs(t, err, models.ErrPreconditionFailed)
+	})
+}
+
+func TestClientRepository_GetClientByID(t *testing.T) {
+	t.Run("should only find clients of the tenant in the context", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &clientRepository{db: db}
+		ctx := pkgs.WithTenant(context.Background(), "tenant-b")
+
+		expectTenantTx(mock, "tenant-b")
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "clients" WHERE id = $1 AND tenant_id = $2 AND "clients"."deleted_at" IS NULL ORDER BY "clients"."id" LIMIT $3`)).
+			WithArgs("client-123", "tenant-b", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		client, err := repo.GetClientByID(ctx, "client-123")
+
+		assert.NoError(t, err)
+		assert.Nil(t, client)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should require a tenant in the context", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &clientRepository{db: db}
+
+		client, err := repo.GetClientByID(context.Background(), "client-123")
+
+		assert.Nil(t, client)
+		assert.ErrorIs(t, err, models.ErrTenantRequired)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestClientRepository_PurgeDeletedClients(t *testing.T) {
+	t.Run("should reach every tenant when running for all tenants", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &clientRepository{db: db}
+		ctx := pkgs.WithTenant(context.Background(), models.AllTenants)
+		before := time.Date(2025, 4, 20, 10, 0, 0, 0, time.UTC)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "clients" WHERE deleted_at < $1`)).
+			WithArgs(before).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		purged, err := repo.PurgeDeletedClients(ctx, before)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), purged)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
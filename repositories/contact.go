@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -15,6 +16,13 @@ type ContactRepository interface {
 	CreateContact(ctx context.Context, contact *models.Contact) error
 	GetContactsByClientID(ctx context.Context, clientID string) ([]*models.Contact, error)
 	CreateContacts(ctx context.Context, contacts []*models.Contact) error
+	DeleteContactsByClientID(ctx context.Context, clientID string, deletedAt time.Time) error
+	RestoreContactsByClientID(ctx context.Context, clientID string, deletedAt time.Time) error
+	PurgeDeletedContacts(ctx context.Context, before time.Time) (int64, error)
+	GetContactByID(ctx context.Context, id string) (*models.Contact, error)
+	UpdateContact(ctx context.Context, contact *models.Contact) error
+	DeleteContact(ctx context.Context, id string, version int64) error
+	SearchContacts(ctx context.Context, email string, phone string) ([]*models.Contact, error)
 }
 
 type contactRepository struct {
@@ -41,19 +49,23 @@ func (c *contactRepository) CreateContact(ctx context.Context, contact *models.C
 	}
 
 	contact.ID = id.String()
+	contact.TenantID = pkgs.TenantFromContext(ctx)
+	contact.Version = 1
 	contact.CreatedAt = time.Now().UTC()
+	normalizeContact(contact)
 
-	if err := c.db.WithContext(ctx).Create(contact).Error; err != nil {
-		return err
-	}
-
-	return nil
+	return tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		return db.Create(contact).Error
+	})
 }
 
 func (c *contactRepository) GetContactsByClientID(ctx context.Context, clientID string) ([]*models.Contact, error) {
 	var contacts []*models.Contact
 
-	if err := c.db.WithContext(ctx).Where("client_id = ?", clientID).Find(&contacts).Error; err != nil {
+	err := tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		return db.Where("client_id = ?", clientID).Find(&contacts).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -62,6 +74,7 @@ func (c *contactRepository) GetContactsByClientID(ctx context.Context, clientID
 
 func (c *contactRepository) CreateContacts(ctx context.Context, contacts []*models.Contact) error {
 	now := time.Now().UTC()
+	tenant := pkgs.TenantFromContext(ctx)
 	for _, contact := range contacts {
 		id, err := uuid.NewRandom()
 		if err != nil {
@@ -69,12 +82,167 @@ func (c *contactRepository) CreateContacts(ctx context.Context, contacts []*mode
 		}
 
 		contact.ID = id.String()
+		contact.TenantID = tenant
+		contact.Version = 1
 		contact.CreatedAt = now
+		normalizeContact(contact)
 	}
 
-	if err := c.db.WithContext(ctx).Create(contacts).Error; err != nil {
+	return tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		return db.Create(contacts).Error
+	})
+}
+
+func (c *contactRepository) DeleteContactsByClientID(ctx context.Context, clientID string, deletedAt time.Time) error {
+	return tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		return db.
+			Model(&models.Contact{}).
+			Where("client_id = ?", clientID).
+			UpdateColumn("deleted_at", deletedAt).Error
+	})
+}
+
+// RestoreContactsByClientID restaura apenas os contatos removidos junto com o cliente,
+// identificados pelo mesmo instante de remoção
+func (c *contactRepository) RestoreContactsByClientID(ctx context.Context, clientID string, deletedAt time.Time) error {
+	return tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		return db.
+			Unscoped().
+			Model(&models.Contact{}).
+			Where("client_id = ? AND deleted_at = ?", clientID, deletedAt).
+			UpdateColumn("deleted_at", nil).Error
+	})
+}
+
+func (c *contactRepository) PurgeDeletedContacts(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+
+	err := tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		result := db.
+			Unscoped().
+			Where("deleted_at < ?", before).
+			Delete(&models.Contact{})
+
+		purged = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, nil
+}
+
+func (c *contactRepository) GetContactByID(ctx context.Context, id string) (*models.Contact, error) {
+	var contact models.Contact
+
+	err := tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		return db.First(&contact, "id = ?", id).Error
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &contact, nil
+}
+
+// UpdateContact grava o contato somente se ele ainda estiver na versão lida, incrementando-a.
+// Retorna models.ErrPreconditionFailed se outra requisição alterou o contato antes.
+func (c *contactRepository) UpdateContact(ctx context.Context, contact *models.Contact) error {
+	updatedAt := sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	normalizeContact(contact)
+
+	err := tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		result := db.
+			Model(&models.Contact{}).
+			Where("id = ? AND version = ?", contact.ID, contact.Version).
+			UpdateColumns(map[string]any{
+				"phone":            contact.Phone,
+				"email":            contact.Email,
+				"normalized_phone": contact.NormalizedPhone,
+				"normalized_email": contact.NormalizedEmail,
+				"client_id":        contact.ClientID,
+				"version":          gorm.Expr("version + 1"),
+				"updated_at":       updatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return models.ErrPreconditionFailed
+		}
+
+		return nil
+	})
+	if err != nil {
 		return err
 	}
 
+	contact.Version++
+	contact.UpdatedAt = updatedAt
+
 	return nil
 }
+
+func (c *contactRepository) DeleteContact(ctx context.Context, id string, version int64) error {
+	return tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		result := db.
+			Model(&models.Contact{}).
+			Where("id = ? AND version = ?", id, version).
+			UpdateColumns(map[string]any{
+				"version":    gorm.Expr("version + 1"),
+				"deleted_at": time.Now().UTC(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return models.ErrPreconditionFailed
+		}
+
+		return nil
+	})
+}
+
+// SearchContacts busca os contatos pelo email e/ou telefone já normalizados, de modo que
+// "+55 21 99999-9999" e "+5521999999999" encontrem o mesmo registro.
+// Quando os dois são informados, o contato precisa corresponder a ambos.
+func (c *contactRepository) SearchContacts(ctx context.Context, email string, phone string) ([]*models.Contact, error) {
+	email = pkgs.NormalizeEmail(email)
+	phone = pkgs.NormalizePhone(phone)
+
+	if email == "" && phone == "" {
+		return []*models.Contact{}, nil
+	}
+
+	var contacts []*models.Contact
+	err := tenantConn(ctx, c.db, func(db *gorm.DB) error {
+		query := db
+
+		if email != "" {
+			query = query.Where("normalized_email = ?", email)
+		}
+
+		if phone != "" {
+			query = query.Where("normalized_phone = ?", phone)
+		}
+
+		return query.Order("created_at ASC, id ASC").Find(&contacts).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return contacts, nil
+}
+
+func normalizeContact(contact *models.Contact) {
+	contact.NormalizedPhone = pkgs.NormalizePhone(contact.Phone)
+	contact.NormalizedEmail = pkgs.NormalizeEmail(contact.Email)
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContactRepository_SearchContacts(t *testing.T) {
+	ctx := pkgs.WithTenant(context.Background(), "tenant-a")
+
+	t.Run("should match the normalized email and phone", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &contactRepository{db: db}
+
+		expectTenantTx(mock, "tenant-a")
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "contacts" WHERE normalized_email = $1 AND normalized_phone = $2 AND tenant_id = $3 AND "contacts"."deleted_at" IS NULL ORDER BY created_at ASC, id ASC`)).
+			WithArgs("gabriel@gmail.com", "+5521999999999", "tenant-a").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "client_id", "phone", "email"}).
+				AddRow("contact-1", "client-1", "+5521999999999", "gabriel@gmail.com"))
+		mock.ExpectCommit()
+
+		contacts, err := repo.SearchContacts(ctx, " Gabriel@Gmail.com", "+55 21 99999-9999")
+
+		assert.NoError(t, err)
+		assert.Len(t, contacts, 1)
+		assert.Equal(t, "client-1", contacts[0].ClientID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should filter only by phone when email is empty", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &contactRepository{db: db}
+
+		expectTenantTx(mock, "tenant-a")
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "contacts" WHERE normalized_phone = $1 AND tenant_id = $2 AND "contacts"."deleted_at" IS NULL ORDER BY created_at ASC, id ASC`)).
+			WithArgs("+5521999999999", "tenant-a").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "client_id"}))
+		mock.ExpectCommit()
+
+		contacts, err := repo.SearchContacts(ctx, "", "+55 (21) 99999-9999")
+
+		assert.NoError(t, err)
+		assert.Empty(t, contacts)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should not query when nothing is left after normalization", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &contactRepository{db: db}
+
+		contacts, err := repo.SearchContacts(ctx, "  ", "-")
+
+		assert.NoError(t, err)
+		assert.Empty(t, contacts)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestContactRepository_CreateContact(t *testing.T) {
+	ctx := pkgs.WithTenant(context.Background(), "tenant-a")
+
+	t.Run("should store the normalized email and phone in the tenant of the context", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &contactRepository{db: db}
+
+		expectTenantTx(mock, "tenant-a")
+		mock.ExpectQuery(`INSERT INTO "contacts"`).
+			WithArgs(sqlmock.AnyArg(), "+55 21 99999-9999", "Gabriel@Gmail.com", "+5521999999999", "gabriel@gmail.com", int64(1), "tenant-a", "client-1", sqlmock.AnyArg(), nil).
+			WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(nil))
+		mock.ExpectCommit()
+
+		contact := &models.Contact{Phone: "+55 21 99999-9999", Email: "Gabriel@Gmail.com", ClientID: "client-1"}
+		err := repo.CreateContact(ctx, contact)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "+5521999999999", contact.NormalizedPhone)
+		assert.Equal(t, "gabriel@gmail.com", contact.NormalizedEmail)
+		assert.Equal(t, "tenant-a", contact.TenantID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"gorm.io/gorm"
+)
+
+type HealthRepository interface {
+	HealthCheck(ctx context.Context) error
+}
+
+type healthRepository struct {
+	di *pkgs.Di
+	db *gorm.DB
+}
+
+func NewHealthRepository(di *pkgs.Di) (HealthRepository, error) {
+	db, err := pkgs.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke gorm.DB: %w", err)
+	}
+
+	return &healthRepository{
+		di: di,
+		db: db,
+	}, nil
+}
+
+// HealthCheck executa uma consulta trivial em vez de apenas testar a conexão, para que um banco
+// que aceita conexões mas não responde a comandos também seja considerado indisponível
+func (h *healthRepository) HealthCheck(ctx context.Context) error {
+	return h.db.WithContext(ctx).Exec("SELECT 1").Error
+}
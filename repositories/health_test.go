@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthRepository_HealthCheck(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should run a trivial query", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &healthRepository{db: db}
+
+		mock.ExpectExec(regexp.QuoteMeta("SELECT 1")).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.HealthCheck(ctx)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should return the database error", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &healthRepository{db: db}
+
+		mock.ExpectExec(regexp.QuoteMeta("SELECT 1")).
+			WillReturnError(errors.New("connection refused"))
+
+		err := repo.HealthCheck(ctx)
+
+		assert.EqualError(t, err, "connection refused")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
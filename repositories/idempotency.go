@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyRepository interface {
+	CreateKey(ctx context.Context, key *models.IdempotencyKey) (bool, error)
+	GetKey(ctx context.Context, scope string, key string) (*models.IdempotencyKey, error)
+	CompleteKey(ctx context.Context, key *models.IdempotencyKey) error
+	PurgeExpiredKeys(ctx context.Context, before time.Time) (int64, error)
+}
+
+type idempotencyRepository struct {
+	di *pkgs.Di
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(di *pkgs.Di) (IdempotencyRepository, error) {
+	db, err := pkgs.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke gorm.DB: %w", err)
+	}
+
+	return &idempotencyRepository{
+		di: di,
+		db: db,
+	}, nil
+}
+
+// CreateKey reserva a chave para uma nova requisição. Uma chave já expirada é reaproveitada;
+// retorna false quando a chave ainda está em uso por outra requisição.
+func (i *idempotencyRepository) CreateKey(ctx context.Context, key *models.IdempotencyKey) (bool, error) {
+	result := conn(ctx, i.db).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "scope"}, {Name: "key"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"fingerprint", "status_code", "content_type", "response_body", "created_at", "expires_at",
+			}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Lt{Column: clause.Column{Table: "idempotency_keys", Name: "expires_at"}, Value: key.CreatedAt},
+			}},
+		}).
+		Create(key)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (i *idempotencyRepository) GetKey(ctx context.Context, scope string, key string) (*models.IdempotencyKey, error) {
+	var idempotencyKey models.IdempotencyKey
+
+	if err := conn(ctx, i.db).First(&idempotencyKey, "scope = ? AND key = ?", scope, key).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &idempotencyKey, nil
+}
+
+func (i *idempotencyRepository) CompleteKey(ctx context.Context, key *models.IdempotencyKey) error {
+	err := conn(ctx, i.db).
+		Model(&models.IdempotencyKey{}).
+		Where("scope = ? AND key = ?", key.Scope, key.Key).
+		Updates(map[string]any{
+			"status_code":   key.StatusCode,
+			"content_type":  key.ContentType,
+			"response_body": key.ResponseBody,
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *idempotencyRepository) PurgeExpiredKeys(ctx context.Context, before time.Time) (int64, error) {
+	result := conn(ctx, i.db).
+		Where("expires_at < ?", before).
+		Delete(&models.IdempotencyKey{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
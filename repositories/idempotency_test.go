@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyRepository_CreateKey(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 4, 20, 10, 0, 0, 0, time.UTC)
+
+	newKey := func() *models.IdempotencyKey {
+		return &models.IdempotencyKey{
+			Scope:       "POST /clients",
+			Key:         "key-1",
+			Fingerprint: "fp",
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(24 * time.Hour),
+		}
+	}
+
+	t.Run("should reserve the key or take over an expired one", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &idempotencyRepository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`ON CONFLICT ("scope","key") DO UPDATE SET "fingerprint"="excluded"."fingerprint","status_code"="excluded"."status_code","content_type"="excluded"."content_type","response_body"="excluded"."response_body","created_at"="excluded"."created_at","expires_at"="excluded"."expires_at" WHERE "idempotency_keys"."expires_at" < $`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		created, err := repo.CreateKey(ctx, newKey())
+
+		assert.NoError(t, err)
+		assert.True(t, created)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should not reserve a key still in use", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &idempotencyRepository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "idempotency_keys"`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		created, err := repo.CreateKey(ctx, newKey())
+
+		assert.NoError(t, err)
+		assert.False(t, created)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
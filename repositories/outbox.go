@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// outboxRelayLockID identifica o advisory lock que garante um único relay publicando por vez
+const outboxRelayLockID = 7_240_613
+
+type OutboxRepository interface {
+	CreateEvents(ctx context.Context, events []*models.OutboxEvent) error
+	LockRelay(ctx context.Context) (bool, error)
+	GetPendingEvents(ctx context.Context, limit int) ([]*models.OutboxEvent, error)
+	MarkPublished(ctx context.Context, ids []string, publishedAt time.Time) error
+	MarkFailed(ctx context.Context, id string, reason string) error
+	MarkDead(ctx context.Context, id string, reason string, deadAt time.Time) error
+	PurgeFinishedEvents(ctx context.Context, before time.Time) (int64, error)
+}
+
+type outboxRepository struct {
+	di *pkgs.Di
+	db *gorm.DB
+}
+
+func NewOutboxRepository(di *pkgs.Di) (OutboxRepository, error) {
+	db, err := pkgs.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke gorm.DB: %w", err)
+	}
+
+	return &outboxRepository{
+		di: di,
+		db: db,
+	}, nil
+}
+
+func (o *outboxRepository) CreateEvents(ctx context.Context, events []*models.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	for _, event := range events {
+		id, err := uuid.NewRandom()
+		if err != nil {
+			return fmt.Errorf("generate uuid: %w", err)
+		}
+
+		event.ID = id.String()
+		event.TenantID = pkgs.TenantFromContext(ctx)
+		event.CreatedAt = now
+	}
+
+	return tenantConn(ctx, o.db, func(db *gorm.DB) error {
+		return db.Create(&events).Error
+	})
+}
+
+// LockRelay tenta obter o lock do relay até o fim da transação atual. Retorna false quando
+// outra instância já está publicando; deve ser chamado dentro de uma unidade de trabalho.
+func (o *outboxRepository) LockRelay(ctx context.Context) (bool, error) {
+	var locked bool
+
+	if err := conn(ctx, o.db).Raw("SELECT pg_try_advisory_xact_lock(?)", outboxRelayLockID).Scan(&locked).Error; err != nil {
+		return false, err
+	}
+
+	return locked, nil
+}
+
+// GetPendingEvents retorna os eventos ainda não publicados nem descartados, na ordem em que
+// foram gravados
+func (o *outboxRepository) GetPendingEvents(ctx context.Context, limit int) ([]*models.OutboxEvent, error) {
+	var events []*models.OutboxEvent
+
+	err := tenantConn(ctx, o.db, func(db *gorm.DB) error {
+		return db.
+			Where("published_at IS NULL AND dead_at IS NULL").
+			Order("sequence ASC").
+			Limit(limit).
+			Find(&events).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (o *outboxRepository) MarkPublished(ctx context.Context, ids []string, publishedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return tenantConn(ctx, o.db, func(db *gorm.DB) error {
+		return db.
+			Model(&models.OutboxEvent{}).
+			Where("id IN ?", ids).
+			UpdateColumn("published_at", publishedAt).Error
+	})
+}
+
+func (o *outboxRepository) MarkFailed(ctx context.Context, id string, reason string) error {
+	return tenantConn(ctx, o.db, func(db *gorm.DB) error {
+		return db.
+			Model(&models.OutboxEvent{}).
+			Where("id = ?", id).
+			UpdateColumns(map[string]any{
+				"attempts":   gorm.Expr("attempts + 1"),
+				"last_error": reason,
+			}).Error
+	})
+}
+
+// MarkDead registra a última falha e tira o evento da fila de publicação. O evento continua no
+// outbox, fora do expurgo, para ser investigado.
+func (o *outboxRepository) MarkDead(ctx context.Context, id string, reason string, deadAt time.Time) error {
+	return tenantConn(ctx, o.db, func(db *gorm.DB) error {
+		return db.
+			Model(&models.OutboxEvent{}).
+			Where("id = ?", id).
+			UpdateColumns(map[string]any{
+				"attempts":   gorm.Expr("attempts + 1"),
+				"last_error": reason,
+				"dead_at":    deadAt,
+			}).Error
+	})
+}
+
+// PurgeFinishedEvents remove os eventos publicados ou enviados para a lista de dead letters antes
+// de before. Os eventos carregam os dados dos contatos, que não devem sobreviver à retenção.
+func (o *outboxRepository) PurgeFinishedEvents(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := tenantConn(ctx, o.db, func(db *gorm.DB) error {
+		result := db.
+			Where("published_at < ? OR dead_at < ?", before, before).
+			Delete(&models.OutboxEvent{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, nil
+}
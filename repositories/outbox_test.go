@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxRepository_CreateEvents(t *testing.T) {
+	ctx := pkgs.WithTenant(context.Background(), "tenant-a")
+
+	t.Run("should insert the events and read back their sequence", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &outboxRepository{db: db}
+
+		events := []*models.OutboxEvent{
+			{Type: models.EventClientCreated, AggregateID: "client-123", Payload: []byte(`{}`)},
+			{Type: models.EventContactAdded, AggregateID: "client-123", Payload: []byte(`{}`)},
+		}
+
+		expectTenantTx(mock, "tenant-a")
+		mock.ExpectQuery(`INSERT INTO "outbox_events" .* RETURNING "sequence"`).
+			WillReturnRows(sqlmock.NewRows([]string{"sequence", "attempts", "last_error"}).
+				AddRow(1, 0, "").
+				AddRow(2, 0, ""))
+		mock.ExpectCommit()
+
+		err := repo.CreateEvents(ctx, events)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, events[0].ID)
+		assert.NotEqual(t, events[0].ID, events[1].ID)
+		assert.Equal(t, int64(2), events[1].Sequence)
+		assert.Equal(t, "tenant-a", events[0].TenantID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should do nothing without events", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &outboxRepository{db: db}
+
+		err := repo.CreateEvents(ctx, nil)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOutboxRepository_LockRelay(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should report whether the relay lock was taken", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &outboxRepository{db: db}
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT pg_try_advisory_xact_lock($1)`)).
+			WithArgs(outboxRelayLockID).
+			WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(false))
+
+		locked, err := repo.LockRelay(ctx)
+
+		assert.NoError(t, err)
+		assert.False(t, locked)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOutboxRepository_GetPendingEvents(t *testing.T) {
+	ctx := pkgs.WithTenant(context.Background(), models.AllTenants)
+
+	t.Run("should return unpublished events in sequence order", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &outboxRepository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "outbox_events" WHERE published_at IS NULL AND dead_at IS NULL ORDER BY sequence ASC LIMIT $1`)).
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "sequence"}).AddRow("event-1", 1).AddRow("event-2", 2))
+		mock.ExpectCommit()
+
+		events, err := repo.GetPendingEvents(ctx, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, events, 2)
+		assert.Equal(t, "event-1", events[0].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOutboxRepository_MarkPublished(t *testing.T) {
+	ctx := pkgs.WithTenant(context.Background(), models.AllTenants)
+	publishedAt := time.Date(2025, 4, 20, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should set published_at on the events", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &outboxRepository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "outbox_events" SET "published_at"=$1 WHERE id IN ($2,$3)`)).
+			WithArgs(publishedAt, "event-1", "event-2").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err := repo.MarkPublished(ctx, []string{"event-1", "event-2"}, publishedAt)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOutboxRepository_MarkDead(t *testing.T) {
+	ctx := pkgs.WithTenant(context.Background(), models.AllTenants)
+	deadAt := time.Date(2025, 4, 20, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should count the attempt and set dead_at on the event", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &outboxRepository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "outbox_events" SET "attempts"=attempts + 1,"dead_at"=$1,"last_error"=$2 WHERE id = $3`)).
+			WithArgs(deadAt, "payload rejected", "event-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.MarkDead(ctx, "event-1", "payload rejected", deadAt)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOutboxRepository_PurgeFinishedEvents(t *testing.T) {
+	ctx := pkgs.WithTenant(context.Background(), models.AllTenants)
+	before := time.Date(2025, 4, 20, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should remove the published and dead events finished before the cutoff", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &outboxRepository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "outbox_events" WHERE published_at < $1 OR dead_at < $2`)).
+			WithArgs(before, before).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		purged, err := repo.PurgeFinishedEvents(ctx, before)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), purged)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"gorm.io/gorm"
+)
+
+// refillExpression calcula os tokens do balde no instante @now, limitados a @burst
+const refillExpression = `LEAST(CAST(@burst AS double precision), b.tokens + GREATEST(EXTRACT(EPOCH FROM (CAST(@now AS timestamptz) - b.updated_at))::double precision, 0) * CAST(@rate AS double precision))`
+
+// takeTokenQuery lê e atualiza o balde num único comando, de modo que requisições simultâneas
+// em instâncias diferentes não consumam o mesmo token
+var takeTokenQuery = fmt.Sprintf(`
+	INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at)
+	VALUES (@key, CAST(@burst AS double precision) - 1, CAST(@burst AS double precision) >= 1, @now)
+	ON CONFLICT (key) DO UPDATE SET
+		tokens = CASE WHEN %[1]s >= 1 THEN %[1]s - 1 ELSE %[1]s END,
+		allowed = %[1]s >= 1,
+		updated_at = GREATEST(b.updated_at, CAST(@now AS timestamptz))
+	RETURNING key, tokens, allowed, updated_at
+`, refillExpression)
+
+type RateLimitRepository interface {
+	TakeToken(ctx context.Context, key string, policy models.RateLimitPolicy, now time.Time) (*models.RateLimitBucket, error)
+	GetBucket(ctx context.Context, key string) (*models.RateLimitBucket, error)
+	PurgeStaleBuckets(ctx context.Context, before time.Time) (int64, error)
+}
+
+type rateLimitRepository struct {
+	di *pkgs.Di
+	db *gorm.DB
+}
+
+func NewRateLimitRepository(di *pkgs.Di) (RateLimitRepository, error) {
+	db, err := pkgs.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke gorm.DB: %w", err)
+	}
+
+	return &rateLimitRepository{
+		di: di,
+		db: db,
+	}, nil
+}
+
+// TakeToken reabastece o balde pelo tempo decorrido e consome um token se houver. O balde
+// devolvido informa em Allowed se o token foi consumido. Um balde novo começa cheio.
+func (r *rateLimitRepository) TakeToken(ctx context.Context, key string, policy models.RateLimitPolicy, now time.Time) (*models.RateLimitBucket, error) {
+	var bucket models.RateLimitBucket
+
+	err := conn(ctx, r.db).
+		Raw(takeTokenQuery, map[string]any{
+			"key":   key,
+			"burst": float64(policy.Burst),
+			"rate":  policy.Rate(),
+			"now":   now,
+		}).
+		Scan(&bucket).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &bucket, nil
+}
+
+// GetBucket retorna o balde sem alterá-lo, ou nil se ele ainda não existe. Os tokens são os da
+// última atualização, sem o reabastecimento desde updated_at.
+func (r *rateLimitRepository) GetBucket(ctx context.Context, key string) (*models.RateLimitBucket, error) {
+	var bucket models.RateLimitBucket
+
+	if err := conn(ctx, r.db).Where("key = ?", key).First(&bucket).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &bucket, nil
+}
+
+// PurgeStaleBuckets remove os baldes sem requisições desde before. Um balde removido equivale
+// a um balde cheio, então before só precisa ser anterior ao tempo de reabastecimento.
+func (r *rateLimitRepository) PurgeStaleBuckets(ctx context.Context, before time.Time) (int64, error) {
+	result := conn(ctx, r.db).
+		Where("updated_at < ?", before).
+		Delete(&models.RateLimitBucket{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitRepository_TakeToken(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := models.RateLimitPolicy{Burst: 10, PerMinute: 120}
+
+	t.Run("should upsert the bucket in a single statement and return its state", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &rateLimitRepository{db: db}
+
+		mock.ExpectQuery(`INSERT INTO rate_limit_buckets AS b .* ON CONFLICT \(key\) DO UPDATE SET .* RETURNING key, tokens, allowed, updated_at`).
+			WithArgs("read:api-key:key-1", float64(10), float64(10), now,
+				float64(10), now, float64(2), float64(10), now, float64(2), float64(10), now, float64(2),
+				float64(10), now, float64(2), now).
+			WillReturnRows(sqlmock.NewRows([]string{"key", "tokens", "allowed", "updated_at"}).
+				AddRow("read:api-key:key-1", 4.5, true, now))
+
+		bucket, err := repo.TakeToken(ctx, "read:api-key:key-1", policy, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "read:api-key:key-1", bucket.Key)
+		assert.Equal(t, 4.5, bucket.Tokens)
+		assert.True(t, bucket.Allowed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should return the database error", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &rateLimitRepository{db: db}
+
+		mock.ExpectQuery(`INSERT INTO rate_limit_buckets`).WillReturnError(assert.AnError)
+
+		bucket, err := repo.TakeToken(ctx, "read:ip:10.0.0.1", policy, now)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, bucket)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRateLimitRepository_GetBucket(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should read the bucket without updating it", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &rateLimitRepository{db: db}
+
+		mock.ExpectQuery(`SELECT \* FROM "rate_limit_buckets" WHERE key = \$1 ORDER BY "rate_limit_buckets"."key" LIMIT \$2`).
+			WithArgs("anon:ip:203.0.113.10", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"key", "tokens", "allowed", "updated_at"}).
+				AddRow("anon:ip:203.0.113.10", 3.5, true, now))
+
+		bucket, err := repo.GetBucket(ctx, "anon:ip:203.0.113.10")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3.5, bucket.Tokens)
+		assert.Equal(t, now, bucket.UpdatedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should return nil when the bucket does not exist", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &rateLimitRepository{db: db}
+
+		mock.ExpectQuery(`SELECT \* FROM "rate_limit_buckets"`).
+			WillReturnRows(sqlmock.NewRows([]string{"key", "tokens", "allowed", "updated_at"}))
+
+		bucket, err := repo.GetBucket(ctx, "anon:ip:203.0.113.10")
+
+		assert.NoError(t, err)
+		assert.Nil(t, bucket)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRateLimitRepository_PurgeStaleBuckets(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should delete the buckets idle since before", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &rateLimitRepository{db: db}
+		before := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "rate_limit_buckets" WHERE updated_at < \$1`).
+			WithArgs(before).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		purged, err := repo.PurgeStaleBuckets(ctx, before)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), purged)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
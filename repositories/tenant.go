@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"gorm.io/gorm"
+)
+
+// tenantConn executa fn com a conexão filtrada pelo tenant do contexto. As consultas rodam
+// sempre numa transação em que o Postgres conhece o tenant, de modo que as políticas de
+// row-level security barram o acesso a outros tenants mesmo que um filtro seja esquecido.
+// Dentro de uma UnitOfWork, reaproveita a transação já aberta.
+func tenantConn(ctx context.Context, db *gorm.DB, fn func(db *gorm.DB) error) error {
+	tenant := pkgs.TenantFromContext(ctx)
+	if tenant == "" {
+		return models.ErrTenantRequired
+	}
+
+	run := func(ctx context.Context) error {
+		return fn(conn(ctx, db).Scopes(tenantScope(tenant)))
+	}
+
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return run(ctx)
+	}
+
+	return transaction(ctx, db, run)
+}
+
+// tenantScope restringe a consulta às linhas do tenant
+func tenantScope(tenant string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if tenant == models.AllTenants {
+			return db
+		}
+
+		return db.Where("tenant_id = ?", tenant)
+	}
+}
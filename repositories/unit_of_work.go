@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"gorm.io/gorm"
+)
+
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+type unitOfWork struct {
+	di *pkgs.Di
+	db *gorm.DB
+}
+
+func NewUnitOfWork(di *pkgs.Di) (UnitOfWork, error) {
+	db, err := pkgs.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke gorm.DB: %w", err)
+	}
+
+	return &unitOfWork{
+		di: di,
+		db: db,
+	}, nil
+}
+
+// Do executa fn dentro de uma transação. Os repositórios chamados com o ctx recebido por fn
+// participam da mesma transação, que sofre rollback se fn retornar erro.
+// Chamadas aninhadas reaproveitam a transação já aberta no contexto.
+func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return transaction(ctx, u.db, fn)
+}
+
+// transaction abre uma transação e, se houver um tenant no contexto, o informa ao Postgres com
+// SET LOCAL para que as políticas de row-level security o apliquem até o fim da transação. As
+// políticas não conhecem models.AllTenants; as rotinas que o usam conectam com um papel que as
+// ignora.
+func transaction(ctx context.Context, db *gorm.DB, fn func(ctx context.Context) error) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if tenant := pkgs.TenantFromContext(ctx); tenant != "" && tenant != models.AllTenants {
+			if err := tx.Exec("SELECT set_config(?, ?, true)", models.TenantSetting, tenant).Error; err != nil {
+				return fmt.Errorf("set tenant: %w", err)
+			}
+		}
+
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn retorna a transação associada ao contexto ou, se não houver, a conexão padrão
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+
+	return db.WithContext(ctx)
+}
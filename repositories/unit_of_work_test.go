@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	return db, mock
+}
+
+// expectTenantTx espera a transação que informa o tenant ao Postgres antes das consultas
+func expectTenantTx(mock sqlmock.Sqlmock, tenant string) {
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT set_config($1, $2, true)`)).
+		WithArgs(models.TenantSetting, tenant).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestUnitOfWork_Do(t *testing.T) {
+	ctx := pkgs.WithTenant(context.Background(), "tenant-a")
+
+	t.Run("should commit client and contacts in a single transaction", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		uow := &unitOfWork{db: db}
+		clr := &clientRepository{db: db}
+		ctr := &contactRepository{db: db}
+
+		expectTenantTx(mock, "tenant-a")
+		mock.ExpectQuery(`INSERT INTO "clients"`).WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(nil))
+		mock.ExpectQuery(`INSERT INTO "contacts"`).WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(nil))
+		mock.ExpectCommit()
+
+		err := uow.Do(ctx, func(ctx context.Context) error {
+			client := &models.Client{Name: "Gabriel"}
+			if err := clr.CreateClient(ctx, client); err != nil {
+				return err
+			}
+
+			return ctr.CreateContacts(ctx, []*models.Contact{
+				{Phone: "+5521999999999", Email: "gabriel@gmail.com", ClientID: client.ID},
+			})
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should rollback the client when creating contacts fails", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		uow := &unitOfWork{db: db}
+		clr := &clientRepository{db: db}
+		ctr := &contactRepository{db: db}
+
+		expectTenantTx(mock, "tenant-a")
+		mock.ExpectQuery(`INSERT INTO "clients"`).WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(nil))
+		mock.ExpectQuery(`INSERT INTO "contacts"`).WillReturnError(errors.New("duplicate key"))
+		mock.ExpectRollback()
+
+		err := uow.Do(ctx, func(ctx context.Context) error {
+			client := &models.Client{Name: "Gabriel"}
+			if err := clr.CreateClient(ctx, client); err != nil {
+				return err
+			}
+
+			return ctr.CreateContacts(ctx, []*models.Contact{
+				{Phone: "+5521999999999", Email: "gabriel@gmail.com", ClientID: client.ID},
+			})
+		})
+
+		assert.EqualError(t, err, "duplicate key")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should join the transaction already in the context", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		uow := &unitOfWork{db: db}
+		clr := &clientRepository{db: db}
+
+		expectTenantTx(mock, "tenant-a")
+		mock.ExpectQuery(`INSERT INTO "clients"`).WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(nil))
+		mock.ExpectRollback()
+
+		err := uow.Do(ctx, func(ctx context.Context) error {
+			return uow.Do(ctx, func(ctx context.Context) error {
+				if err := clr.CreateClient(ctx, &models.Client{Name: "Gabriel"}); err != nil {
+					return err
+				}
+
+				return errors.New("nested failure")
+			})
+		})
+
+		assert.EqualError(t, err, "nested failure")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should run in its own transaction outside a unit of work", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		clr := &clientRepository{db: db}
+
+		expectTenantTx(mock, "tenant-a")
+		mock.ExpectQuery(`INSERT INTO "clients"`).WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(nil))
+		mock.ExpectCommit()
+
+		err := clr.CreateClient(ctx, &models.Client{Name: "Gabriel"})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should not set a tenant when the context has none", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		uow := &unitOfWork{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
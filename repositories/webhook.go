@@ -0,0 +1,291 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	GetSubscriptionByID(ctx context.Context, id string) (*models.WebhookSubscription, error)
+	GetSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error)
+	GetActiveSubscriptions(ctx context.Context, tenantID string, eventType string) ([]*models.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, id string) error
+	CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error
+	ClaimDueDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error)
+	GetDeliveryByID(ctx context.Context, id string) (*models.WebhookDelivery, error)
+	GetDeliveries(ctx context.Context, opts models.WebhookDeliveryListOptions) ([]*models.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	CreateAttempt(ctx context.Context, attempt *models.WebhookAttempt) error
+	PurgeFinishedDeliveries(ctx context.Context, before time.Time) (int64, error)
+}
+
+type webhookRepository struct {
+	di *pkgs.Di
+	db *gorm.DB
+}
+
+func NewWebhookRepository(di *pkgs.Di) (WebhookRepository, error) {
+	db, err := pkgs.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke gorm.DB: %w", err)
+	}
+
+	return &webhookRepository{
+		di: di,
+		db: db,
+	}, nil
+}
+
+func (w *webhookRepository) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return fmt.Errorf("generate uuid: %w", err)
+	}
+
+	subscription.ID = id.String()
+	subscription.TenantID = pkgs.TenantFromContext(ctx)
+	subscription.CreatedAt = time.Now().UTC()
+
+	return tenantConn(ctx, w.db, func(db *gorm.DB) error {
+		return db.Create(subscription).Error
+	})
+}
+
+func (w *webhookRepository) GetSubscriptionByID(ctx context.Context, id string) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+
+	err := tenantConn(ctx, w.db, func(db *gorm.DB) error {
+		return db.Where("id = ?", id).First(&subscription).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (w *webhookRepository) GetSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	var subscriptions []*models.WebhookSubscription
+
+	err := tenantConn(ctx, w.db, func(db *gorm.DB) error {
+		return db.Order("created_at ASC, id ASC").Find(&subscriptions).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// GetActiveSubscriptions retorna as inscrições ativas do tenant que assinaram o tipo de evento.
+// O relay lê os eventos de todos os tenants, então o tenant do evento é sempre filtrado aqui.
+func (w *webhookRepository) GetActiveSubscriptions(ctx context.Context, tenantID string, eventType string) ([]*models.WebhookSubscription, error) {
+	var subscriptions []*models.WebhookSubscription
+
+	err := tenantConn(ctx, w.db, func(db *gorm.DB) error {
+		return db.
+			Where("tenant_id = ? AND active = ? AND ? = ANY(string_to_array(event_types, ','))", tenantID, true, eventType).
+			Find(&subscriptions).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (w *webhookRepository) UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	subscription.UpdatedAt.Time = time.Now().UTC()
+	subscription.UpdatedAt.Valid = true
+
+	return tenantConn(ctx, w.db, func(db *gorm.DB) error {
+		return db.
+			Model(subscription).
+			Select("url", "event_types", "secret", "active", "updated_at").
+			Updates(subscription).Error
+	})
+}
+
+func (w *webhookRepository) DeleteSubscription(ctx context.Context, id string) error {
+	return tenantConn(ctx, w.db, func(db *gorm.DB) error {
+		return db.Where("id = ?", id).Delete(&models.WebhookSubscription{}).Error
+	})
+}
+
+// CreateDeliveries enfileira as entregas. Uma entrega que já existe para a mesma inscrição e
+// evento é ignorada, então reentregar um evento do outbox não duplica o webhook.
+func (w *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	for _, delivery := range deliveries {
+		id, err := uuid.NewRandom()
+		if err != nil {
+			return fmt.Errorf("generate uuid: %w", err)
+		}
+
+		delivery.ID = id.String()
+		delivery.Status = models.WebhookDeliveryPending
+		delivery.NextAttemptAt = now
+		delivery.CreatedAt = now
+	}
+
+	return tenantConn(ctx, w.db, func(db *gorm.DB) error {
+		return db.
+			Omit(clause.Associations).
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "event_id"}},
+				DoNothing: true,
+			}).
+			Create(&deliveries).Error
+	})
+}
+
+// ClaimDueDeliveries reserva as entregas pendentes vencidas até leaseUntil, para que outras
+// instâncias não as enviem ao mesmo tempo. Se o envio for interrompido, a entrega volta a ser
+// tentada quando a reserva expirar. Deve ser chamado dentro de uma unidade de trabalho.
+func (w *webhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+
+	err := tenantConn(ctx, w.db, func(db *gorm.DB) error {
+		return db.
+			Preload("Subscription").
+			Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Find(&deliveries).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deliveries) == 0 {
+		return deliveries, nil
+	}
+
+	ids := make([]string, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		ids = append(ids, delivery.ID)
+		delivery.NextAttemptAt = leaseUntil
+	}
+
+	err = tenantConn(ctx, w.db, func(db *gorm.DB) error {
+		return db.
+			Model(&models.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			UpdateColumn("next_attempt_at", leaseUntil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (w *webhookRepository) GetDeliveryByID(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+
+	err := tenantConn(ctx, w.db, func(db *gorm.DB) error {
+		return db.
+			Preload("AttemptLogs", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+			Where("id = ?", id).
+			First(&delivery).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// GetDeliveries retorna as entregas mais recentes com o log de tentativas de cada uma
+func (w *webhookRepository) GetDeliveries(ctx context.Context, opts models.WebhookDeliveryListOptions) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := tenantConn(ctx, w.db, func(db *gorm.DB) error {
+		query := db.
+			Preload("AttemptLogs", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") })
+
+		if opts.SubscriptionID != "" {
+			query = query.Where("subscription_id = ?", opts.SubscriptionID)
+		}
+
+		if opts.Status != "" {
+			query = query.Where("status = ?", opts.Status)
+		}
+
+		return query.
+			Order("created_at DESC, id DESC").
+			Limit(opts.Limit).
+			Find(&deliveries).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (w *webhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return tenantConn(ctx, w.db, func(db *gorm.DB) error {
+		return db.
+			Model(delivery).
+			Select("status", "attempts", "attempts_before_redelivery", "next_attempt_at", "last_status_code", "last_error", "delivered_at").
+			Updates(delivery).Error
+	})
+}
+
+func (w *webhookRepository) CreateAttempt(ctx context.Context, attempt *models.WebhookAttempt) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return fmt.Errorf("generate uuid: %w", err)
+	}
+
+	attempt.ID = id.String()
+	attempt.CreatedAt = time.Now().UTC()
+
+	if err := conn(ctx, w.db).Create(attempt).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// PurgeFinishedDeliveries remove as entregas concluídas ou na lista de dead letters criadas antes
+// de before, junto com suas tentativas. O payload de uma entrega é uma cópia do evento e carrega
+// os dados dos contatos.
+func (w *webhookRepository) PurgeFinishedDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := tenantConn(ctx, w.db, func(db *gorm.DB) error {
+		result := db.
+			Where("status IN ? AND created_at < ?", []string{models.WebhookDeliveryDelivered, models.WebhookDeliveryDead}, before).
+			Delete(&models.WebhookDelivery{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookRepository_GetActiveSubscriptions(t *testing.T) {
+	ctx := pkgs.WithTenant(context.Background(), models.AllTenants)
+
+	t.Run("should filter active subscriptions of the event tenant by event type", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &webhookRepository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhook_subscriptions" WHERE tenant_id = $1 AND active = $2 AND $3 = ANY(string_to_array(event_types, ','))`)).
+			WithArgs("tenant-a", true, models.EventClientCreated).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "url", "event_types", "active"}).
+				AddRow("webhook-1", "tenant-a", "https://partner.example.com", "ClientCreated,ContactAdded", true))
+		mock.ExpectCommit()
+
+		subscriptions, err := repo.GetActiveSubscriptions(ctx, "tenant-a", models.EventClientCreated)
+
+		assert.NoError(t, err)
+		assert.Len(t, subscriptions, 1)
+		assert.Equal(t, "webhook-1", subscriptions[0].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestWebhookRepository_GetSubscriptionByID(t *testing.T) {
+	ctx := pkgs.WithTenant(context.Background(), "tenant-a")
+
+	t.Run("should return nil if the subscription does not exist in the tenant", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &webhookRepository{db: db}
+
+		expectTenantTx(mock, "tenant-a")
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhook_subscriptions" WHERE id = $1 AND tenant_id = $2`)).
+			WithArgs("webhook-1", "tenant-a", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		subscription, err := repo.GetSubscriptionByID(ctx, "webhook-1")
+
+		assert.NoError(t, err)
+		assert.Nil(t, subscription)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestWebhookRepository_CreateDeliveries(t *testing.T) {
+	ctx := pkgs.WithTenant(context.Background(), models.AllTenants)
+
+	t.Run("should enqueue pending deliveries ignoring duplicates", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &webhookRepository{db: db}
+
+		deliveries := []*models.WebhookDelivery{
+			{SubscriptionID: "webhook-1", EventID: "event-1", EventType: models.EventClientCreated, Payload: []byte(`{}`)},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "webhook_deliveries" .* ON CONFLICT \("subscription_id","event_id"\) DO NOTHING`).
+			WillReturnRows(sqlmock.NewRows([]string{"attempts", "last_status_code", "last_error"}).AddRow(0, 0, ""))
+		mock.ExpectCommit()
+
+		err := repo.CreateDeliveries(ctx, deliveries)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, deliveries[0].ID)
+		assert.Equal(t, models.WebhookDeliveryPending, deliveries[0].Status)
+		assert.False(t, deliveries[0].NextAttemptAt.IsZero())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestWebhookRepository_ClaimDueDeliveries(t *testing.T) {
+	ctx := pkgs.WithTenant(context.Background(), models.AllTenants)
+	now := time.Date(2025, 4, 20, 10, 0, 0, 0, time.UTC)
+	leaseUntil := now.Add(time.Minute)
+
+	t.Run("should lock due deliveries and extend their next attempt as a lease", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &webhookRepository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhook_deliveries" WHERE status = $1 AND next_attempt_at <= $2 ORDER BY next_attempt_at ASC LIMIT $3 FOR UPDATE SKIP LOCKED`)).
+			WithArgs(models.WebhookDeliveryPending, now, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "subscription_id", "status"}).
+				AddRow("delivery-1", "webhook-1", models.WebhookDeliveryPending))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhook_subscriptions" WHERE "webhook_subscriptions"."id" = $1`)).
+			WithArgs("webhook-1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "url", "secret"}).
+				AddRow("webhook-1", "https://partner.example.com", "secret"))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "webhook_deliveries" SET "next_attempt_at"=$1 WHERE id IN ($2)`)).
+			WithArgs(leaseUntil, "delivery-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		deliveries, err := repo.ClaimDueDeliveries(ctx, now, leaseUntil, 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 1)
+		assert.Equal(t, "https://partner.example.com", deliveries[0].Subscription.URL)
+		assert.Equal(t, leaseUntil, deliveries[0].NextAttemptAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should not update anything when no delivery is due", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &webhookRepository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "webhook_deliveries"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectCommit()
+
+		deliveries, err := repo.ClaimDueDeliveries(ctx, now, leaseUntil, 10)
+
+		assert.NoError(t, err)
+		assert.Empty(t, deliveries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestWebhookRepository_PurgeFinishedDeliveries(t *testing.T) {
+	ctx := pkgs.WithTenant(context.Background(), models.AllTenants)
+	before := time.Date(2025, 4, 20, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should remove the delivered and dead deliveries created before the cutoff", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		repo := &webhookRepository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "webhook_deliveries" WHERE status IN ($1,$2) AND created_at < $3`)).
+			WithArgs(models.WebhookDeliveryDelivered, models.WebhookDeliveryDead, before).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		purged, err := repo.PurgeFinishedDeliveries(ctx, before)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), purged)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/repositories"
+)
+
+// apiKeyTouchInterval evita uma escrita por requisição ao registrar o último uso da chave
+const apiKeyTouchInterval = time.Minute
+
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, payload models.CreateAPIKeyPayload) (*models.CreatedAPIKeyResponse, error)
+	GetAPIKeys(ctx context.Context) ([]models.APIKeyResponse, error)
+	RevokeAPIKey(ctx context.Context, id string) error
+	Authenticate(ctx context.Context, key string) (*models.Principal, error)
+}
+
+type apiKeyService struct {
+	di  *pkgs.Di
+	v   *pkgs.Validator
+	akr repositories.APIKeyRepository
+}
+
+func NewAPIKeyService(di *pkgs.Di) (APIKeyService, error) {
+	apiKeyRepository, err := pkgs.Invoke[repositories.APIKeyRepository](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke repositories.api_key: %w", err)
+	}
+
+	return &apiKeyService{
+		di:  di,
+		v:   pkgs.NewValidator(),
+		akr: apiKeyRepository,
+	}, nil
+}
+
+// CreateAPIKey gera uma nova chave com os escopos informados, vinculada ao tenant do payload
+// quando houver. A chave só é devolvida aqui; depois disso apenas o hash fica guardado.
+func (a *apiKeyService) CreateAPIKey(ctx context.Context, payload models.CreateAPIKeyPayload) (*models.CreatedAPIKeyResponse, error) {
+	if err := a.v.Validate(&payload); err != nil {
+		return nil, err
+	}
+
+	if payload.TenantID == "" && !slices.Contains(payload.Scopes, models.ScopePlatform) {
+		return nil, models.NewValidationError("tenantId", "is required unless the key has the platform scope")
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("generate api key: %w", err)
+	}
+
+	key := models.APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	apiKey := &models.APIKey{
+		Name:     payload.Name,
+		Prefix:   key[:models.APIKeyDisplayLength],
+		Hash:     hashAPIKey(key),
+		TenantID: payload.TenantID,
+	}
+	apiKey.SetScopes(payload.Scopes)
+
+	if err := a.akr.CreateAPIKey(ctx, apiKey); err != nil {
+		return nil, fmt.Errorf("create api key: %w", err)
+	}
+
+	return &models.CreatedAPIKeyResponse{
+		APIKeyResponse: *apiKey.ToAPIKeyResponse(),
+		Key:            key,
+	}, nil
+}
+
+func (a *apiKeyService) GetAPIKeys(ctx context.Context) ([]models.APIKeyResponse, error) {
+	keys, err := a.akr.GetAPIKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get api keys: %w", err)
+	}
+
+	response := make([]models.APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		response = append(response, *key.ToAPIKeyResponse())
+	}
+
+	return response, nil
+}
+
+func (a *apiKeyService) RevokeAPIKey(ctx context.Context, id string) error {
+	revoked, err := a.akr.RevokeAPIKey(ctx, id, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("revoke api key %s: %w", id, err)
+	}
+
+	if !revoked {
+		return models.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// Authenticate retorna a credencial da chave informada. Chaves desconhecidas ou revogadas
+// resultam em models.ErrUnauthorized.
+func (a *apiKeyService) Authenticate(ctx context.Context, key string) (*models.Principal, error) {
+	logger := pkgs.LoggerFromContext(ctx).With(
+		slog.String("service", "api_key"),
+		slog.String("method", "Authenticate"),
+	)
+
+	apiKey, err := a.akr.GetAPIKeyByHash(ctx, hashAPIKey(key))
+	if err != nil {
+		return nil, fmt.Errorf("get api key by hash: %w", err)
+	}
+
+	if apiKey == nil || apiKey.RevokedAt.Valid {
+		return nil, models.ErrUnauthorized
+	}
+
+	now := time.Now().UTC()
+	if !apiKey.LastUsedAt.Valid || now.Sub(apiKey.LastUsedAt.Time) >= apiKeyTouchInterval {
+		// Falhar ao registrar o uso não deve impedir a requisição
+		if err := a.akr.TouchAPIKey(ctx, apiKey.ID, now); err != nil {
+			logger.Warn("error to touch api key", "key", apiKey.ID, "error", err)
+		}
+	}
+
+	return apiKey.ToPrincipal(), nil
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
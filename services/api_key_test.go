@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/mocks"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAPIKeyService_CreateAPIKey(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should store only the hash of the generated key", func(t *testing.T) {
+		apiKeyRepo := new(mocks.APIKeyRepositoryMock)
+		svc := &apiKeyService{v: pkgs.NewValidator(), akr: apiKeyRepo}
+
+		var stored *models.APIKey
+		apiKeyRepo.
+			On("CreateAPIKey", ctx, mock.AnythingOfType("*models.APIKey")).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(*models.APIKey) }).
+			Return(nil)
+
+		created, err := svc.CreateAPIKey(ctx, models.CreateAPIKeyPayload{
+			Name:     "backoffice",
+			Scopes:   []string{models.ScopeClientsWrite, models.ScopeClientsRead},
+			TenantID: "retail",
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(created.Key, models.APIKeyPrefix))
+		assert.Equal(t, created.Key[:models.APIKeyDisplayLength], stored.Prefix)
+		assert.Equal(t, hashAPIKey(created.Key), stored.Hash)
+		assert.NotContains(t, stored.Hash, created.Key)
+		assert.Equal(t, "clients:read,clients:write", stored.Scopes)
+	})
+
+	t.Run("should return validation error on unknown scope", func(t *testing.T) {
+		apiKeyRepo := new(mocks.APIKeyRepositoryMock)
+		svc := &apiKeyService{v: pkgs.NewValidator(), akr: apiKeyRepo}
+
+		created, err := svc.CreateAPIKey(ctx, models.CreateAPIKeyPayload{
+			Name:     "backoffice",
+			Scopes:   []string{"clients:delete"},
+			TenantID: "retail",
+		})
+
+		var validationErr *models.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []models.FieldError{{
+			Field:   "scopes[0]",
+			Message: "must be one of: " + strings.Join(models.Scopes, ", "),
+		}}, validationErr.Fields)
+		assert.Nil(t, created)
+		apiKeyRepo.AssertNotCalled(t, "CreateAPIKey", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should bind the key to the tenant", func(t *testing.T) {
+		apiKeyRepo := new(mocks.APIKeyRepositoryMock)
+		svc := &apiKeyService{v: pkgs.NewValidator(), akr: apiKeyRepo}
+
+		apiKeyRepo.
+			On("CreateAPIKey", ctx, mock.MatchedBy(func(key *models.APIKey) bool { return key.TenantID == "retail" })).
+			Return(nil)
+
+		created, err := svc.CreateAPIKey(ctx, models.CreateAPIKeyPayload{
+			Name:     "retail-backoffice",
+			Scopes:   []string{models.ScopeClientsRead},
+			TenantID: "retail",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "retail", created.TenantID)
+		apiKeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("should require a tenant unless the key has the platform scope", func(t *testing.T) {
+		apiKeyRepo := new(mocks.APIKeyRepositoryMock)
+		svc := &apiKeyService{v: pkgs.NewValidator(), akr: apiKeyRepo}
+
+		created, err := svc.CreateAPIKey(ctx, models.CreateAPIKeyPayload{Name: "backoffice", Scopes: []string{models.ScopeClientsRead}})
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+		assert.Nil(t, created)
+		apiKeyRepo.AssertNotCalled(t, "CreateAPIKey", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should create a platform key without a tenant", func(t *testing.T) {
+		apiKeyRepo := new(mocks.APIKeyRepositoryMock)
+		svc := &apiKeyService{v: pkgs.NewValidator(), akr: apiKeyRepo}
+
+		apiKeyRepo.
+			On("CreateAPIKey", ctx, mock.MatchedBy(func(key *models.APIKey) bool { return key.TenantID == "" })).
+			Return(nil)
+
+		created, err := svc.CreateAPIKey(ctx, models.CreateAPIKeyPayload{
+			Name:   "platform",
+			Scopes: []string{models.ScopeClientsRead, models.ScopePlatform},
+		})
+
+		assert.NoError(t, err)
+		assert.Empty(t, created.TenantID)
+		apiKeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return validation error on invalid tenant", func(t *testing.T) {
+		svc := &apiKeyService{v: pkgs.NewValidator()}
+
+		created, err := svc.CreateAPIKey(ctx, models.CreateAPIKeyPayload{
+			Name:     "backoffice",
+			Scopes:   []string{models.ScopeClientsRead},
+			TenantID: "Retail Unit",
+		})
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+		assert.Nil(t, created)
+	})
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	key := models.APIKeyPrefix + "secret"
+
+	t.Run("should return the principal and record the use of the key", func(t *testing.T) {
+		apiKeyRepo := new(mocks.APIKeyRepositoryMock)
+		svc := &apiKeyService{akr: apiKeyRepo}
+
+		apiKeyRepo.On("GetAPIKeyByHash", ctx, hashAPIKey(key)).Return(&models.APIKey{
+			ID: "key-1", Name: "backoffice", Scopes: "clients:read",
+		}, nil)
+		apiKeyRepo.On("TouchAPIKey", ctx, "key-1", mock.AnythingOfType("time.Time")).Return(nil)
+
+		principal, err := svc.Authenticate(ctx, key)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "backoffice", principal.Subject)
+		assert.Equal(t, "api-key:backoffice", principal.Actor())
+		assert.True(t, principal.HasScope(models.ScopeClientsRead))
+		apiKeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("should not record the use again within the touch interval", func(t *testing.T) {
+		apiKeyRepo := new(mocks.APIKeyRepositoryMock)
+		svc := &apiKeyService{akr: apiKeyRepo}
+
+		apiKeyRepo.On("GetAPIKeyByHash", ctx, hashAPIKey(key)).Return(&models.APIKey{
+			ID: "key-1", LastUsedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		}, nil)
+
+		_, err := svc.Authenticate(ctx, key)
+
+		assert.NoError(t, err)
+		apiKeyRepo.AssertNotCalled(t, "TouchAPIKey", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject unknown and revoked keys", func(t *testing.T) {
+		apiKeyRepo := new(mocks.APIKeyRepositoryMock)
+		svc := &apiKeyService{akr: apiKeyRepo}
+
+		apiKeyRepo.On("GetAPIKeyByHash", ctx, hashAPIKey("unknown")).Return(nil, nil)
+		apiKeyRepo.On("GetAPIKeyByHash", ctx, hashAPIKey(key)).Return(&models.APIKey{
+			ID: "key-1", RevokedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		}, nil)
+
+		_, err := svc.Authenticate(ctx, "unknown")
+		assert.ErrorIs(t, err, models.ErrUnauthorized)
+
+		_, err = svc.Authenticate(ctx, key)
+		assert.ErrorIs(t, err, models.ErrUnauthorized)
+	})
+
+	t.Run("should return error if the key cannot be read", func(t *testing.T) {
+		apiKeyRepo := new(mocks.APIKeyRepositoryMock)
+		svc := &apiKeyService{akr: apiKeyRepo}
+
+		apiKeyRepo.On("GetAPIKeyByHash", ctx, mock.Anything).Return(nil, errors.New("db failure"))
+
+		_, err := svc.Authenticate(ctx, key)
+
+		assert.EqualError(t, err, "get api key by hash: db failure")
+	})
+}
+
+func TestAPIKeyService_RevokeAPIKey(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return not found if the key is unknown or already revoked", func(t *testing.T) {
+		apiKeyRepo := new(mocks.APIKeyRepositoryMock)
+		svc := &apiKeyService{akr: apiKeyRepo}
+
+		apiKeyRepo.On("RevokeAPIKey", ctx, "key-1", mock.AnythingOfType("time.Time")).Return(false, nil)
+
+		err := svc.RevokeAPIKey(ctx, "key-1")
+
+		assert.ErrorIs(t, err, models.ErrAPIKeyNotFound)
+	})
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/repositories"
+)
+
+type AuditService interface {
+	Record(ctx context.Context, entry models.AuditEntry) error
+	GetAuditLogs(ctx context.Context, query models.AuditQuery) (*models.AuditPageResponse, error)
+	GetClientHistory(ctx context.Context, clientID string, query models.AuditQuery) (*models.AuditPageResponse, error)
+}
+
+type auditService struct {
+	di  *pkgs.Di
+	ar  repositories.AuditRepository
+	clr repositories.ClientRepository
+}
+
+func NewAuditService(di *pkgs.Di) (AuditService, error) {
+	auditRepository, err := pkgs.Invoke[repositories.AuditRepository](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke repositories.audit: %w", err)
+	}
+
+	clientRepository, err := pkgs.Invoke[repositories.ClientRepository](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke repositories.client: %w", err)
+	}
+
+	return &auditService{
+		di:  di,
+		ar:  auditRepository,
+		clr: clientRepository,
+	}, nil
+}
+
+// Record grava a alteração com o autor e o ID da requisição presentes no contexto. Deve ser
+// chamado dentro da mesma unidade de trabalho da alteração para que ambos sejam gravados juntos.
+func (a *auditService) Record(ctx context.Context, entry models.AuditEntry) error {
+	diff, err := pkgs.Diff(entry.Before, entry.After)
+	if err != nil {
+		return fmt.Errorf("diff %s %s: %w", entry.EntityType, entry.EntityID, err)
+	}
+
+	log := &models.AuditLog{
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		ClientID:   entry.ClientID,
+		Action:     entry.Action,
+		Actor:      pkgs.ActorFromContext(ctx),
+		RequestID:  pkgs.RequestIDFromContext(ctx),
+	}
+
+	if log.Actor == "" {
+		log.Actor = models.AnonymousActor
+	}
+
+	if log.Before, err = marshalSnapshot(entry.Before); err != nil {
+		return fmt.Errorf("encode %s %s before: %w", entry.EntityType, entry.EntityID, err)
+	}
+
+	if log.After, err = marshalSnapshot(entry.After); err != nil {
+		return fmt.Errorf("encode %s %s after: %w", entry.EntityType, entry.EntityID, err)
+	}
+
+	if log.Diff, err = jsoniter.Marshal(diff); err != nil {
+		return fmt.Errorf("encode %s %s diff: %w", entry.EntityType, entry.EntityID, err)
+	}
+
+	if err := a.ar.CreateAuditLog(ctx, log); err != nil {
+		return fmt.Errorf("create audit log: %w", err)
+	}
+
+	return nil
+}
+
+func (a *auditService) GetAuditLogs(ctx context.Context, query models.AuditQuery) (*models.AuditPageResponse, error) {
+	var cursor *models.Cursor
+	if query.Cursor != "" {
+		decoded, err := models.DecodeCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		if decoded.Sort != models.AuditSortCreatedAt || decoded.Backward {
+			return nil, models.NewValidationError("cursor", "is invalid")
+		}
+
+		cursor = decoded
+	}
+
+	limit := query.PageLimit()
+
+	logs, hasMore, err := a.ar.GetAuditLogs(ctx, models.AuditListOptions{
+		Limit:      limit,
+		Cursor:     cursor,
+		EntityType: query.EntityType,
+		EntityID:   query.EntityID,
+		ClientID:   query.ClientID,
+		Action:     query.Action,
+		Actor:      query.Actor,
+		RequestID:  query.RequestID,
+		From:       query.From,
+		To:         query.To,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get audit logs: %w", err)
+	}
+
+	page := &models.AuditPageResponse{
+		Data: make([]models.AuditLogResponse, 0, len(logs)),
+		Meta: models.PageMeta{
+			Limit:   limit,
+			HasNext: hasMore,
+			HasPrev: cursor != nil,
+		},
+	}
+
+	for _, log := range logs {
+		page.Data = append(page.Data, *log.ToAuditLogResponse())
+	}
+
+	if hasMore {
+		page.Meta.NextCursor = logs[len(logs)-1].ToCursor().Encode()
+	}
+
+	return page, nil
+}
+
+// GetClientHistory retorna as alterações do cliente e de seus contatos. O histórico continua
+// disponível depois que o cliente é removido, mas só para quem ainda enxerga o cliente.
+func (a *auditService) GetClientHistory(ctx context.Context, clientID string, query models.AuditQuery) (*models.AuditPageResponse, error) {
+	exists, err := a.clientExists(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, models.ErrClientNotFound
+	}
+
+	query.ClientID = clientID
+
+	return a.GetAuditLogs(ctx, query)
+}
+
+func (a *auditService) clientExists(ctx context.Context, id string) (bool, error) {
+	client, err := a.clr.GetClientByID(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("get client by id %s: %w", id, err)
+	}
+
+	if client != nil {
+		return true, nil
+	}
+
+	deleted, err := a.clr.GetDeletedClientByID(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("get deleted client by id %s: %w", id, err)
+	}
+
+	return deleted != nil, nil
+}
+
+// marshalSnapshot serializa a representação da entidade, retornando nil quando ela não existe
+func marshalSnapshot(snapshot any) ([]byte, error) {
+	data, err := jsoniter.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	if string(data) == "null" {
+		return nil, nil
+	}
+
+	return data, nil
+}
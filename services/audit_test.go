@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/mocks"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditService_Record(t *testing.T) {
+	type snapshot struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	t.Run("should record the change with actor, request id and diff", func(t *testing.T) {
+		auditRepo := new(mocks.AuditRepositoryMock)
+		svc := &auditService{ar: auditRepo}
+
+		ctx := pkgs.WithRequestID(pkgs.WithActor(context.Background(), "backoffice"), "request-1")
+
+		auditRepo.
+			On("CreateAuditLog", ctx, mock.MatchedBy(func(log *models.AuditLog) bool {
+				return log.EntityType == models.AuditEntityClient &&
+					log.EntityID == "client-123" &&
+					log.ClientID == "client-123" &&
+					log.Action == models.AuditActionUpdate &&
+					log.Actor == "backoffice" &&
+					log.RequestID == "request-1" &&
+					string(log.Before) == `{"id":"client-123","name":"Gabriel"}` &&
+					string(log.After) == `{"id":"client-123","name":"Gabriel V."}` &&
+					string(log.Diff) == `{"name":{"from":"Gabriel","to":"Gabriel V."}}`
+			})).
+			Return(nil)
+
+		err := svc.Record(ctx, models.AuditEntry{
+			EntityType: models.AuditEntityClient,
+			EntityID:   "client-123",
+			ClientID:   "client-123",
+			Action:     models.AuditActionUpdate,
+			Before:     snapshot{ID: "client-123", Name: "Gabriel"},
+			After:      snapshot{ID: "client-123", Name: "Gabriel V."},
+		})
+
+		assert.NoError(t, err)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("should record anonymous actor and no before on creation", func(t *testing.T) {
+		auditRepo := new(mocks.AuditRepositoryMock)
+		svc := &auditService{ar: auditRepo}
+
+		ctx := context.Background()
+		var before *models.ContactResponse
+
+		auditRepo.
+			On("CreateAuditLog", ctx, mock.MatchedBy(func(log *models.AuditLog) bool {
+				return log.Actor == models.AnonymousActor && log.Before == nil && log.After != nil
+			})).
+			Return(nil)
+
+		err := svc.Record(ctx, models.AuditEntry{
+			EntityType: models.AuditEntityContact,
+			EntityID:   "contact-1",
+			ClientID:   "client-123",
+			Action:     models.AuditActionCreate,
+			Before:     before,
+			After:      &models.ContactResponse{ID: "contact-1"},
+		})
+
+		assert.NoError(t, err)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error if the repository fails", func(t *testing.T) {
+		auditRepo := new(mocks.AuditRepositoryMock)
+		svc := &auditService{ar: auditRepo}
+
+		ctx := context.Background()
+
+		auditRepo.On("CreateAuditLog", ctx, mock.Anything).Return(errors.New("db failure"))
+
+		err := svc.Record(ctx, models.AuditEntry{EntityType: models.AuditEntityClient, EntityID: "client-123"})
+
+		assert.EqualError(t, err, "create audit log: db failure")
+	})
+}
+
+func TestAuditService_GetAuditLogs(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2025, 4, 20, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should return a page with the next cursor", func(t *testing.T) {
+		auditRepo := new(mocks.AuditRepositoryMock)
+		svc := &auditService{ar: auditRepo}
+
+		auditRepo.
+			On("GetAuditLogs", ctx, models.AuditListOptions{Limit: 2, Actor: "backoffice"}).
+			Return([]*models.AuditLog{
+				{ID: "5c1d7e20-3a4b-4c5d-8e6f-7a8b9c0d1e02", Actor: "backoffice", Diff: []byte(`{}`), CreatedAt: createdAt},
+				{ID: "5c1d7e20-3a4b-4c5d-8e6f-7a8b9c0d1e01", Actor: "backoffice", Diff: []byte(`{}`), CreatedAt: createdAt.Add(-time.Minute)},
+			}, true, nil)
+
+		page, err := svc.GetAuditLogs(ctx, models.AuditQuery{Limit: 2, Actor: "backoffice"})
+
+		assert.NoError(t, err)
+		assert.Len(t, page.Data, 2)
+		assert.True(t, page.Meta.HasNext)
+		assert.False(t, page.Meta.HasPrev)
+
+		cursor, err := models.DecodeCursor(page.Meta.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, "5c1d7e20-3a4b-4c5d-8e6f-7a8b9c0d1e01", cursor.ID)
+		assert.Equal(t, models.AuditSortCreatedAt, cursor.Sort)
+	})
+
+	t.Run("should reject a cursor from another listing", func(t *testing.T) {
+		svc := &auditService{}
+
+		cursor := (&models.Cursor{Sort: models.ClientSortName, Value: "Gabriel", ID: "7a395834-0ed5-4954-8e1d-b63cd2fdb97a"}).Encode()
+
+		page, err := svc.GetAuditLogs(ctx, models.AuditQuery{Cursor: cursor})
+
+		assert.Nil(t, page)
+		assert.ErrorIs(t, err, models.ErrValidation)
+	})
+}
+
+func TestAuditService_GetClientHistory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return the changes of the client", func(t *testing.T) {
+		auditRepo := new(mocks.AuditRepositoryMock)
+		clientRepo := new(mocks.ClientRepositoryMock)
+		svc := &auditService{ar: auditRepo, clr: clientRepo}
+
+		clientRepo.On("GetClientByID", ctx, "client-123").Return(&models.Client{ID: "client-123"}, nil)
+		auditRepo.
+			On("GetAuditLogs", ctx, models.AuditListOptions{Limit: models.DefaultPageLimit, ClientID: "client-123"}).
+			Return([]*models.AuditLog{{ID: "5c1d7e20-3a4b-4c5d-8e6f-7a8b9c0d1e01", ClientID: "client-123"}}, false, nil)
+
+		page, err := svc.GetClientHistory(ctx, "client-123", models.AuditQuery{})
+
+		assert.NoError(t, err)
+		assert.Len(t, page.Data, 1)
+	})
+
+	t.Run("should return an empty history for a deleted client", func(t *testing.T) {
+		auditRepo := new(mocks.AuditRepositoryMock)
+		clientRepo := new(mocks.ClientRepositoryMock)
+		svc := &auditService{ar: auditRepo, clr: clientRepo}
+
+		auditRepo.On("GetAuditLogs", ctx, mock.Anything).Return([]*models.AuditLog{}, false, nil)
+		clientRepo.On("GetClientByID", ctx, "client-123").Return(nil, nil)
+		clientRepo.On("GetDeletedClientByID", ctx, "client-123").Return(&models.Client{ID: "client-123"}, nil)
+
+		page, err := svc.GetClientHistory(ctx, "client-123", models.AuditQuery{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, page.Data)
+	})
+
+	t.Run("should not page through the history of a client the tenant cannot see", func(t *testing.T) {
+		auditRepo := new(mocks.AuditRepositoryMock)
+		clientRepo := new(mocks.ClientRepositoryMock)
+		svc := &auditService{ar: auditRepo, clr: clientRepo}
+
+		cursor := (&models.Cursor{Sort: models.AuditSortCreatedAt, Value: "2025-04-20T10:00:00Z", ID: "5c1d7e20-3a4b-4c5d-8e6f-7a8b9c0d1e03"}).Encode()
+
+		clientRepo.On("GetClientByID", ctx, "client-of-another-tenant").Return(nil, nil)
+		clientRepo.On("GetDeletedClientByID", ctx, "client-of-another-tenant").Return(nil, nil)
+
+		page, err := svc.GetClientHistory(ctx, "client-of-another-tenant", models.AuditQuery{Cursor: cursor})
+
+		assert.Nil(t, page)
+		assert.ErrorIs(t, err, models.ErrClientNotFound)
+		auditRepo.AssertNotCalled(t, "GetAuditLogs", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error if client not found", func(t *testing.T) {
+		auditRepo := new(mocks.AuditRepositoryMock)
+		clientRepo := new(mocks.ClientRepositoryMock)
+		svc := &auditService{ar: auditRepo, clr: clientRepo}
+
+		clientRepo.On("GetClientByID", ctx, "missing-client").Return(nil, nil)
+		clientRepo.On("GetDeletedClientByID", ctx, "missing-client").Return(nil, nil)
+
+		page, err := svc.GetClientHistory(ctx, "missing-client", models.AuditQuery{})
+
+		assert.Nil(t, page)
+		assert.ErrorIs(t, err, models.ErrClientNotFound)
+		auditRepo.AssertNotCalled(t, "GetAuditLogs", mock.Anything, mock.Anything)
+	})
+}
@@ -3,7 +3,12 @@ package services
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/g-villarinho/nubank-challenge/metrics"
 	"github.com/g-villarinho/nubank-challenge/models"
 	"github.com/g-villarinho/nubank-challenge/pkgs"
 	"github.com/g-villarinho/nubank-challenge/repositories"
@@ -11,17 +16,34 @@ import (
 
 type ClientService interface {
 	CreateClient(ctx context.Context, name string, contacts []*models.Contact) (*models.ClientResponse, error)
-	GetClientsWithContact(ctx context.Context) ([]models.ClientResponse, error)
+	GetClientsWithContact(ctx context.Context, query models.ListClientsQuery) (*models.ClientPageResponse, error)
 	GetClientContactsByID(ctx context.Context, id string) ([]models.ContactResponse, error)
+	GetClient(ctx context.Context, id string, includeContacts bool) (*models.ClientResponse, error)
+	UpdateClient(ctx context.Context, id string, version int64, name string) (*models.ClientResponse, error)
+	PatchClient(ctx context.Context, id string, version int64, patch []byte) (*models.ClientResponse, error)
+	DeleteClient(ctx context.Context, id string, version int64) error
+	RestoreClient(ctx context.Context, id string) (*models.ClientResponse, error)
+	GetDeletedClients(ctx context.Context) ([]models.ClientResponse, error)
+	PurgeDeletedClients(ctx context.Context, retention time.Duration) (int64, error)
 }
 
 type clientService struct {
 	di  *pkgs.Di
+	v   *pkgs.Validator
+	uow repositories.UnitOfWork
 	clr repositories.ClientRepository
 	ctr repositories.ContactRepository
+	as  AuditService
+	ob  OutboxService
+	m   *metrics.Metrics
 }
 
 func NewClientService(di *pkgs.Di) (ClientService, error) {
+	unitOfWork, err := pkgs.Invoke[repositories.UnitOfWork](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke repositories.UnitOfWork: %w", err)
+	}
+
 	clientRepository, err := pkgs.Invoke[repositories.ClientRepository](di)
 	if err != nil {
 		return nil, fmt.Errorf("invoke repositories.Client: %w", err)
@@ -32,52 +54,181 @@ func NewClientService(di *pkgs.Di) (ClientService, error) {
 		return nil, fmt.Errorf("invoke repositories.Contact: %w", err)
 	}
 
-	return &clientService{
+	auditService, err := pkgs.Invoke[AuditService](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke services.Audit: %w", err)
+	}
+
+	outboxService, err := pkgs.Invoke[OutboxService](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke services.Outbox: %w", err)
+	}
+
+	m, err := pkgs.Invoke[*metrics.Metrics](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke metrics: %w", err)
+	}
+
+	svc := &clientService{
 		di:  di,
+		v:   pkgs.NewValidator(),
+		uow: unitOfWork,
 		clr: clientRepository,
 		ctr: contactRepository,
-	}, nil
+		as:  auditService,
+		ob:  outboxService,
+		m:   m,
+	}
+
+	return &tracedClientService{next: svc}, nil
 }
 
 func (c *clientService) CreateClient(ctx context.Context, name string, contacts []*models.Contact) (*models.ClientResponse, error) {
 	client := &models.Client{Name: name}
 
-	if err := c.clr.CreateClient(ctx, client); err != nil {
-		return nil, fmt.Errorf("create client: %w", err)
-	}
+	err := c.uow.Do(ctx, func(ctx context.Context) error {
+		if err := c.clr.CreateClient(ctx, client); err != nil {
+			return fmt.Errorf("create client: %w", err)
+		}
+
+		if err := c.record(ctx, client.ID, models.AuditActionCreate, nil, client.ToSnapshot()); err != nil {
+			return err
+		}
+
+		if err := c.ob.Emit(ctx, models.ClientCreated{Client: client.ToSnapshot()}); err != nil {
+			return err
+		}
+
+		if len(contacts) == 0 {
+			return nil
+		}
 
-	if len(contacts) > 0 {
 		for _, contact := range contacts {
 			contact.ClientID = client.ID
 		}
 
 		if err := c.ctr.CreateContacts(ctx, contacts); err != nil {
-			return nil, fmt.Errorf("create contacts: %w", err)
+			return fmt.Errorf("create contacts: %w", err)
 		}
 
-		client.Contacts = make([]models.Contact, len(contacts))
-		for i, contact := range contacts {
-			client.Contacts[i] = *contact
+		events := make([]models.DomainEvent, 0, len(contacts))
+		for _, contact := range contacts {
+			if err := recordContact(ctx, c.as, contact.ID, contact.ClientID, models.AuditActionCreate, nil, contact.ToContactResponse()); err != nil {
+				return err
+			}
+
+			events = append(events, models.ContactAdded{Contact: contact.ToContactResponse()})
 		}
+
+		return c.ob.Emit(ctx, events...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.m.ClientsCreated.Inc()
+	c.m.ContactsCreated.Add(float64(len(contacts)))
+
+	client.Contacts = make([]models.Contact, len(contacts))
+	for i, contact := range contacts {
+		client.Contacts[i] = *contact
 	}
 
 	resp := client.ToClientResponse()
 	return resp, nil
 }
 
-func (c *clientService) GetClientsWithContact(ctx context.Context) ([]models.ClientResponse, error) {
-	clients, err := c.clr.GetClientsWithContact(ctx)
+func (c *clientService) GetClientsWithContact(ctx context.Context, query models.ListClientsQuery) (*models.ClientPageResponse, error) {
+	sort := query.SortOrDefault()
+	limit := query.PageLimit()
+
+	var cursor *models.Cursor
+	if query.Cursor != "" {
+		decoded, err := models.DecodeCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		if decoded.Sort != sort {
+			return nil, models.NewValidationError("cursor", "does not match the requested sort")
+		}
+
+		cursor = decoded
+	}
+
+	page := &models.ClientPageResponse{
+		Data: []models.ClientResponse{},
+		Meta: models.PageMeta{Limit: limit},
+	}
+
+	opts := models.ClientListOptions{
+		Limit:       limit,
+		Sort:        sort,
+		Cursor:      cursor,
+		NamePrefix:  query.Name,
+		CreatedFrom: query.CreatedFrom,
+		CreatedTo:   query.CreatedTo,
+	}
+
+	if query.ContactEmail != "" || query.ContactPhone != "" {
+		clientIDs, err := c.getClientIDsByContact(ctx, query.ContactEmail, query.ContactPhone)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(clientIDs) == 0 {
+			return page, nil
+		}
+
+		opts.ClientIDs = clientIDs
+	}
+
+	clients, hasMore, err := c.clr.GetClientsWithContact(ctx, opts)
 	if err != nil {
 		return nil, fmt.Errorf("get clients with contact: %w", err)
 	}
 
-	clientResponses := make([]models.ClientResponse, 0, len(clients))
-
 	for _, client := range clients {
-		clientResponses = append(clientResponses, *client.ToClientResponse())
+		page.Data = append(page.Data, *client.ToClientResponse())
 	}
 
-	return clientResponses, nil
+	if len(clients) == 0 {
+		return page, nil
+	}
+
+	if cursor != nil && cursor.Backward {
+		page.Meta.HasNext = true
+		page.Meta.HasPrev = hasMore
+	} else {
+		page.Meta.HasNext = hasMore
+		page.Meta.HasPrev = cursor != nil
+	}
+
+	if page.Meta.HasNext {
+		page.Meta.NextCursor = clients[len(clients)-1].ToCursor(sort, false).Encode()
+	}
+
+	if page.Meta.HasPrev {
+		page.Meta.PrevCursor = clients[0].ToCursor(sort, true).Encode()
+	}
+
+	return page, nil
+}
+
+func (c *clientService) getClientIDsByContact(ctx context.Context, email string, phone string) ([]string, error) {
+	contacts, err := c.ctr.SearchContacts(ctx, email, phone)
+	if err != nil {
+		return nil, fmt.Errorf("search contacts: %w", err)
+	}
+
+	clientIDs := make([]string, 0, len(contacts))
+	for _, contact := range contacts {
+		if !slices.Contains(clientIDs, contact.ClientID) {
+			clientIDs = append(clientIDs, contact.ClientID)
+		}
+	}
+
+	return clientIDs, nil
 }
 
 func (c *clientService) GetClientContactsByID(ctx context.Context, id string) ([]models.ContactResponse, error) {
@@ -102,3 +253,257 @@ func (c *clientService) GetClientContactsByID(ctx context.Context, id string) ([
 
 	return contactsResponse, nil
 }
+
+func (c *clientService) GetClient(ctx context.Context, id string, includeContacts bool) (*models.ClientResponse, error) {
+	var (
+		client *models.Client
+		err    error
+	)
+
+	if includeContacts {
+		client, err = c.clr.GetClientWitContactsByID(ctx, id)
+	} else {
+		client, err = c.clr.GetClientByID(ctx, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get client by id %s: %w", id, err)
+	}
+
+	if client == nil {
+		return nil, models.ErrClientNotFound
+	}
+
+	return client.ToClientResponse(), nil
+}
+
+func (c *clientService) UpdateClient(ctx context.Context, id string, version int64, name string) (*models.ClientResponse, error) {
+	client, err := c.clr.GetClientWitContactsByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get client with contacts by id %s: %w", id, err)
+	}
+
+	if client == nil {
+		return nil, models.ErrClientNotFound
+	}
+
+	if err := checkVersion(version, client.Version); err != nil {
+		return nil, err
+	}
+
+	before := client.ToSnapshot()
+	client.Name = name
+
+	if err := c.updateClient(ctx, client, before); err != nil {
+		return nil, err
+	}
+
+	return client.ToClientResponse(), nil
+}
+
+func (c *clientService) PatchClient(ctx context.Context, id string, version int64, patch []byte) (*models.ClientResponse, error) {
+	client, err := c.clr.GetClientWitContactsByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get client with contacts by id %s: %w", id, err)
+	}
+
+	if client == nil {
+		return nil, models.ErrClientNotFound
+	}
+
+	if err := checkVersion(version, client.Version); err != nil {
+		return nil, err
+	}
+
+	original, err := jsoniter.Marshal(client.ToUpdateClientPayload())
+	if err != nil {
+		return nil, fmt.Errorf("encode client %s: %w", id, err)
+	}
+
+	merged, err := pkgs.MergePatch(original, patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	var payload models.UpdateClientPayload
+	if err := jsoniter.Unmarshal(merged, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	if err := c.v.Validate(&payload); err != nil {
+		return nil, err
+	}
+
+	before := client.ToSnapshot()
+	client.Name = payload.Name
+
+	if err := c.updateClient(ctx, client, before); err != nil {
+		return nil, err
+	}
+
+	return client.ToClientResponse(), nil
+}
+
+func (c *clientService) DeleteClient(ctx context.Context, id string, version int64) error {
+	client, err := c.clr.GetClientWitContactsByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get client with contacts by id %s: %w", id, err)
+	}
+
+	if client == nil {
+		return models.ErrClientNotFound
+	}
+
+	if err := checkVersion(version, client.Version); err != nil {
+		return err
+	}
+
+	// Postgres armazena timestamps com precisão de microssegundos
+	deletedAt := time.Now().UTC().Truncate(time.Microsecond)
+
+	return c.uow.Do(ctx, func(ctx context.Context) error {
+		if err := c.clr.DeleteClient(ctx, id, client.Version, deletedAt); err != nil {
+			return fmt.Errorf("delete client %s: %w", id, err)
+		}
+
+		if err := c.ctr.DeleteContactsByClientID(ctx, id, deletedAt); err != nil {
+			return fmt.Errorf("delete contacts by client id %s: %w", id, err)
+		}
+
+		if err := c.record(ctx, id, models.AuditActionDelete, client.ToSnapshot(), nil); err != nil {
+			return err
+		}
+
+		for _, contact := range client.Contacts {
+			if err := recordContact(ctx, c.as, contact.ID, id, models.AuditActionDelete, contact.ToContactResponse(), nil); err != nil {
+				return err
+			}
+		}
+
+		return c.ob.Emit(ctx, models.ClientDeleted{ClientID: id, DeletedAt: deletedAt})
+	})
+}
+
+func (c *clientService) RestoreClient(ctx context.Context, id string) (*models.ClientResponse, error) {
+	client, err := c.clr.GetDeletedClientByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get deleted client by id %s: %w", id, err)
+	}
+
+	if client == nil {
+		return nil, models.ErrClientNotFound
+	}
+
+	var restored *models.Client
+	err = c.uow.Do(ctx, func(ctx context.Context) error {
+		if err := c.clr.RestoreClient(ctx, id); err != nil {
+			return fmt.Errorf("restore client %s: %w", id, err)
+		}
+
+		if err := c.ctr.RestoreContactsByClientID(ctx, id, client.DeletedAt.Time); err != nil {
+			return fmt.Errorf("restore contacts by client id %s: %w", id, err)
+		}
+
+		restored, err = c.clr.GetClientWitContactsByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("get client with contacts by id %s: %w", id, err)
+		}
+
+		if restored == nil {
+			return models.ErrClientNotFound
+		}
+
+		if err := c.record(ctx, id, models.AuditActionRestore, client.ToSnapshot(), restored.ToSnapshot()); err != nil {
+			return err
+		}
+
+		event := models.ClientRestored{
+			Client:   restored.ToSnapshot(),
+			Contacts: make([]*models.ContactResponse, 0, len(restored.Contacts)),
+		}
+
+		for _, contact := range restored.Contacts {
+			if err := recordContact(ctx, c.as, contact.ID, id, models.AuditActionRestore, nil, contact.ToContactResponse()); err != nil {
+				return err
+			}
+
+			event.Contacts = append(event.Contacts, contact.ToContactResponse())
+		}
+
+		return c.ob.Emit(ctx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return restored.ToClientResponse(), nil
+}
+
+// updateClient grava o cliente, a alteração na auditoria e o evento ClientUpdated na mesma transação
+func (c *clientService) updateClient(ctx context.Context, client *models.Client, before *models.ClientSnapshot) error {
+	return c.uow.Do(ctx, func(ctx context.Context) error {
+		if err := c.clr.UpdateClient(ctx, client); err != nil {
+			return fmt.Errorf("update client %s: %w", client.ID, err)
+		}
+
+		if err := c.record(ctx, client.ID, models.AuditActionUpdate, before, client.ToSnapshot()); err != nil {
+			return err
+		}
+
+		return c.ob.Emit(ctx, models.ClientUpdated{Client: client.ToSnapshot()})
+	})
+}
+
+func (c *clientService) record(ctx context.Context, id string, action string, before *models.ClientSnapshot, after *models.ClientSnapshot) error {
+	entry := models.AuditEntry{
+		EntityType: models.AuditEntityClient,
+		EntityID:   id,
+		ClientID:   id,
+		Action:     action,
+		Before:     before,
+		After:      after,
+	}
+
+	if err := c.as.Record(ctx, entry); err != nil {
+		return fmt.Errorf("record %s of client %s: %w", action, id, err)
+	}
+
+	return nil
+}
+
+func (c *clientService) GetDeletedClients(ctx context.Context) ([]models.ClientResponse, error) {
+	clients, err := c.clr.GetDeletedClients(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get deleted clients: %w", err)
+	}
+
+	clientResponses := make([]models.ClientResponse, 0, len(clients))
+	for _, client := range clients {
+		clientResponses = append(clientResponses, *client.ToClientResponse())
+	}
+
+	return clientResponses, nil
+}
+
+func (c *clientService) PurgeDeletedClients(ctx context.Context, retention time.Duration) (int64, error) {
+	before := time.Now().UTC().Add(-retention)
+
+	var purged int64
+	err := c.uow.Do(ctx, func(ctx context.Context) error {
+		if _, err := c.ctr.PurgeDeletedContacts(ctx, before); err != nil {
+			return fmt.Errorf("purge deleted contacts: %w", err)
+		}
+
+		count, err := c.clr.PurgeDeletedClients(ctx, before)
+		if err != nil {
+			return fmt.Errorf("purge deleted clients: %w", err)
+		}
+
+		purged = count
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, nil
+}
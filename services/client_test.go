@@ -2,28 +2,43 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/g-villarinho/nubank-challenge/mocks"
 	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func TestCreateClient(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("should create client with contacts successfully", func(t *testing.T) {
+		unitOfWork := new(mocks.UnitOfWorkMock)
 		clientRepo := new(mocks.ClientRepositoryMock)
 		contactRepo := new(mocks.ContactRepositoryMock)
 
 		svc := &clientService{
+			uow: unitOfWork,
 			clr: clientRepo,
 			ctr: contactRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+			m:   newTestMetrics(t),
 		}
 
+		unitOfWork.
+			On("Do", ctx, mock.Anything).
+			Return(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			})
+
 		contacts := []*models.Contact{
 			{
 				ID:    "contact-1",
@@ -55,17 +70,30 @@ func TestCreateClient(t *testing.T) {
 		assert.Equal(t, "Gabriel", resp.Name)
 		assert.Len(t, resp.Contacts, 1)
 		assert.Equal(t, "+5521999999999", resp.Contacts[0].Phone)
+		assert.Equal(t, float64(1), testutil.ToFloat64(svc.m.ClientsCreated))
+		assert.Equal(t, float64(1), testutil.ToFloat64(svc.m.ContactsCreated))
 	})
 
 	t.Run("should create client without contacts", func(t *testing.T) {
+		unitOfWork := new(mocks.UnitOfWorkMock)
 		clientRepo := new(mocks.ClientRepositoryMock)
 		contactRepo := new(mocks.ContactRepositoryMock)
 
 		svc := &clientService{
+			uow: unitOfWork,
 			clr: clientRepo,
 			ctr: contactRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+			m:   newTestMetrics(t),
 		}
 
+		unitOfWork.
+			On("Do", ctx, mock.Anything).
+			Return(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			})
+
 		clientRepo.
 			On("CreateClient", ctx, mock.Anything).
 			Run(func(args mock.Arguments) {
@@ -83,14 +111,25 @@ func TestCreateClient(t *testing.T) {
 	})
 
 	t.Run("should return error if client creation fails", func(t *testing.T) {
+		unitOfWork := new(mocks.UnitOfWorkMock)
 		clientRepo := new(mocks.ClientRepositoryMock)
 		contactRepo := new(mocks.ContactRepositoryMock)
 
 		svc := &clientService{
+			uow: unitOfWork,
 			clr: clientRepo,
 			ctr: contactRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+			m:   newTestMetrics(t),
 		}
 
+		unitOfWork.
+			On("Do", ctx, mock.Anything).
+			Return(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			})
+
 		clientRepo.
 			On("CreateClient", ctx, mock.Anything).
 			Return(errors.New("erro no banco"))
@@ -100,17 +139,29 @@ func TestCreateClient(t *testing.T) {
 		assert.Error(t, err)
 		assert.Nil(t, resp)
 		assert.Contains(t, err.Error(), "create client")
+		assert.Zero(t, testutil.ToFloat64(svc.m.ClientsCreated))
 	})
 
 	t.Run("should return error if creating contacts fails", func(t *testing.T) {
+		unitOfWork := new(mocks.UnitOfWorkMock)
 		clientRepo := new(mocks.ClientRepositoryMock)
 		contactRepo := new(mocks.ContactRepositoryMock)
 
 		svc := &clientService{
+			uow: unitOfWork,
 			clr: clientRepo,
 			ctr: contactRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+			m:   newTestMetrics(t),
 		}
 
+		unitOfWork.
+			On("Do", ctx, mock.Anything).
+			Return(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			})
+
 		contacts := []*models.Contact{
 			{
 				ID:    "c1",
@@ -142,7 +193,7 @@ func TestCreateClient(t *testing.T) {
 func TestGetClientsWithContact(t *testing.T) {
 	ctx := context.Background()
 
-	t.Run("should return list of clients with contacts", func(t *testing.T) {
+	t.Run("should return first page of clients with contacts", func(t *testing.T) {
 		clientRepo := new(mocks.ClientRepositoryMock)
 
 		svc := &clientService{
@@ -151,7 +202,7 @@ func TestGetClientsWithContact(t *testing.T) {
 
 		mockClients := []*models.Client{
 			{
-				ID:        "client-1",
+				ID:        "0b8f3c52-1d2e-4f6a-9b7c-8d9e0f1a2b31",
 				Name:      "Gabriel",
 				CreatedAt: time.Now(),
 				Contacts: []models.Contact{
@@ -159,22 +210,195 @@ func TestGetClientsWithContact(t *testing.T) {
 						ID:        "contact-1",
 						Phone:     "+5521999999999",
 						Email:     "gabriel@gmail.com",
-						ClientID:  "client-1",
+						ClientID:  "0b8f3c52-1d2e-4f6a-9b7c-8d9e0f1a2b31",
 						CreatedAt: time.Now(),
 					},
 				},
 			},
 		}
 
-		clientRepo.On("GetClientsWithContact", ctx).Return(mockClients, nil)
+		clientRepo.
+			On("GetClientsWithContact", ctx, models.ClientListOptions{
+				Limit: models.DefaultPageLimit,
+				Sort:  models.ClientSortCreatedAt,
+			}).
+			Return(mockClients, true, nil)
 
-		resp, err := svc.GetClientsWithContact(ctx)
+		resp, err := svc.GetClientsWithContact(ctx, models.ListClientsQuery{})
 
 		assert.NoError(t, err)
-		assert.Len(t, resp, 1)
-		assert.Equal(t, "Gabriel", resp[0].Name)
-		assert.Equal(t, 1, len(resp[0].Contacts))
-		assert.Equal(t, "gabriel@gmail.com", resp[0].Contacts[0].Email)
+		assert.Len(t, resp.Data, 1)
+		assert.Equal(t, "Gabriel", resp.Data[0].Name)
+		assert.Equal(t, 1, len(resp.Data[0].Contacts))
+		assert.Equal(t, "gabriel@gmail.com", resp.Data[0].Contacts[0].Email)
+		assert.True(t, resp.Meta.HasNext)
+		assert.False(t, resp.Meta.HasPrev)
+		assert.Empty(t, resp.Meta.PrevCursor)
+
+		next, err := models.DecodeCursor(resp.Meta.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, "0b8f3c52-1d2e-4f6a-9b7c-8d9e0f1a2b31", next.ID)
+		assert.False(t, next.Backward)
+	})
+
+	t.Run("should clamp the limit and pass filters and cursor to the repository", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			clr: clientRepo,
+		}
+
+		cursor := &models.Cursor{Sort: "-name", Value: "Gabriel", ID: "0b8f3c52-1d2e-4f6a-9b7c-8d9e0f1a2b31"}
+		createdFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		clientRepo.
+			On("GetClientsWithContact", ctx, models.ClientListOptions{
+				Limit:       models.MaxPageLimit,
+				Sort:        "-name",
+				Cursor:      cursor,
+				NamePrefix:  "Ga",
+				CreatedFrom: createdFrom,
+			}).
+			Return([]*models.Client{{ID: "0b8f3c52-1d2e-4f6a-9b7c-8d9e0f1a2b32", Name: "Gabi"}}, false, nil)
+
+		resp, err := svc.GetClientsWithContact(ctx, models.ListClientsQuery{
+			Cursor:      cursor.Encode(),
+			Limit:       500,
+			Sort:        "-name",
+			Name:        "Ga",
+			CreatedFrom: createdFrom,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.MaxPageLimit, resp.Meta.Limit)
+		assert.False(t, resp.Meta.HasNext)
+		assert.True(t, resp.Meta.HasPrev)
+
+		prev, err := models.DecodeCursor(resp.Meta.PrevCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, &models.Cursor{Sort: "-name", Value: "Gabi", ID: "0b8f3c52-1d2e-4f6a-9b7c-8d9e0f1a2b32", Backward: true}, prev)
+	})
+
+	t.Run("should always have a next page when paging backwards", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			clr: clientRepo,
+		}
+
+		cursor := &models.Cursor{Sort: "name", Value: "Gabriel", ID: "0b8f3c52-1d2e-4f6a-9b7c-8d9e0f1a2b33", Backward: true}
+
+		clientRepo.
+			On("GetClientsWithContact", ctx, mock.Anything).
+			Return([]*models.Client{{ID: "0b8f3c52-1d2e-4f6a-9b7c-8d9e0f1a2b31", Name: "Ana"}, {ID: "0b8f3c52-1d2e-4f6a-9b7c-8d9e0f1a2b32", Name: "Bruno"}}, false, nil)
+
+		resp, err := svc.GetClientsWithContact(ctx, models.ListClientsQuery{Cursor: cursor.Encode(), Sort: "name"})
+
+		assert.NoError(t, err)
+		assert.True(t, resp.Meta.HasNext)
+		assert.False(t, resp.Meta.HasPrev)
+
+		next, err := models.DecodeCursor(resp.Meta.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, "0b8f3c52-1d2e-4f6a-9b7c-8d9e0f1a2b32", next.ID)
+	})
+
+	t.Run("should return validation error on invalid cursor", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			clr: clientRepo,
+		}
+
+		resp, err := svc.GetClientsWithContact(ctx, models.ListClientsQuery{Cursor: "not-a-cursor"})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrValidation)
+		clientRepo.AssertNotCalled(t, "GetClientsWithContact", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return validation error when cursor id is not a uuid", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			clr: clientRepo,
+		}
+
+		cursor := &models.Cursor{Sort: models.ClientSortCreatedAt, Value: "2025-04-20T10:00:00Z", ID: "client-1' OR 1=1"}
+
+		resp, err := svc.GetClientsWithContact(ctx, models.ListClientsQuery{Cursor: cursor.Encode()})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, models.NewValidationError("cursor", "is invalid"), err)
+		clientRepo.AssertNotCalled(t, "GetClientsWithContact", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return validation error when cursor was issued for another sort", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			clr: clientRepo,
+		}
+
+		cursor := &models.Cursor{Sort: "name", Value: "Gabriel", ID: "0b8f3c52-1d2e-4f6a-9b7c-8d9e0f1a2b31"}
+
+		resp, err := svc.GetClientsWithContact(ctx, models.ListClientsQuery{Cursor: cursor.Encode(), Sort: "-created_at"})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrValidation)
+	})
+
+	t.Run("should restrict the page to clients owning the contact", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		svc := &clientService{
+			clr: clientRepo,
+			ctr: contactRepo,
+		}
+
+		contactRepo.
+			On("SearchContacts", ctx, "gabriel@gmail.com", "+55 21 99999-9999").
+			Return([]*models.Contact{
+				{ID: "contact-1", ClientID: "0b8f3c52-1d2e-4f6a-9b7c-8d9e0f1a2b31"},
+				{ID: "contact-2", ClientID: "0b8f3c52-1d2e-4f6a-9b7c-8d9e0f1a2b31"},
+				{ID: "contact-3", ClientID: "0b8f3c52-1d2e-4f6a-9b7c-8d9e0f1a2b32"},
+			}, nil)
+		clientRepo.
+			On("GetClientsWithContact", ctx, models.ClientListOptions{
+				Limit:     models.DefaultPageLimit,
+				Sort:      models.ClientSortCreatedAt,
+				ClientIDs: []string{"0b8f3c52-1d2e-4f6a-9b7c-8d9e0f1a2b31", "0b8f3c52-1d2e-4f6a-9b7c-8d9e0f1a2b32"},
+			}).
+			Return([]*models.Client{{ID: "0b8f3c52-1d2e-4f6a-9b7c-8d9e0f1a2b31"}, {ID: "0b8f3c52-1d2e-4f6a-9b7c-8d9e0f1a2b32"}}, false, nil)
+
+		resp, err := svc.GetClientsWithContact(ctx, models.ListClientsQuery{
+			ContactEmail: "gabriel@gmail.com",
+			ContactPhone: "+55 21 99999-9999",
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Data, 2)
+		clientRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return an empty page when no contact matches", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		svc := &clientService{
+			clr: clientRepo,
+			ctr: contactRepo,
+		}
+
+		contactRepo.On("SearchContacts", ctx, "nobody@gmail.com", "").Return([]*models.Contact{}, nil)
+
+		resp, err := svc.GetClientsWithContact(ctx, models.ListClientsQuery{ContactEmail: "nobody@gmail.com"})
+
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Data)
+		assert.False(t, resp.Meta.HasNext)
+		clientRepo.AssertNotCalled(t, "GetClientsWithContact", mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error if repository fails", func(t *testing.T) {
@@ -184,9 +408,9 @@ func TestGetClientsWithContact(t *testing.T) {
 			clr: clientRepo,
 		}
 
-		clientRepo.On("GetClientsWithContact", ctx).Return(nil, errors.New("db failure"))
+		clientRepo.On("GetClientsWithContact", ctx, mock.Anything).Return(nil, false, errors.New("db failure"))
 
-		resp, err := svc.GetClientsWithContact(ctx)
+		resp, err := svc.GetClientsWithContact(ctx, models.ListClientsQuery{})
 
 		assert.Nil(t, resp)
 		assert.Contains(t, err.Error(), "get clients with contact")
@@ -284,3 +508,536 @@ func TestGetClientContactsByID(t *testing.T) {
 		assert.Contains(t, err.Error(), "get contacts by client id")
 	})
 }
+
+func TestUpdateClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should update client name", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			uow: newUnitOfWorkMock(),
+			clr: clientRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		client := &models.Client{ID: "client-123", Name: "Gabriel", Version: 2}
+
+		clientRepo.On("GetClientWitContactsByID", ctx, "client-123").Return(client, nil)
+		clientRepo.
+			On("UpdateClient", ctx, mock.MatchedBy(func(c *models.Client) bool {
+				return c.ID == "client-123" && c.Name == "Gabriel Villarinho" && c.Version == 2
+			})).
+			Run(func(args mock.Arguments) {
+				arg := args.Get(1).(*models.Client)
+				arg.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+			}).
+			Return(nil)
+
+		resp, err := svc.UpdateClient(ctx, "client-123", 2, "Gabriel Villarinho")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Gabriel Villarinho", resp.Name)
+		assert.NotNil(t, resp.UpdatedAt)
+		clientRepo.AssertExpectations(t)
+	})
+
+	t.Run("should record the change in the audit log", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+		auditService := newAuditServiceMock()
+
+		svc := &clientService{
+			uow: newUnitOfWorkMock(),
+			clr: clientRepo,
+			as:  auditService,
+			ob:  newOutboxServiceMock(),
+		}
+
+		clientRepo.
+			On("GetClientWitContactsByID", ctx, "client-123").
+			Return(&models.Client{ID: "client-123", Name: "Gabriel", Version: 2}, nil)
+		clientRepo.On("UpdateClient", ctx, mock.Anything).Return(nil)
+
+		_, err := svc.UpdateClient(ctx, "client-123", 2, "Gabriel Villarinho")
+
+		assert.NoError(t, err)
+		auditService.AssertCalled(t, "Record", ctx, mock.MatchedBy(func(e models.AuditEntry) bool {
+			before := e.Before.(*models.ClientSnapshot)
+			after := e.After.(*models.ClientSnapshot)
+			return e.EntityType == models.AuditEntityClient && e.EntityID == "client-123" &&
+				e.Action == models.AuditActionUpdate && before.Name == "Gabriel" && after.Name == "Gabriel Villarinho"
+		}))
+	})
+
+	t.Run("should return error if the audit log cannot be recorded", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+		auditService := new(mocks.AuditServiceMock)
+
+		svc := &clientService{
+			uow: newUnitOfWorkMock(),
+			clr: clientRepo,
+			as:  auditService,
+			ob:  newOutboxServiceMock(),
+		}
+
+		clientRepo.
+			On("GetClientWitContactsByID", ctx, "client-123").
+			Return(&models.Client{ID: "client-123", Name: "Gabriel", Version: 2}, nil)
+		clientRepo.On("UpdateClient", ctx, mock.Anything).Return(nil)
+		auditService.On("Record", ctx, mock.Anything).Return(errors.New("db failure"))
+
+		resp, err := svc.UpdateClient(ctx, "client-123", 2, "Gabriel Villarinho")
+
+		assert.Nil(t, resp)
+		assert.EqualError(t, err, "record update of client client-123: db failure")
+	})
+
+	t.Run("should return error if client not found", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			uow: newUnitOfWorkMock(),
+			clr: clientRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		clientRepo.On("GetClientWitContactsByID", ctx, "missing-client").Return(nil, nil)
+
+		resp, err := svc.UpdateClient(ctx, "missing-client", AnyVersion, "Gabriel")
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrClientNotFound)
+		clientRepo.AssertNotCalled(t, "UpdateClient", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error if repository fails", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			uow: newUnitOfWorkMock(),
+			clr: clientRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		clientRepo.On("GetClientWitContactsByID", ctx, "client-123").Return(&models.Client{ID: "client-123"}, nil)
+		clientRepo.On("UpdateClient", ctx, mock.Anything).Return(errors.New("db error"))
+
+		resp, err := svc.UpdateClient(ctx, "client-123", AnyVersion, "Gabriel")
+
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "update client")
+	})
+
+	t.Run("should fail the precondition when the version does not match", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			uow: newUnitOfWorkMock(),
+			clr: clientRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		clientRepo.On("GetClientWitContactsByID", ctx, "client-123").Return(&models.Client{ID: "client-123", Version: 3}, nil)
+
+		resp, err := svc.UpdateClient(ctx, "client-123", 2, "Gabriel")
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrPreconditionFailed)
+		clientRepo.AssertNotCalled(t, "UpdateClient", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPatchClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should apply merge patch to client", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			uow: newUnitOfWorkMock(),
+			v:   pkgs.NewValidator(),
+			clr: clientRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		client := &models.Client{ID: "client-123", Name: "Gabriel"}
+
+		clientRepo.On("GetClientWitContactsByID", ctx, "client-123").Return(client, nil)
+		clientRepo.
+			On("UpdateClient", ctx, mock.MatchedBy(func(c *models.Client) bool {
+				return c.Name == "Caio Gabriel"
+			})).
+			Return(nil)
+
+		resp, err := svc.PatchClient(ctx, "client-123", AnyVersion, []byte(`{"name":"Caio Gabriel"}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Caio Gabriel", resp.Name)
+		clientRepo.AssertExpectations(t)
+	})
+
+	t.Run("should keep fields absent from the patch", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			uow: newUnitOfWorkMock(),
+			v:   pkgs.NewValidator(),
+			clr: clientRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		client := &models.Client{ID: "client-123", Name: "Gabriel"}
+
+		clientRepo.On("GetClientWitContactsByID", ctx, "client-123").Return(client, nil)
+		clientRepo.On("UpdateClient", ctx, mock.Anything).Return(nil)
+
+		resp, err := svc.PatchClient(ctx, "client-123", AnyVersion, []byte(`{}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Gabriel", resp.Name)
+	})
+
+	t.Run("should return validation error when removing a required field", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			uow: newUnitOfWorkMock(),
+			v:   pkgs.NewValidator(),
+			clr: clientRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		clientRepo.On("GetClientWitContactsByID", ctx, "client-123").Return(&models.Client{ID: "client-123", Name: "Gabriel"}, nil)
+
+		resp, err := svc.PatchClient(ctx, "client-123", AnyVersion, []byte(`{"name":null}`))
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrValidation)
+		clientRepo.AssertNotCalled(t, "UpdateClient", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return invalid payload on malformed patch", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			uow: newUnitOfWorkMock(),
+			v:   pkgs.NewValidator(),
+			clr: clientRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		clientRepo.On("GetClientWitContactsByID", ctx, "client-123").Return(&models.Client{ID: "client-123", Name: "Gabriel"}, nil)
+
+		resp, err := svc.PatchClient(ctx, "client-123", AnyVersion, []byte(`{"name":`))
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrInvalidPayload)
+	})
+
+	t.Run("should return error if client not found", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			uow: newUnitOfWorkMock(),
+			v:   pkgs.NewValidator(),
+			clr: clientRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		clientRepo.On("GetClientWitContactsByID", ctx, "missing-client").Return(nil, nil)
+
+		resp, err := svc.PatchClient(ctx, "missing-client", AnyVersion, []byte(`{"name":"Caio"}`))
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrClientNotFound)
+	})
+
+	t.Run("should fail the precondition when the version does not match", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			uow: newUnitOfWorkMock(),
+			v:   pkgs.NewValidator(),
+			clr: clientRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		clientRepo.On("GetClientWitContactsByID", ctx, "client-123").Return(&models.Client{ID: "client-123", Name: "Gabriel", Version: 3}, nil)
+
+		resp, err := svc.PatchClient(ctx, "client-123", 1, []byte(`{"name":"Caio"}`))
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrPreconditionFailed)
+		clientRepo.AssertNotCalled(t, "UpdateClient", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeleteClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should soft delete client and its contacts with the same timestamp", func(t *testing.T) {
+		unitOfWork := new(mocks.UnitOfWorkMock)
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		svc := &clientService{
+			uow: unitOfWork,
+			clr: clientRepo,
+			ctr: contactRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		unitOfWork.
+			On("Do", ctx, mock.Anything).
+			Return(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			})
+
+		var deletedAt time.Time
+		clientRepo.On("GetClientWitContactsByID", ctx, "client-123").Return(&models.Client{ID: "client-123", Version: 2}, nil)
+		clientRepo.
+			On("DeleteClient", ctx, "client-123", int64(2), mock.AnythingOfType("time.Time")).
+			Run(func(args mock.Arguments) {
+				deletedAt = args.Get(3).(time.Time)
+			}).
+			Return(nil)
+		contactRepo.
+			On("DeleteContactsByClientID", ctx, "client-123", mock.MatchedBy(func(at time.Time) bool {
+				return at.Equal(deletedAt)
+			})).
+			Return(nil)
+
+		err := svc.DeleteClient(ctx, "client-123", 2)
+
+		assert.NoError(t, err)
+		clientRepo.AssertExpectations(t)
+		contactRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error if client not found", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		svc := &clientService{
+			uow: newUnitOfWorkMock(),
+			clr: clientRepo,
+			ctr: contactRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		clientRepo.On("GetClientWitContactsByID", ctx, "missing-client").Return(nil, nil)
+
+		err := svc.DeleteClient(ctx, "missing-client", AnyVersion)
+
+		assert.ErrorIs(t, err, models.ErrClientNotFound)
+		clientRepo.AssertNotCalled(t, "DeleteClient", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		contactRepo.AssertNotCalled(t, "DeleteContactsByClientID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should fail the precondition when the version does not match", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			uow: newUnitOfWorkMock(),
+			clr: clientRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		clientRepo.On("GetClientWitContactsByID", ctx, "client-123").Return(&models.Client{ID: "client-123", Version: 2}, nil)
+
+		err := svc.DeleteClient(ctx, "client-123", 1)
+
+		assert.ErrorIs(t, err, models.ErrPreconditionFailed)
+		clientRepo.AssertNotCalled(t, "DeleteClient", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return the client with its contacts and version", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			clr: clientRepo,
+		}
+
+		clientRepo.
+			On("GetClientWitContactsByID", ctx, "client-123").
+			Return(&models.Client{ID: "client-123", Name: "Gabriel", Version: 4, Contacts: []models.Contact{{ID: "contact-1"}}}, nil)
+
+		resp, err := svc.GetClient(ctx, "client-123", true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), resp.Version)
+		assert.Len(t, resp.Contacts, 1)
+	})
+
+	t.Run("should not load contacts when they are not included", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			clr: clientRepo,
+		}
+
+		clientRepo.
+			On("GetClientByID", ctx, "client-123").
+			Return(&models.Client{ID: "client-123", Name: "Gabriel", Version: 4}, nil)
+
+		resp, err := svc.GetClient(ctx, "client-123", false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Gabriel", resp.Name)
+		assert.Empty(t, resp.Contacts)
+		clientRepo.AssertNotCalled(t, "GetClientWitContactsByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error if client not found", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			clr: clientRepo,
+		}
+
+		clientRepo.On("GetClientWitContactsByID", ctx, "missing-client").Return(nil, nil)
+
+		resp, err := svc.GetClient(ctx, "missing-client", true)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrClientNotFound)
+	})
+}
+
+func TestRestoreClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should restore client and the contacts deleted with it", func(t *testing.T) {
+		unitOfWork := new(mocks.UnitOfWorkMock)
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		svc := &clientService{
+			uow: unitOfWork,
+			clr: clientRepo,
+			ctr: contactRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		deletedAt := time.Now().UTC()
+
+		unitOfWork.
+			On("Do", ctx, mock.Anything).
+			Return(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			})
+		clientRepo.
+			On("GetDeletedClientByID", ctx, "client-123").
+			Return(&models.Client{ID: "client-123", DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}}, nil)
+		clientRepo.On("RestoreClient", ctx, "client-123").Return(nil)
+		contactRepo.On("RestoreContactsByClientID", ctx, "client-123", deletedAt).Return(nil)
+		clientRepo.
+			On("GetClientWitContactsByID", ctx, "client-123").
+			Return(&models.Client{ID: "client-123", Name: "Gabriel"}, nil)
+
+		resp, err := svc.RestoreClient(ctx, "client-123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Gabriel", resp.Name)
+		assert.Nil(t, resp.DeletedAt)
+		clientRepo.AssertExpectations(t)
+		contactRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error if deleted client not found", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+
+		svc := &clientService{
+			uow: newUnitOfWorkMock(),
+			clr: clientRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		clientRepo.On("GetDeletedClientByID", ctx, "client-123").Return(nil, nil)
+
+		resp, err := svc.RestoreClient(ctx, "client-123")
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrClientNotFound)
+	})
+}
+
+func TestPurgeDeletedClients(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should purge contacts and clients deleted before the retention window", func(t *testing.T) {
+		unitOfWork := new(mocks.UnitOfWorkMock)
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		svc := &clientService{
+			uow: unitOfWork,
+			clr: clientRepo,
+			ctr: contactRepo,
+		}
+
+		retention := 30 * 24 * time.Hour
+		beforeWindow := mock.MatchedBy(func(before time.Time) bool {
+			return time.Since(before) >= retention && time.Since(before) < retention+time.Minute
+		})
+
+		unitOfWork.
+			On("Do", ctx, mock.Anything).
+			Return(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			})
+		contactRepo.On("PurgeDeletedContacts", ctx, beforeWindow).Return(int64(3), nil)
+		clientRepo.On("PurgeDeletedClients", ctx, beforeWindow).Return(int64(2), nil)
+
+		purged, err := svc.PurgeDeletedClients(ctx, retention)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), purged)
+		clientRepo.AssertExpectations(t)
+		contactRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error if purging contacts fails", func(t *testing.T) {
+		unitOfWork := new(mocks.UnitOfWorkMock)
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		svc := &clientService{
+			uow: unitOfWork,
+			clr: clientRepo,
+			ctr: contactRepo,
+		}
+
+		unitOfWork.
+			On("Do", ctx, mock.Anything).
+			Return(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			})
+		contactRepo.On("PurgeDeletedContacts", ctx, mock.Anything).Return(int64(0), errors.New("db error"))
+
+		purged, err := svc.PurgeDeletedClients(ctx, time.Hour)
+
+		assert.Zero(t, purged)
+		assert.Contains(t, err.Error(), "purge deleted contacts")
+		clientRepo.AssertNotCalled(t, "PurgeDeletedClients", mock.Anything, mock.Anything)
+	})
+}
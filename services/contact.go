@@ -3,7 +3,12 @@ package services
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/g-villarinho/nubank-challenge/metrics"
 	"github.com/g-villarinho/nubank-challenge/models"
 	"github.com/g-villarinho/nubank-challenge/pkgs"
 	"github.com/g-villarinho/nubank-challenge/repositories"
@@ -11,15 +16,31 @@ import (
 
 type ContactService interface {
 	CreateContact(ctx context.Context, phone string, email string, clientId string) (*models.ContactResponse, error)
+	GetContactByID(ctx context.Context, id string) (*models.ContactResponse, error)
+	UpdateContact(ctx context.Context, id string, version int64, phone string, email string) (*models.ContactResponse, error)
+	PatchContact(ctx context.Context, id string, version int64, patch []byte) (*models.ContactResponse, error)
+	DeleteContact(ctx context.Context, id string, version int64) error
+	TransferContact(ctx context.Context, id string, clientId string) (*models.ContactResponse, error)
+	SearchContacts(ctx context.Context, email string, phone string) ([]models.ContactResponse, error)
 }
 
 type contactService struct {
 	di  *pkgs.Di
+	v   *pkgs.Validator
+	uow repositories.UnitOfWork
 	clr repositories.ClientRepository
 	ctr repositories.ContactRepository
+	as  AuditService
+	ob  OutboxService
+	m   *metrics.Metrics
 }
 
 func NewContactService(di *pkgs.Di) (ContactService, error) {
+	unitOfWork, err := pkgs.Invoke[repositories.UnitOfWork](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke repositories.unit_of_work: %w", err)
+	}
+
 	clientRepository, err := pkgs.Invoke[repositories.ClientRepository](di)
 	if err != nil {
 		return nil, fmt.Errorf("invoke repositories.client: %w", err)
@@ -30,32 +51,310 @@ func NewContactService(di *pkgs.Di) (ContactService, error) {
 		return nil, fmt.Errorf("invoke repositories.contact: %w", err)
 	}
 
-	return &contactService{
+	auditService, err := pkgs.Invoke[AuditService](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke services.audit: %w", err)
+	}
+
+	outboxService, err := pkgs.Invoke[OutboxService](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke services.outbox: %w", err)
+	}
+
+	m, err := pkgs.Invoke[*metrics.Metrics](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke metrics: %w", err)
+	}
+
+	svc := &contactService{
 		di:  di,
+		v:   pkgs.NewValidator(),
+		uow: unitOfWork,
 		clr: clientRepository,
 		ctr: contactRepository,
-	}, nil
+		as:  auditService,
+		ob:  outboxService,
+		m:   m,
+	}
+
+	return &tracedContactService{next: svc}, nil
 }
 
 func (c *contactService) CreateContact(ctx context.Context, phone string, email string, clientId string) (*models.ContactResponse, error) {
-	client, err := c.clr.GetClientByID(ctx, clientId)
+	contact := &models.Contact{
+		Phone:    phone,
+		Email:    email,
+		ClientID: clientId,
+	}
+
+	err := c.uow.Do(ctx, func(ctx context.Context) error {
+		// Incrementar a versão trava a linha do cliente até o commit e falha se ele já foi
+		// excluído, então a exclusão concorrente não deixa o contato órfão
+		if err := c.touchClient(ctx, clientId); err != nil {
+			return err
+		}
+
+		if err := c.ctr.CreateContact(ctx, contact); err != nil {
+			return fmt.Errorf("create contact: %w", err)
+		}
+
+		if err := recordContact(ctx, c.as, contact.ID, clientId, models.AuditActionCreate, nil, contact.ToContactResponse()); err != nil {
+			return err
+		}
+
+		return c.ob.Emit(ctx, models.ContactAdded{Contact: contact.ToContactResponse()})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.m.ContactsCreated.Inc()
+
+	return contact.ToContactResponse(), nil
+}
+
+func (c *contactService) GetContactByID(ctx context.Context, id string) (*models.ContactResponse, error) {
+	contact, err := c.getContact(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return contact.ToContactResponse(), nil
+}
+
+func (c *contactService) UpdateContact(ctx context.Context, id string, version int64, phone string, email string) (*models.ContactResponse, error) {
+	contact, err := c.getContact(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkVersion(version, contact.Version); err != nil {
+		return nil, err
+	}
+
+	before := contact.ToContactResponse()
+	contact.Phone = phone
+	contact.Email = email
+
+	if err := c.updateContact(ctx, contact, before); err != nil {
+		return nil, err
+	}
+
+	return contact.ToContactResponse(), nil
+}
+
+func (c *contactService) PatchContact(ctx context.Context, id string, version int64, patch []byte) (*models.ContactResponse, error) {
+	contact, err := c.getContact(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("get client by id %s: %w", clientId, err)
+		return nil, err
+	}
+
+	if err := checkVersion(version, contact.Version); err != nil {
+		return nil, err
+	}
+
+	original, err := jsoniter.Marshal(contact.ToUpdateContactPayload())
+	if err != nil {
+		return nil, fmt.Errorf("encode contact %s: %w", id, err)
+	}
+
+	merged, err := pkgs.MergePatch(original, patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	var payload models.UpdateContactPayload
+	if err := jsoniter.Unmarshal(merged, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+
+	if err := c.v.Validate(&payload); err != nil {
+		return nil, err
+	}
+
+	before := contact.ToContactResponse()
+	contact.Phone = payload.Phone
+	contact.Email = payload.Email
+
+	if err := c.updateContact(ctx, contact, before); err != nil {
+		return nil, err
+	}
+
+	return contact.ToContactResponse(), nil
+}
+
+func (c *contactService) DeleteContact(ctx context.Context, id string, version int64) error {
+	contact, err := c.getContact(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := checkVersion(version, contact.Version); err != nil {
+		return err
+	}
+
+	return c.uow.Do(ctx, func(ctx context.Context) error {
+		if err := c.ctr.DeleteContact(ctx, id, contact.Version); err != nil {
+			return fmt.Errorf("delete contact %s: %w", id, err)
+		}
+
+		if err := recordContact(ctx, c.as, id, contact.ClientID, models.AuditActionDelete, contact.ToContactResponse(), nil); err != nil {
+			return err
+		}
+
+		if err := c.touchClient(ctx, contact.ClientID); err != nil {
+			return err
+		}
+
+		return c.ob.Emit(ctx, models.ContactRemoved{ContactID: id, ClientID: contact.ClientID})
+	})
+}
+
+func (c *contactService) TransferContact(ctx context.Context, id string, clientId string) (*models.ContactResponse, error) {
+	contact, err := c.getContact(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Transferir para o próprio dono não move nada, mas travaria o cliente, mudaria a versão dele
+	// e registraria uma transferência na auditoria e no outbox
+	if strings.EqualFold(contact.ClientID, clientId) {
+		return nil, models.NewValidationError("clientId", "must differ from the current client")
+	}
+
+	before := contact.ToContactResponse()
+	previousClientID := contact.ClientID
+	contact.ClientID = clientId
+
+	err = c.uow.Do(ctx, func(ctx context.Context) error {
+		// Os dois clientes são travados antes da transferência, e em ordem de ID para que
+		// transferências em sentidos opostos não se bloqueiem. Um destino excluído falha aqui.
+		affected := []string{previousClientID, clientId}
+		slices.Sort(affected)
+		for _, clientID := range affected {
+			if err := c.touchClient(ctx, clientID); err != nil {
+				return err
+			}
+		}
+
+		if err := c.ctr.UpdateContact(ctx, contact); err != nil {
+			return fmt.Errorf("transfer contact %s to client %s: %w", id, clientId, err)
+		}
+
+		// A transferência entra no histórico dos dois clientes
+		after := contact.ToContactResponse()
+		for _, clientID := range []string{previousClientID, clientId} {
+			if err := recordContact(ctx, c.as, id, clientID, models.AuditActionTransfer, before, after); err != nil {
+				return err
+			}
+		}
+
+		return c.ob.Emit(ctx, models.ContactTransferred{Contact: after, FromClientID: previousClientID, ToClientID: clientId})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return contact.ToContactResponse(), nil
+}
+
+func (c *contactService) SearchContacts(ctx context.Context, email string, phone string) ([]models.ContactResponse, error) {
+	contacts, err := c.ctr.SearchContacts(ctx, email, phone)
+	if err != nil {
+		return nil, fmt.Errorf("search contacts: %w", err)
+	}
+
+	response := make([]models.ContactResponse, 0, len(contacts))
+	for _, contact := range contacts {
+		response = append(response, *contact.ToContactResponse())
+	}
+
+	return response, nil
+}
+
+// updateContact grava o contato, registra a alteração na auditoria, emite o evento
+// ContactUpdated e incrementa a versão do cliente ao qual ele pertence
+func (c *contactService) updateContact(ctx context.Context, contact *models.Contact, before *models.ContactResponse) error {
+	return c.uow.Do(ctx, func(ctx context.Context) error {
+		if err := c.ctr.UpdateContact(ctx, contact); err != nil {
+			return fmt.Errorf("update contact %s: %w", contact.ID, err)
+		}
+
+		if err := recordContact(ctx, c.as, contact.ID, contact.ClientID, models.AuditActionUpdate, before, contact.ToContactResponse()); err != nil {
+			return err
+		}
+
+		if err := c.touchClient(ctx, contact.ClientID); err != nil {
+			return err
+		}
+
+		return c.ob.Emit(ctx, models.ContactUpdated{Contact: contact.ToContactResponse()})
+	})
+}
+
+// touchClient incrementa a versão do cliente, bloqueando sua linha até o fim da transação. Os
+// eventos são emitidos depois dele para que a ordem no outbox siga a ordem das alterações do cliente.
+func (c *contactService) touchClient(ctx context.Context, clientID string) error {
+	if err := c.clr.TouchClient(ctx, clientID); err != nil {
+		return fmt.Errorf("touch client %s: %w", clientID, err)
+	}
+
+	return nil
+}
+
+// getContact busca o contato e garante que o cliente ao qual ele pertence ainda existe
+func (c *contactService) getContact(ctx context.Context, id string) (*models.Contact, error) {
+	contact, err := c.ctr.GetContactByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get contact by id %s: %w", id, err)
+	}
+
+	if contact == nil {
+		return nil, models.ErrContactNotFound
+	}
+
+	client, err := c.clr.GetClientByID(ctx, contact.ClientID)
+	if err != nil {
+		return nil, fmt.Errorf("get client by id %s: %w", contact.ClientID, err)
 	}
 
 	if client == nil {
 		return nil, models.ErrClientNotFound
 	}
 
-	contact := &models.Contact{
-		Phone:    phone,
-		Email:    email,
-		ClientID: clientId,
+	return contact, nil
+}
+
+func recordContact(ctx context.Context, as AuditService, id string, clientID string, action string, before *models.ContactResponse, after *models.ContactResponse) error {
+	entry := models.AuditEntry{
+		EntityType: models.AuditEntityContact,
+		EntityID:   id,
+		ClientID:   clientID,
+		Action:     action,
+		Before:     contactSnapshot(before),
+		After:      contactSnapshot(after),
 	}
 
-	if err := c.ctr.CreateContact(ctx, contact); err != nil {
-		return nil, fmt.Errorf("create contact: %w", err)
+	if err := as.Record(ctx, entry); err != nil {
+		return fmt.Errorf("record %s of contact %s: %w", action, id, err)
 	}
 
-	return contact.ToContactResponse(), nil
+	return nil
+}
+
+// contactSnapshot troca o email e o telefone do contato pelos hashes guardados na auditoria
+func contactSnapshot(contact *models.ContactResponse) *models.ContactSnapshot {
+	if contact == nil {
+		return nil
+	}
+
+	return &models.ContactSnapshot{
+		ID:        contact.ID,
+		ClientID:  contact.ClientID,
+		EmailHash: pkgs.HashPII(pkgs.NormalizeEmail(contact.Email)),
+		PhoneHash: pkgs.HashPII(pkgs.NormalizePhone(contact.Phone)),
+		Version:   contact.Version,
+		CreatedAt: contact.CreatedAt,
+		UpdatedAt: contact.UpdatedAt,
+	}
 }
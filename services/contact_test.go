@@ -5,10 +5,15 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/g-villarinho/nubank-challenge/metrics"
 	"github.com/g-villarinho/nubank-challenge/mocks"
 	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestContactService_CreateContact(t *testing.T) {
@@ -19,16 +24,15 @@ func TestContactService_CreateContact(t *testing.T) {
 		contactRepo := new(mocks.ContactRepositoryMock)
 
 		service := &contactService{
+			uow: newUnitOfWorkMock(),
 			clr: clientRepo,
 			ctr: contactRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+			m:   newTestMetrics(t),
 		}
 
-		client := &models.Client{ID: "client-123", Name: "Gabriel"}
-
-		clientRepo.
-			On("GetClientByID", ctx, "client-123").
-			Return(client, nil)
-
+		clientRepo.On("TouchClient", ctx, "client-123").Return(nil)
 		contactRepo.
 			On("CreateContact", ctx, mock.MatchedBy(func(c *models.Contact) bool {
 				return c.Phone == "123456789" && c.Email == "test@example.com" && c.ClientID == "client-123"
@@ -41,25 +45,30 @@ func TestContactService_CreateContact(t *testing.T) {
 		assert.NotNil(t, result)
 		assert.Equal(t, "123456789", result.Phone)
 		assert.Equal(t, "test@example.com", result.Email)
+		assert.Equal(t, float64(1), testutil.ToFloat64(service.m.ContactsCreated))
+		clientRepo.AssertExpectations(t)
+		contactRepo.AssertExpectations(t)
 	})
 
-	t.Run("should return error if client not found", func(t *testing.T) {
+	t.Run("should return error if client is gone when the transaction locks it", func(t *testing.T) {
 		clientRepo := new(mocks.ClientRepositoryMock)
 		contactRepo := new(mocks.ContactRepositoryMock)
 
 		service := &contactService{
+			uow: newUnitOfWorkMock(),
 			clr: clientRepo,
 			ctr: contactRepo,
+			m:   newTestMetrics(t),
 		}
 
-		clientRepo.
-			On("GetClientByID", ctx, "missing-client").
-			Return(nil, nil)
+		clientRepo.On("TouchClient", ctx, "missing-client").Return(models.ErrClientNotFound)
 
 		result, err := service.CreateContact(ctx, "123456789", "test@example.com", "missing-client")
 
 		assert.ErrorIs(t, err, models.ErrClientNotFound)
 		assert.Nil(t, result)
+		assert.Zero(t, testutil.ToFloat64(service.m.ContactsCreated))
+		contactRepo.AssertNotCalled(t, "CreateContact", mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when client repo fails", func(t *testing.T) {
@@ -67,19 +76,17 @@ func TestContactService_CreateContact(t *testing.T) {
 		contactRepo := new(mocks.ContactRepositoryMock)
 
 		service := &contactService{
+			uow: newUnitOfWorkMock(),
 			clr: clientRepo,
 			ctr: contactRepo,
 		}
 
-		clientRepo.
-			On("GetClientByID", ctx, "client-error").
-			Return(nil, errors.New("db failure"))
+		clientRepo.On("TouchClient", ctx, "client-error").Return(errors.New("db failure"))
 
 		result, err := service.CreateContact(ctx, "123456789", "test@example.com", "client-error")
 
-		assert.Error(t, err)
+		assert.EqualError(t, err, "touch client client-error: db failure")
 		assert.Nil(t, result)
-		assert.Contains(t, err.Error(), "get client by id")
 	})
 
 	t.Run("should return error when creating contact fails", func(t *testing.T) {
@@ -87,16 +94,15 @@ func TestContactService_CreateContact(t *testing.T) {
 		contactRepo := new(mocks.ContactRepositoryMock)
 
 		service := &contactService{
+			uow: newUnitOfWorkMock(),
 			clr: clientRepo,
 			ctr: contactRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+			m:   newTestMetrics(t),
 		}
 
-		client := &models.Client{ID: "client-123", Name: "Gabriel"}
-
-		clientRepo.
-			On("GetClientByID", ctx, "client-123").
-			Return(client, nil)
-
+		clientRepo.On("TouchClient", ctx, "client-123").Return(nil)
 		contactRepo.
 			On("CreateContact", ctx, mock.Anything).
 			Return(errors.New("create contact error"))
@@ -108,3 +114,493 @@ func TestContactService_CreateContact(t *testing.T) {
 		assert.Contains(t, err.Error(), "create contact")
 	})
 }
+
+func TestContactService_GetContactByID(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return contact", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		service := &contactService{
+			clr: clientRepo,
+			ctr: contactRepo,
+		}
+
+		contactRepo.
+			On("GetContactByID", ctx, "contact-1").
+			Return(&models.Contact{ID: "contact-1", Email: "test@example.com", ClientID: "client-123"}, nil)
+		clientRepo.
+			On("GetClientByID", ctx, "client-123").
+			Return(&models.Client{ID: "client-123"}, nil)
+
+		result, err := service.GetContactByID(ctx, "contact-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "contact-1", result.ID)
+		assert.Equal(t, "client-123", result.ClientID)
+	})
+
+	t.Run("should return error if contact not found", func(t *testing.T) {
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		service := &contactService{
+			ctr: contactRepo,
+		}
+
+		contactRepo.On("GetContactByID", ctx, "missing-contact").Return(nil, nil)
+
+		result, err := service.GetContactByID(ctx, "missing-contact")
+
+		assert.ErrorIs(t, err, models.ErrContactNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should return error if client of the contact not found", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		service := &contactService{
+			clr: clientRepo,
+			ctr: contactRepo,
+		}
+
+		contactRepo.
+			On("GetContactByID", ctx, "contact-1").
+			Return(&models.Contact{ID: "contact-1", ClientID: "client-123"}, nil)
+		clientRepo.On("GetClientByID", ctx, "client-123").Return(nil, nil)
+
+		result, err := service.GetContactByID(ctx, "contact-1")
+
+		assert.ErrorIs(t, err, models.ErrClientNotFound)
+		assert.Nil(t, result)
+	})
+}
+
+func TestContactService_UpdateContact(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should update contact successfully", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		service := &contactService{
+			uow: newUnitOfWorkMock(),
+			clr: clientRepo,
+			ctr: contactRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		contactRepo.
+			On("GetContactByID", ctx, "contact-1").
+			Return(&models.Contact{ID: "contact-1", Phone: "+5521999999999", Email: "old@example.com", ClientID: "client-123", Version: 2}, nil)
+		clientRepo.
+			On("GetClientByID", ctx, "client-123").
+			Return(&models.Client{ID: "client-123"}, nil)
+		contactRepo.
+			On("UpdateContact", ctx, mock.MatchedBy(func(c *models.Contact) bool {
+				return c.Phone == "+5521988888888" && c.Email == "new@example.com" && c.ClientID == "client-123"
+			})).
+			Return(nil)
+		clientRepo.On("TouchClient", ctx, "client-123").Return(nil)
+
+		result, err := service.UpdateContact(ctx, "contact-1", 2, "+5521988888888", "new@example.com")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "new@example.com", result.Email)
+		contactRepo.AssertExpectations(t)
+		clientRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error when updating contact fails", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		service := &contactService{
+			uow: newUnitOfWorkMock(),
+			clr: clientRepo,
+			ctr: contactRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		contactRepo.
+			On("GetContactByID", ctx, "contact-1").
+			Return(&models.Contact{ID: "contact-1", ClientID: "client-123"}, nil)
+		clientRepo.
+			On("GetClientByID", ctx, "client-123").
+			Return(&models.Client{ID: "client-123"}, nil)
+		contactRepo.On("UpdateContact", ctx, mock.Anything).Return(errors.New("db failure"))
+
+		result, err := service.UpdateContact(ctx, "contact-1", AnyVersion, "+5521988888888", "new@example.com")
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "update contact")
+		clientRepo.AssertNotCalled(t, "TouchClient", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should fail the precondition when the version does not match", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		service := &contactService{
+			clr: clientRepo,
+			ctr: contactRepo,
+		}
+
+		contactRepo.
+			On("GetContactByID", ctx, "contact-1").
+			Return(&models.Contact{ID: "contact-1", ClientID: "client-123", Version: 3}, nil)
+		clientRepo.
+			On("GetClientByID", ctx, "client-123").
+			Return(&models.Client{ID: "client-123"}, nil)
+
+		result, err := service.UpdateContact(ctx, "contact-1", 2, "+5521988888888", "new@example.com")
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, models.ErrPreconditionFailed)
+		contactRepo.AssertNotCalled(t, "UpdateContact", mock.Anything, mock.Anything)
+	})
+}
+
+func TestContactService_PatchContact(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should apply merge patch to contact", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		service := &contactService{
+			v:   pkgs.NewValidator(),
+			uow: newUnitOfWorkMock(),
+			clr: clientRepo,
+			ctr: contactRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		contactRepo.
+			On("GetContactByID", ctx, "contact-1").
+			Return(&models.Contact{ID: "contact-1", Phone: "+5521999999999", Email: "old@example.com", ClientID: "client-123"}, nil)
+		clientRepo.
+			On("GetClientByID", ctx, "client-123").
+			Return(&models.Client{ID: "client-123"}, nil)
+		contactRepo.
+			On("UpdateContact", ctx, mock.MatchedBy(func(c *models.Contact) bool {
+				return c.Phone == "+5521999999999" && c.Email == "new@example.com"
+			})).
+			Return(nil)
+		clientRepo.On("TouchClient", ctx, "client-123").Return(nil)
+
+		result, err := service.PatchContact(ctx, "contact-1", AnyVersion, []byte(`{"email":"new@example.com"}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "new@example.com", result.Email)
+		assert.Equal(t, "+5521999999999", result.Phone)
+	})
+
+	t.Run("should return validation error on invalid phone", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		service := &contactService{
+			v:   pkgs.NewValidator(),
+			clr: clientRepo,
+			ctr: contactRepo,
+		}
+
+		contactRepo.
+			On("GetContactByID", ctx, "contact-1").
+			Return(&models.Contact{ID: "contact-1", Phone: "+5521999999999", Email: "old@example.com", ClientID: "client-123"}, nil)
+		clientRepo.
+			On("GetClientByID", ctx, "client-123").
+			Return(&models.Client{ID: "client-123"}, nil)
+
+		result, err := service.PatchContact(ctx, "contact-1", AnyVersion, []byte(`{"phone":"21 9999"}`))
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, models.ErrValidation)
+		contactRepo.AssertNotCalled(t, "UpdateContact", mock.Anything, mock.Anything)
+	})
+}
+
+func TestContactService_DeleteContact(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should delete contact successfully", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		service := &contactService{
+			uow: newUnitOfWorkMock(),
+			clr: clientRepo,
+			ctr: contactRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		contactRepo.
+			On("GetContactByID", ctx, "contact-1").
+			Return(&models.Contact{ID: "contact-1", ClientID: "client-123", Version: 1}, nil)
+		clientRepo.
+			On("GetClientByID", ctx, "client-123").
+			Return(&models.Client{ID: "client-123"}, nil)
+		contactRepo.On("DeleteContact", ctx, "contact-1", int64(1)).Return(nil)
+		clientRepo.On("TouchClient", ctx, "client-123").Return(nil)
+
+		err := service.DeleteContact(ctx, "contact-1", 1)
+
+		assert.NoError(t, err)
+		contactRepo.AssertExpectations(t)
+		clientRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error if contact not found", func(t *testing.T) {
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		service := &contactService{
+			ctr: contactRepo,
+		}
+
+		contactRepo.On("GetContactByID", ctx, "missing-contact").Return(nil, nil)
+
+		err := service.DeleteContact(ctx, "missing-contact", AnyVersion)
+
+		assert.ErrorIs(t, err, models.ErrContactNotFound)
+		contactRepo.AssertNotCalled(t, "DeleteContact", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestContactService_TransferContact(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should move contact to another client", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+		auditService := newAuditServiceMock()
+
+		service := &contactService{
+			uow: newUnitOfWorkMock(),
+			clr: clientRepo,
+			ctr: contactRepo,
+			as:  auditService,
+			ob:  newOutboxServiceMock(),
+		}
+
+		contactRepo.
+			On("GetContactByID", ctx, "contact-1").
+			Return(&models.Contact{ID: "contact-1", ClientID: "client-123"}, nil)
+		clientRepo.
+			On("GetClientByID", ctx, "client-123").
+			Return(&models.Client{ID: "client-123"}, nil)
+		contactRepo.
+			On("UpdateContact", ctx, mock.MatchedBy(func(c *models.Contact) bool {
+				return c.ClientID == "client-456"
+			})).
+			Return(nil)
+		clientRepo.On("TouchClient", ctx, "client-123").Return(nil)
+		clientRepo.On("TouchClient", ctx, "client-456").Return(nil)
+
+		result, err := service.TransferContact(ctx, "contact-1", "client-456")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "client-456", result.ClientID)
+		contactRepo.AssertExpectations(t)
+		clientRepo.AssertExpectations(t)
+		for _, clientID := range []string{"client-123", "client-456"} {
+			auditService.AssertCalled(t, "Record", ctx, mock.MatchedBy(func(e models.AuditEntry) bool {
+				before := e.Before.(*models.ContactSnapshot)
+				after := e.After.(*models.ContactSnapshot)
+				return e.Action == models.AuditActionTransfer && e.ClientID == clientID &&
+					before.ClientID == "client-123" && after.ClientID == "client-456"
+			}))
+		}
+	})
+
+	t.Run("should lock both clients in id order", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		service := &contactService{
+			uow: newUnitOfWorkMock(),
+			clr: clientRepo,
+			ctr: contactRepo,
+			as:  newAuditServiceMock(),
+			ob:  newOutboxServiceMock(),
+		}
+
+		var touched []string
+		contactRepo.
+			On("GetContactByID", ctx, "contact-1").
+			Return(&models.Contact{ID: "contact-1", ClientID: "client-456"}, nil)
+		clientRepo.
+			On("GetClientByID", ctx, "client-456").
+			Return(&models.Client{ID: "client-456"}, nil)
+		clientRepo.
+			On("TouchClient", ctx, mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { touched = append(touched, args.String(1)) }).
+			Return(nil)
+		contactRepo.On("UpdateContact", ctx, mock.Anything).Return(nil)
+
+		_, err := service.TransferContact(ctx, "contact-1", "client-123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"client-123", "client-456"}, touched)
+	})
+
+	t.Run("should reject a transfer to the client that already owns the contact", func(t *testing.T) {
+		uow := new(mocks.UnitOfWorkMock)
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		service := &contactService{
+			uow: uow,
+			clr: clientRepo,
+			ctr: contactRepo,
+		}
+
+		contactRepo.
+			On("GetContactByID", ctx, "contact-1").
+			Return(&models.Contact{ID: "contact-1", ClientID: "client-123"}, nil)
+		clientRepo.
+			On("GetClientByID", ctx, "client-123").
+			Return(&models.Client{ID: "client-123"}, nil)
+
+		result, err := service.TransferContact(ctx, "contact-1", "client-123")
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+		assert.Nil(t, result)
+		uow.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+		clientRepo.AssertNotCalled(t, "TouchClient", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error if target client is gone when the transaction locks it", func(t *testing.T) {
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		service := &contactService{
+			uow: newUnitOfWorkMock(),
+			clr: clientRepo,
+			ctr: contactRepo,
+		}
+
+		contactRepo.
+			On("GetContactByID", ctx, "contact-1").
+			Return(&models.Contact{ID: "contact-1", ClientID: "client-123"}, nil)
+		clientRepo.
+			On("GetClientByID", ctx, "client-123").
+			Return(&models.Client{ID: "client-123"}, nil)
+		clientRepo.On("TouchClient", ctx, "client-123").Return(nil)
+		clientRepo.On("TouchClient", ctx, "missing-client").Return(models.ErrClientNotFound)
+
+		result, err := service.TransferContact(ctx, "contact-1", "missing-client")
+
+		assert.ErrorIs(t, err, models.ErrClientNotFound)
+		assert.Nil(t, result)
+		contactRepo.AssertNotCalled(t, "UpdateContact", mock.Anything, mock.Anything)
+	})
+}
+
+func TestContactService_SearchContacts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return the matching contacts with their clients", func(t *testing.T) {
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		service := &contactService{
+			ctr: contactRepo,
+		}
+
+		contactRepo.
+			On("SearchContacts", ctx, "", "+55 21 99999-9999").
+			Return([]*models.Contact{{ID: "contact-1", Phone: "+5521999999999", ClientID: "client-123"}}, nil)
+
+		result, err := service.SearchContacts(ctx, "", "+55 21 99999-9999")
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "client-123", result[0].ClientID)
+	})
+
+	t.Run("should return error if repository fails", func(t *testing.T) {
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		service := &contactService{
+			ctr: contactRepo,
+		}
+
+		contactRepo.On("SearchContacts", ctx, "gabriel@gmail.com", "").Return(nil, errors.New("db failure"))
+
+		result, err := service.SearchContacts(ctx, "gabriel@gmail.com", "")
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "search contacts: db failure")
+	})
+}
+
+func newUnitOfWorkMock() *mocks.UnitOfWorkMock {
+	unitOfWork := new(mocks.UnitOfWorkMock)
+	unitOfWork.
+		On("Do", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	return unitOfWork
+}
+
+func newTestMetrics(t *testing.T) *metrics.Metrics {
+	t.Helper()
+
+	m, err := metrics.NewMetrics(nil)
+	require.NoError(t, err)
+
+	return m
+}
+
+func newAuditServiceMock() *mocks.AuditServiceMock {
+	auditService := new(mocks.AuditServiceMock)
+	auditService.On("Record", mock.Anything, mock.Anything).Return(nil)
+
+	return auditService
+}
+
+func newOutboxServiceMock() *mocks.OutboxServiceMock {
+	outboxService := new(mocks.OutboxServiceMock)
+
+	// Emit é variádico, então cada quantidade de eventos precisa da sua própria expectativa
+	args := []any{mock.Anything}
+	for range 3 {
+		args = append(args, mock.Anything)
+		outboxService.On("Emit", args...).Return(nil)
+	}
+
+	return outboxService
+}
+
+func TestContactSnapshot(t *testing.T) {
+	t.Run("should keep the hashes of the normalized email and phone instead of the values", func(t *testing.T) {
+		snapshot := contactSnapshot(&models.ContactResponse{
+			ID:       "contact-1",
+			ClientID: "client-123",
+			Email:    " Gabriel@Email.com ",
+			Phone:    "+55 (21) 99999-9999",
+			Version:  2,
+		})
+
+		assert.Equal(t, pkgs.HashPII("gabriel@email.com"), snapshot.EmailHash)
+		assert.Equal(t, pkgs.HashPII("+5521999999999"), snapshot.PhoneHash)
+		assert.Equal(t, "client-123", snapshot.ClientID)
+		assert.Equal(t, int64(2), snapshot.Version)
+
+		data, err := jsoniter.Marshal(snapshot)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(data), "gabriel")
+		assert.NotContains(t, string(data), "99999")
+	})
+
+	t.Run("should return nil when the contact does not exist", func(t *testing.T) {
+		assert.Nil(t, contactSnapshot(nil))
+	})
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/configs"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+)
+
+type HealthcheckService interface {
+	Liveness() *models.HealthResponse
+	Readiness(ctx context.Context) *models.HealthResponse
+	Drain()
+}
+
+type healthcheckService struct {
+	di       *pkgs.Di
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+func NewHealthcheckService(di *pkgs.Di) (HealthcheckService, error) {
+	return &healthcheckService{
+		di:      di,
+		timeout: time.Duration(configs.Env.Health.CheckTimeoutMs) * time.Millisecond,
+	}, nil
+}
+
+// Liveness informa apenas que o processo está respondendo. Não consulta dependências, para que
+// uma falha no banco não faça o orquestrador reiniciar instâncias saudáveis.
+func (h *healthcheckService) Liveness() *models.HealthResponse {
+	return &models.HealthResponse{Status: models.HealthStatusUp}
+}
+
+// Readiness verifica, com o tempo limite de HEALTH_CHECK_TIMEOUT_MS, cada serviço do container
+// que implementa pkgs.Healthchecker, identificado pelo nome do registro. Depois de Drain
+// responde draining sem consultar as dependências.
+func (h *healthcheckService) Readiness(ctx context.Context) *models.HealthResponse {
+	if h.draining.Load() {
+		return &models.HealthResponse{Status: models.HealthStatusDraining}
+	}
+
+	checks := h.di.Healthchecks()
+
+	response := &models.HealthResponse{
+		Status: models.HealthStatusUp,
+		Checks: make(map[string]models.HealthCheck, len(checks)),
+	}
+
+	for name, check := range checks {
+		result, err := h.check(ctx, check)
+		if err != nil {
+			response.Status = models.HealthStatusDown
+
+			pkgs.LoggerFromContext(ctx).Warn("dependency is not ready",
+				slog.String("service", "healthcheck"),
+				slog.String("dependency", name),
+				slog.Any("error", err),
+			)
+		}
+
+		response.Checks[name] = result
+	}
+
+	return response
+}
+
+// Drain marca a instância como em desligamento. A partir daí o /readyz responde 503, para que o
+// balanceador pare de enviar requisições antes de o servidor deixar de aceitar conexões.
+func (h *healthcheckService) Drain() {
+	h.draining.Store(true)
+}
+
+// check executa a verificação e devolve o erro à parte, para que ele vá só para o log: a resposta
+// do /readyz é pública e não deve expor detalhes do driver nem da infraestrutura.
+func (h *healthcheckService) check(ctx context.Context, check func(ctx context.Context) error) (models.HealthCheck, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	result := models.HealthCheck{
+		Status:    models.HealthStatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+
+	if err != nil {
+		result.Status = models.HealthStatusDown
+	}
+
+	return result, err
+}
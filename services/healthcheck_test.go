@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/mocks"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newHealthDi registra o repositório com o nome usado em setup.go, para que seja descoberto
+// pelo container como uma dependência verificada pelo /readyz
+func newHealthDi(healthRepo *mocks.HealthRepositoryMock) *pkgs.Di {
+	di := pkgs.NewDi()
+	pkgs.ProvideNamed(di, models.HealthDependencyPostgres, func(di *pkgs.Di) (repositories.HealthRepository, error) {
+		return healthRepo, nil
+	})
+
+	return di
+}
+
+func TestHealthcheckService_Liveness(t *testing.T) {
+	t.Run("should report up without checking the dependencies", func(t *testing.T) {
+		healthRepo := new(mocks.HealthRepositoryMock)
+		svc := &healthcheckService{di: newHealthDi(healthRepo)}
+
+		response := svc.Liveness()
+
+		assert.Equal(t, &models.HealthResponse{Status: models.HealthStatusUp}, response)
+		healthRepo.AssertNotCalled(t, "HealthCheck", mock.Anything)
+	})
+}
+
+func TestHealthcheckService_Readiness(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should report up when postgres answers", func(t *testing.T) {
+		healthRepo := new(mocks.HealthRepositoryMock)
+		svc := &healthcheckService{di: newHealthDi(healthRepo), timeout: time.Second}
+
+		healthRepo.
+			On("HealthCheck", mock.MatchedBy(func(ctx context.Context) bool {
+				_, ok := ctx.Deadline()
+				return ok
+			})).
+			Return(nil)
+
+		response := svc.Readiness(ctx)
+
+		assert.Equal(t, models.HealthStatusUp, response.Status)
+		assert.Equal(t, models.HealthStatusUp, response.Checks[models.HealthDependencyPostgres].Status)
+		healthRepo.AssertExpectations(t)
+	})
+
+	t.Run("should report down without the driver error when postgres fails", func(t *testing.T) {
+		healthRepo := new(mocks.HealthRepositoryMock)
+		svc := &healthcheckService{di: newHealthDi(healthRepo), timeout: time.Second}
+
+		healthRepo.On("HealthCheck", mock.Anything).Return(errors.New("dial tcp 10.0.3.7:5432: connect: connection refused"))
+
+		response := svc.Readiness(ctx)
+
+		assert.False(t, response.IsUp())
+		assert.Equal(t, models.HealthStatusDown, response.Status)
+		assert.Equal(t, models.HealthCheck{
+			Status:    models.HealthStatusDown,
+			LatencyMs: response.Checks[models.HealthDependencyPostgres].LatencyMs,
+		}, response.Checks[models.HealthDependencyPostgres])
+	})
+
+	t.Run("should report draining without checking the dependencies after drain", func(t *testing.T) {
+		healthRepo := new(mocks.HealthRepositoryMock)
+		svc := &healthcheckService{di: newHealthDi(healthRepo), timeout: time.Second}
+
+		svc.Drain()
+		response := svc.Readiness(ctx)
+
+		assert.Equal(t, &models.HealthResponse{Status: models.HealthStatusDraining}, response)
+		healthRepo.AssertNotCalled(t, "HealthCheck", mock.Anything)
+	})
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/configs"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/repositories"
+)
+
+// errResponseNotStored desfaz a transação de Execute quando a resposta não deve ser armazenada
+var errResponseNotStored = errors.New("response not stored")
+
+type IdempotencyService interface {
+	Execute(ctx context.Context, scope string, key string, fingerprint string, fn func(ctx context.Context) (*models.IdempotentResponse, error)) (*models.IdempotencyKey, error)
+	PurgeExpiredKeys(ctx context.Context) (int64, error)
+}
+
+type idempotencyService struct {
+	di  *pkgs.Di
+	ttl time.Duration
+	uow repositories.UnitOfWork
+	ir  repositories.IdempotencyRepository
+}
+
+func NewIdempotencyService(di *pkgs.Di) (IdempotencyService, error) {
+	idempotencyRepository, err := pkgs.Invoke[repositories.IdempotencyRepository](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke repositories.idempotency: %w", err)
+	}
+
+	unitOfWork, err := pkgs.Invoke[repositories.UnitOfWork](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke repositories.unit_of_work: %w", err)
+	}
+
+	return &idempotencyService{
+		di:  di,
+		ttl: time.Duration(configs.Env.Idempotency.TTLHours) * time.Hour,
+		uow: unitOfWork,
+		ir:  idempotencyRepository,
+	}, nil
+}
+
+// Execute reserva a chave e executa fn na mesma transação em que grava a resposta devolvida por
+// fn, de modo que a chave nunca fica presa em andamento: se o processo cair ou a gravação falhar,
+// a transação é desfeita junto com as alterações de fn e a chave volta a ficar livre. Uma
+// repetição concorrente espera a transação terminar para reproduzir a resposta. Quando fn falha
+// ou devolve nil, nada é gravado. Retorna a chave armazenada, sem executar fn, quando a resposta
+// original deve ser reproduzida.
+func (i *idempotencyService) Execute(ctx context.Context, scope string, key string, fingerprint string, fn func(ctx context.Context) (*models.IdempotentResponse, error)) (*models.IdempotencyKey, error) {
+	var stored *models.IdempotencyKey
+
+	err := i.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		stored, err = i.begin(ctx, scope, key, fingerprint)
+		if err != nil || stored != nil {
+			return err
+		}
+
+		response, err := fn(ctx)
+		if err != nil {
+			return err
+		}
+
+		if response == nil {
+			return errResponseNotStored
+		}
+
+		return i.complete(ctx, scope, key, response)
+	})
+	if errors.Is(err, errResponseNotStored) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+// begin reserva a chave para a requisição. Retorna nil quando a requisição deve ser processada
+// e a chave armazenada quando a resposta original deve ser reproduzida.
+func (i *idempotencyService) begin(ctx context.Context, scope string, key string, fingerprint string) (*models.IdempotencyKey, error) {
+	now := time.Now().UTC()
+
+	created, err := i.ir.CreateKey(ctx, &models.IdempotencyKey{
+		Scope:       scope,
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(i.ttl),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create idempotency key: %w", err)
+	}
+
+	if created {
+		return nil, nil
+	}
+
+	stored, err := i.ir.GetKey(ctx, scope, key)
+	if err != nil {
+		return nil, fmt.Errorf("get idempotency key: %w", err)
+	}
+
+	// A chave foi removida entre a reserva e a leitura
+	if stored == nil {
+		return nil, models.ErrIdempotencyKeyInProgress
+	}
+
+	if stored.Fingerprint != fingerprint {
+		return nil, models.ErrIdempotencyKeyReused
+	}
+
+	if !stored.IsCompleted() {
+		return nil, models.ErrIdempotencyKeyInProgress
+	}
+
+	return stored, nil
+}
+
+func (i *idempotencyService) complete(ctx context.Context, scope string, key string, response *models.IdempotentResponse) error {
+	err := i.ir.CompleteKey(ctx, &models.IdempotencyKey{
+		Scope:        scope,
+		Key:          key,
+		StatusCode:   response.StatusCode,
+		ContentType:  response.ContentType,
+		ResponseBody: response.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (i *idempotencyService) PurgeExpiredKeys(ctx context.Context) (int64, error) {
+	purged, err := i.ir.PurgeExpiredKeys(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("purge expired idempotency keys: %w", err)
+	}
+
+	return purged, nil
+}
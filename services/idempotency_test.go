@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/mocks"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIdempotencyService_Execute(t *testing.T) {
+	ctx := context.Background()
+	response := &models.IdempotentResponse{StatusCode: 201, ContentType: "application/json", Body: []byte(`{"id":"client-1"}`)}
+
+	newService := func() (*idempotencyService, *mocks.IdempotencyRepositoryMock) {
+		unitOfWork := new(mocks.UnitOfWorkMock)
+		idempotencyRepo := new(mocks.IdempotencyRepositoryMock)
+
+		unitOfWork.
+			On("Do", ctx, mock.Anything).
+			Return(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			})
+
+		return &idempotencyService{ttl: time.Hour, uow: unitOfWork, ir: idempotencyRepo}, idempotencyRepo
+	}
+
+	t.Run("should reserve the key, run fn and store its response in the same unit of work", func(t *testing.T) {
+		service, idempotencyRepo := newService()
+
+		idempotencyRepo.On("CreateKey", ctx, mock.Anything).Return(true, nil)
+		idempotencyRepo.
+			On("CompleteKey", ctx, &models.IdempotencyKey{
+				Scope:        "POST /clients",
+				Key:          "key-1",
+				StatusCode:   201,
+				ContentType:  "application/json",
+				ResponseBody: []byte(`{"id":"client-1"}`),
+			}).
+			Return(nil)
+
+		stored, err := service.Execute(ctx, "POST /clients", "key-1", "fp", func(ctx context.Context) (*models.IdempotentResponse, error) {
+			return response, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Nil(t, stored)
+		idempotencyRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return the stored key without running fn", func(t *testing.T) {
+		service, idempotencyRepo := newService()
+
+		storedKey := &models.IdempotencyKey{Scope: "POST /clients", Key: "key-1", Fingerprint: "fp", StatusCode: 201}
+
+		idempotencyRepo.On("CreateKey", ctx, mock.Anything).Return(false, nil)
+		idempotencyRepo.On("GetKey", ctx, "POST /clients", "key-1").Return(storedKey, nil)
+
+		stored, err := service.Execute(ctx, "POST /clients", "key-1", "fp", func(ctx context.Context) (*models.IdempotentResponse, error) {
+			t.Fatal("fn must not run on replay")
+			return nil, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, storedKey, stored)
+	})
+
+	t.Run("should undo the reservation when fn returns no response", func(t *testing.T) {
+		service, idempotencyRepo := newService()
+
+		idempotencyRepo.On("CreateKey", ctx, mock.Anything).Return(true, nil)
+
+		stored, err := service.Execute(ctx, "POST /clients", "key-1", "fp", func(ctx context.Context) (*models.IdempotentResponse, error) {
+			return nil, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Nil(t, stored)
+		idempotencyRepo.AssertNotCalled(t, "CompleteKey", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return the error of fn without storing a response", func(t *testing.T) {
+		service, idempotencyRepo := newService()
+
+		idempotencyRepo.On("CreateKey", ctx, mock.Anything).Return(true, nil)
+
+		stored, err := service.Execute(ctx, "POST /clients", "key-1", "fp", func(ctx context.Context) (*models.IdempotentResponse, error) {
+			return nil, models.ErrValidation
+		})
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+		assert.Nil(t, stored)
+		idempotencyRepo.AssertNotCalled(t, "CompleteKey", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should fail the unit of work when the response cannot be stored", func(t *testing.T) {
+		service, idempotencyRepo := newService()
+
+		idempotencyRepo.On("CreateKey", ctx, mock.Anything).Return(true, nil)
+		idempotencyRepo.On("CompleteKey", ctx, mock.Anything).Return(errors.New("db failure"))
+
+		stored, err := service.Execute(ctx, "POST /clients", "key-1", "fp", func(ctx context.Context) (*models.IdempotentResponse, error) {
+			return response, nil
+		})
+
+		assert.EqualError(t, err, "complete idempotency key: db failure")
+		assert.Nil(t, stored)
+	})
+}
+
+func TestIdempotencyService_begin(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should reserve a new key with the configured ttl", func(t *testing.T) {
+		idempotencyRepo := new(mocks.IdempotencyRepositoryMock)
+
+		service := &idempotencyService{
+			ttl: time.Hour,
+			ir:  idempotencyRepo,
+		}
+
+		idempotencyRepo.
+			On("CreateKey", ctx, mock.MatchedBy(func(k *models.IdempotencyKey) bool {
+				return k.Scope == "POST /clients" && k.Key == "key-1" && k.Fingerprint == "fp" &&
+					k.ExpiresAt.Sub(k.CreatedAt) == time.Hour
+			})).
+			Return(true, nil)
+
+		stored, err := service.begin(ctx, "POST /clients", "key-1", "fp")
+
+		assert.NoError(t, err)
+		assert.Nil(t, stored)
+		idempotencyRepo.AssertNotCalled(t, "GetKey", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return the stored response on retry", func(t *testing.T) {
+		idempotencyRepo := new(mocks.IdempotencyRepositoryMock)
+
+		service := &idempotencyService{
+			ttl: time.Hour,
+			ir:  idempotencyRepo,
+		}
+
+		storedKey := &models.IdempotencyKey{Scope: "POST /clients", Key: "key-1", Fingerprint: "fp", StatusCode: 201, ResponseBody: []byte(`{"id":"client-1"}`)}
+
+		idempotencyRepo.On("CreateKey", ctx, mock.Anything).Return(false, nil)
+		idempotencyRepo.On("GetKey", ctx, "POST /clients", "key-1").Return(storedKey, nil)
+
+		stored, err := service.begin(ctx, "POST /clients", "key-1", "fp")
+
+		assert.NoError(t, err)
+		assert.Equal(t, storedKey, stored)
+	})
+
+	t.Run("should reject a key reused with a different request", func(t *testing.T) {
+		idempotencyRepo := new(mocks.IdempotencyRepositoryMock)
+
+		service := &idempotencyService{
+			ttl: time.Hour,
+			ir:  idempotencyRepo,
+		}
+
+		idempotencyRepo.On("CreateKey", ctx, mock.Anything).Return(false, nil)
+		idempotencyRepo.
+			On("GetKey", ctx, "POST /clients", "key-1").
+			Return(&models.IdempotencyKey{Fingerprint: "other", StatusCode: 201}, nil)
+
+		stored, err := service.begin(ctx, "POST /clients", "key-1", "fp")
+
+		assert.ErrorIs(t, err, models.ErrIdempotencyKeyReused)
+		assert.Nil(t, stored)
+	})
+
+	t.Run("should return conflict while the original request is in progress", func(t *testing.T) {
+		idempotencyRepo := new(mocks.IdempotencyRepositoryMock)
+
+		service := &idempotencyService{
+			ttl: time.Hour,
+			ir:  idempotencyRepo,
+		}
+
+		idempotencyRepo.On("CreateKey", ctx, mock.Anything).Return(false, nil)
+		idempotencyRepo.
+			On("GetKey", ctx, "POST /clients", "key-1").
+			Return(&models.IdempotencyKey{Fingerprint: "fp"}, nil)
+
+		stored, err := service.begin(ctx, "POST /clients", "key-1", "fp")
+
+		assert.ErrorIs(t, err, models.ErrIdempotencyKeyInProgress)
+		assert.Nil(t, stored)
+	})
+
+	t.Run("should return error if repository fails", func(t *testing.T) {
+		idempotencyRepo := new(mocks.IdempotencyRepositoryMock)
+
+		service := &idempotencyService{
+			ttl: time.Hour,
+			ir:  idempotencyRepo,
+		}
+
+		idempotencyRepo.On("CreateKey", ctx, mock.Anything).Return(false, errors.New("db failure"))
+
+		stored, err := service.begin(ctx, "POST /clients", "key-1", "fp")
+
+		assert.EqualError(t, err, "create idempotency key: db failure")
+		assert.Nil(t, stored)
+	})
+}
+
+func TestIdempotencyService_complete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should store the response of the key", func(t *testing.T) {
+		idempotencyRepo := new(mocks.IdempotencyRepositoryMock)
+
+		service := &idempotencyService{
+			ir: idempotencyRepo,
+		}
+
+		idempotencyRepo.
+			On("CompleteKey", ctx, &models.IdempotencyKey{
+				Scope:        "POST /clients",
+				Key:          "key-1",
+				StatusCode:   201,
+				ContentType:  "application/json",
+				ResponseBody: []byte(`{}`),
+			}).
+			Return(nil)
+
+		err := service.complete(ctx, "POST /clients", "key-1", &models.IdempotentResponse{StatusCode: 201, ContentType: "application/json", Body: []byte(`{}`)})
+
+		assert.NoError(t, err)
+		idempotencyRepo.AssertExpectations(t)
+	})
+}
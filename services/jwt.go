@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/configs"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// jwksRefreshInterval limita a busca do JWKS quando chega um token com kid desconhecido
+	jwksRefreshInterval = time.Minute
+
+	// maxJWKSSize limita o tamanho do documento JWKS lido da URL
+	maxJWKSSize = 1 << 20
+)
+
+type JWTService interface {
+	Enabled() bool
+	Authenticate(ctx context.Context, token string) (*models.Principal, error)
+}
+
+type jwtService struct {
+	di       *pkgs.Di
+	client   *http.Client
+	file     string
+	url      string
+	issuer   string
+	audience string
+	skew     time.Duration
+	cacheTTL time.Duration
+
+	group       singleflight.Group
+	mu          sync.Mutex
+	jwks        *pkgs.JWKS
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+// NewJWTService carrega o JWKS de JWT_JWKS_FILE na inicialização. Quando configurado por
+// JWT_JWKS_URL, o JWKS é buscado no primeiro uso e renovado a cada JWT_JWKS_CACHE_MINUTES.
+func NewJWTService(di *pkgs.Di) (JWTService, error) {
+	svc := &jwtService{
+		di:       di,
+		client:   &http.Client{Timeout: 5 * time.Second},
+		file:     configs.Env.JWT.JWKSFile,
+		url:      configs.Env.JWT.JWKSURL,
+		issuer:   configs.Env.JWT.Issuer,
+		audience: configs.Env.JWT.Audience,
+		skew:     time.Duration(configs.Env.JWT.ClockSkewSeconds) * time.Second,
+		cacheTTL: time.Duration(configs.Env.JWT.JWKSCacheMinutes) * time.Minute,
+	}
+
+	if svc.Enabled() && (svc.issuer == "" || svc.audience == "") {
+		return nil, errors.New("jwt issuer and audience are required when a jwks is configured")
+	}
+
+	if svc.file != "" {
+		data, err := os.ReadFile(svc.file)
+		if err != nil {
+			return nil, fmt.Errorf("read jwks file %s: %w", svc.file, err)
+		}
+
+		jwks, err := pkgs.ParseJWKS(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse jwks file %s: %w", svc.file, err)
+		}
+
+		svc.jwks = jwks
+	}
+
+	return svc, nil
+}
+
+func (j *jwtService) Enabled() bool {
+	return j.file != "" || j.url != ""
+}
+
+// Authenticate confere a assinatura e as claims do token e retorna a credencial com o sub e os
+// escopos do token. Tokens inválidos resultam em models.ErrUnauthorized e a falha ao buscar o
+// JWKS sem nenhum em cache resulta em models.ErrAuthenticationUnavailable.
+func (j *jwtService) Authenticate(ctx context.Context, token string) (*models.Principal, error) {
+	if !j.Enabled() {
+		return nil, fmt.Errorf("%w: bearer tokens are not accepted", models.ErrUnauthorized)
+	}
+
+	validation := pkgs.JWTValidation{Issuer: j.issuer, Audience: j.audience, Skew: j.skew}
+
+	claims, err := pkgs.ParseJWT(token, validation, func(kid string) (crypto.PublicKey, error) {
+		return j.key(ctx, kid)
+	})
+	if err != nil {
+		if errors.Is(err, pkgs.ErrInvalidToken) || errors.Is(err, pkgs.ErrUnknownJWTKey) {
+			return nil, fmt.Errorf("%w: %v", models.ErrUnauthorized, err)
+		}
+
+		return nil, err
+	}
+
+	if claims.TenantID != "" && !models.IsValidTenant(claims.TenantID) {
+		return nil, fmt.Errorf("%w: invalid tenant_id claim", models.ErrUnauthorized)
+	}
+
+	return &models.Principal{
+		Method:   models.AuthMethodJWT,
+		ID:       claims.Subject,
+		Subject:  claims.Subject,
+		Scopes:   claims.Scopes(),
+		TenantID: claims.TenantID,
+	}, nil
+}
+
+// key retorna a chave do kid, buscando o JWKS de novo quando o cache expirou ou quando o kid é
+// desconhecido, o que acontece logo depois de uma rotação de chaves no emissor. As buscas são
+// espaçadas por jwksRefreshInterval para que tokens com kid inválido não sobrecarreguem o emissor.
+//
+// A busca acontece fora de j.mu, para que uma resposta lenta do emissor não trave os tokens
+// cujas chaves já estão em cache, e as requisições que chegam durante a busca esperam por ela.
+func (j *jwtService) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	jwks, refresh := j.cached(kid)
+
+	if refresh {
+		// A busca é compartilhada, então não pode ser cancelada junto com a requisição que a iniciou
+		fetched, err, _ := j.group.Do("jwks", func() (any, error) {
+			return j.refresh(context.WithoutCancel(ctx))
+		})
+
+		switch {
+		case err == nil:
+			jwks = fetched.(*pkgs.JWKS)
+		case jwks == nil:
+			// Sem JWKS não há como conferir o token, mas a falha é do emissor e não da credencial
+			return nil, fmt.Errorf("%w: %v", models.ErrAuthenticationUnavailable, err)
+		default:
+			// Com um JWKS ainda em cache, a falha na renovação não derruba a autenticação
+			pkgs.LoggerFromContext(ctx).Warn("error to refresh jwks", slog.String("service", "jwt"), slog.Any("error", err))
+		}
+	}
+
+	key, ok := jwks.Key(kid)
+	if !ok {
+		return nil, fmt.Errorf("%w: kid %q", pkgs.ErrUnknownJWTKey, kid)
+	}
+
+	return key, nil
+}
+
+// cached retorna o JWKS em cache e se ele deve ser buscado de novo para o kid
+func (j *jwtService) cached(kid string) (*pkgs.JWKS, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	_, known := j.jwks.Key(kid)
+	stale := j.jwks == nil || time.Since(j.fetchedAt) >= j.cacheTTL || !known
+
+	return j.jwks, j.url != "" && stale && time.Since(j.attemptedAt) >= jwksRefreshInterval
+}
+
+// refresh busca o JWKS e o guarda no cache. Quem decidiu buscar logo antes de outra busca
+// terminar recebe o resultado dela em vez de buscar de novo.
+func (j *jwtService) refresh(ctx context.Context) (*pkgs.JWKS, error) {
+	j.mu.Lock()
+	if time.Since(j.attemptedAt) < jwksRefreshInterval {
+		defer j.mu.Unlock()
+		return j.jwks, nil
+	}
+	j.mu.Unlock()
+
+	jwks, err := j.fetch(ctx)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.attemptedAt = time.Now()
+	if err != nil {
+		return nil, err
+	}
+
+	j.jwks = jwks
+	j.fetchedAt = j.attemptedAt
+
+	return jwks, nil
+}
+
+func (j *jwtService) fetch(ctx context.Context) (*pkgs.JWKS, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, j.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build jwks request: %w", err)
+	}
+
+	response, err := j.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("fetch jwks: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch jwks: unexpected status %d", response.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxJWKSSize))
+	if err != nil {
+		return nil, fmt.Errorf("read jwks: %w", err)
+	}
+
+	jwks, err := pkgs.ParseJWKS(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse jwks: %w", err)
+	}
+
+	return jwks, nil
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/configs"
+	"github.com/g-villarinho/nubank-challenge/models"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestJWT(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]any) string {
+	t.Helper()
+
+	header, err := jsoniter.Marshal(map[string]string{"alg": "RS256", "kid": kid})
+	require.NoError(t, err)
+	payload, err := jsoniter.Marshal(claims)
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func newTestJWKSServer(t *testing.T, key *rsa.PrivateKey, kid *atomic.Value, hits *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		_ = jsoniter.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": kid.Load().(string),
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestNewJWTService(t *testing.T) {
+	t.Run("should require issuer and audience when a jwks is configured", func(t *testing.T) {
+		previous := configs.Env
+		t.Cleanup(func() { configs.Env = previous })
+
+		configs.Env.JWT = models.JWT{JWKSURL: "https://issuer.example.com/.well-known/jwks.json", Audience: "nubank-challenge"}
+
+		svc, err := NewJWTService(nil)
+
+		assert.Nil(t, svc)
+		assert.EqualError(t, err, "jwt issuer and audience are required when a jwks is configured")
+	})
+
+	t.Run("should accept only api keys without a jwks", func(t *testing.T) {
+		previous := configs.Env
+		t.Cleanup(func() { configs.Env = previous })
+
+		configs.Env.JWT = models.JWT{}
+
+		svc, err := NewJWTService(nil)
+
+		assert.NoError(t, err)
+		assert.False(t, svc.Enabled())
+	})
+}
+
+func TestJWTService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	claims := map[string]any{
+		"iss":   "https://issuer.example.com",
+		"aud":   "nubank-challenge",
+		"sub":   "billing",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": "clients:read contacts:read",
+	}
+
+	newService := func(url string) *jwtService {
+		return &jwtService{
+			client:   http.DefaultClient,
+			url:      url,
+			issuer:   "https://issuer.example.com",
+			audience: "nubank-challenge",
+			skew:     time.Minute,
+			cacheTTL: 15 * time.Minute,
+		}
+	}
+
+	t.Run("should return the principal of a valid token", func(t *testing.T) {
+		var kid atomic.Value
+		var hits atomic.Int32
+		kid.Store("key-1")
+		server := newTestJWKSServer(t, key, &kid, &hits)
+		svc := newService(server.URL)
+
+		principal, err := svc.Authenticate(ctx, newTestJWT(t, key, "key-1", claims))
+
+		require.NoError(t, err)
+		assert.Equal(t, models.AuthMethodJWT, principal.Method)
+		assert.Equal(t, "billing", principal.Subject)
+		assert.Equal(t, []string{models.ScopeClientsRead, models.ScopeContactsRead}, principal.Scopes)
+		assert.Equal(t, "jwt:billing", principal.Actor())
+	})
+
+	t.Run("should fetch the jwks again when the kid is unknown", func(t *testing.T) {
+		var kid atomic.Value
+		var hits atomic.Int32
+		kid.Store("key-1")
+		server := newTestJWKSServer(t, key, &kid, &hits)
+		svc := newService(server.URL)
+
+		_, err := svc.Authenticate(ctx, newTestJWT(t, key, "key-1", claims))
+		require.NoError(t, err)
+
+		kid.Store("key-2")
+		svc.attemptedAt = time.Now().Add(-jwksRefreshInterval)
+
+		principal, err := svc.Authenticate(ctx, newTestJWT(t, key, "key-2", claims))
+
+		require.NoError(t, err)
+		assert.Equal(t, "billing", principal.Subject)
+		assert.Equal(t, int32(2), hits.Load())
+	})
+
+	t.Run("should not fetch the jwks again before the refresh interval", func(t *testing.T) {
+		var kid atomic.Value
+		var hits atomic.Int32
+		kid.Store("key-1")
+		server := newTestJWKSServer(t, key, &kid, &hits)
+		svc := newService(server.URL)
+
+		_, err := svc.Authenticate(ctx, newTestJWT(t, key, "key-1", claims))
+		require.NoError(t, err)
+
+		_, err = svc.Authenticate(ctx, newTestJWT(t, key, "unknown", claims))
+
+		assert.ErrorIs(t, err, models.ErrUnauthorized)
+		assert.Equal(t, int32(1), hits.Load())
+	})
+
+	t.Run("should share one fetch between concurrent requests", func(t *testing.T) {
+		var kid atomic.Value
+		var hits atomic.Int32
+		kid.Store("key-1")
+		server := newTestJWKSServer(t, key, &kid, &hits)
+		svc := newService(server.URL)
+		token := newTestJWT(t, key, "key-1", claims)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 10)
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := svc.Authenticate(ctx, token)
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, int32(1), hits.Load())
+	})
+
+	t.Run("should not block cached keys while the jwks is being fetched", func(t *testing.T) {
+		release := make(chan struct{})
+		fetching := make(chan struct{})
+		var kid atomic.Value
+		var hits atomic.Int32
+		kid.Store("key-1")
+		jwksServer := newTestJWKSServer(t, key, &kid, &hits)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if hits.Load() > 0 {
+				close(fetching)
+				<-release
+			}
+			jwksServer.Config.Handler.ServeHTTP(w, r)
+		}))
+		t.Cleanup(server.Close)
+		svc := newService(server.URL)
+
+		_, err := svc.Authenticate(ctx, newTestJWT(t, key, "key-1", claims))
+		require.NoError(t, err)
+
+		svc.attemptedAt = time.Now().Add(-jwksRefreshInterval)
+		go func() { _, _ = svc.Authenticate(ctx, newTestJWT(t, key, "key-2", claims)) }()
+		<-fetching
+
+		principal, err := svc.Authenticate(ctx, newTestJWT(t, key, "key-1", claims))
+		close(release)
+
+		require.NoError(t, err)
+		assert.Equal(t, "billing", principal.Subject)
+	})
+
+	t.Run("should return unavailable when the jwks cannot be fetched", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		t.Cleanup(server.Close)
+		svc := newService(server.URL)
+
+		principal, err := svc.Authenticate(ctx, newTestJWT(t, key, "key-1", claims))
+
+		assert.Nil(t, principal)
+		assert.ErrorIs(t, err, models.ErrAuthenticationUnavailable)
+		assert.NotErrorIs(t, err, models.ErrUnauthorized)
+	})
+
+	t.Run("should return unauthorized for a token from another audience", func(t *testing.T) {
+		var kid atomic.Value
+		var hits atomic.Int32
+		kid.Store("key-1")
+		server := newTestJWKSServer(t, key, &kid, &hits)
+		svc := newService(server.URL)
+
+		other := map[string]any{"iss": claims["iss"], "aud": "payments", "sub": "billing", "exp": claims["exp"]}
+
+		principal, err := svc.Authenticate(ctx, newTestJWT(t, key, "key-1", other))
+
+		assert.Nil(t, principal)
+		assert.ErrorIs(t, err, models.ErrUnauthorized)
+	})
+
+	t.Run("should bind the principal to the tenant claim", func(t *testing.T) {
+		var kid atomic.Value
+		var hits atomic.Int32
+		kid.Store("key-1")
+		server := newTestJWKSServer(t, key, &kid, &hits)
+		svc := newService(server.URL)
+
+		withTenant := map[string]any{"iss": claims["iss"], "aud": claims["aud"], "sub": "billing", "exp": claims["exp"], "tenant_id": "retail"}
+
+		principal, err := svc.Authenticate(ctx, newTestJWT(t, key, "key-1", withTenant))
+
+		require.NoError(t, err)
+		assert.Equal(t, "retail", principal.TenantID)
+	})
+
+	t.Run("should reject bearer tokens when no jwks is configured", func(t *testing.T) {
+		svc := &jwtService{}
+
+		principal, err := svc.Authenticate(ctx, newTestJWT(t, key, "key-1", claims))
+
+		assert.False(t, svc.Enabled())
+		assert.Nil(t, principal)
+		assert.ErrorIs(t, err, models.ErrUnauthorized)
+	})
+}
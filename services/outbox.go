@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/g-villarinho/nubank-challenge/configs"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/publishers"
+	"github.com/g-villarinho/nubank-challenge/repositories"
+)
+
+type OutboxService interface {
+	Emit(ctx context.Context, events ...models.DomainEvent) error
+	Relay(ctx context.Context) (int, error)
+	PurgeFinishedEvents(ctx context.Context) (int64, error)
+}
+
+type outboxService struct {
+	di          *pkgs.Di
+	batchSize   int
+	maxAttempts int
+	retention   time.Duration
+	uow         repositories.UnitOfWork
+	or          repositories.OutboxRepository
+	pub         publishers.Publisher
+}
+
+func NewOutboxService(di *pkgs.Di) (OutboxService, error) {
+	unitOfWork, err := pkgs.Invoke[repositories.UnitOfWork](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke repositories.unit_of_work: %w", err)
+	}
+
+	outboxRepository, err := pkgs.Invoke[repositories.OutboxRepository](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke repositories.outbox: %w", err)
+	}
+
+	publisher, err := pkgs.Invoke[publishers.Publisher](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke publishers.publisher: %w", err)
+	}
+
+	return &outboxService{
+		di:          di,
+		batchSize:   configs.Env.Outbox.BatchSize,
+		maxAttempts: configs.Env.Outbox.MaxAttempts,
+		retention:   time.Duration(configs.Env.Outbox.RetentionHours) * time.Hour,
+		uow:         unitOfWork,
+		or:          outboxRepository,
+		pub:         publisher,
+	}, nil
+}
+
+// Emit grava os eventos no outbox. Deve ser chamado dentro da mesma unidade de trabalho da
+// alteração que os originou, para que os eventos só existam se a alteração for gravada.
+func (o *outboxService) Emit(ctx context.Context, events ...models.DomainEvent) error {
+	outboxEvents := make([]*models.OutboxEvent, 0, len(events))
+	for _, event := range events {
+		payload, err := jsoniter.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("encode %s event: %w", event.EventType(), err)
+		}
+
+		outboxEvents = append(outboxEvents, &models.OutboxEvent{
+			Type:        event.EventType(),
+			AggregateID: event.AggregateID(),
+			Payload:     payload,
+		})
+	}
+
+	if err := o.or.CreateEvents(ctx, outboxEvents); err != nil {
+		return fmt.Errorf("create outbox events: %w", err)
+	}
+
+	return nil
+}
+
+// Relay publica um lote de eventos pendentes e retorna quantos foram publicados. Os eventos são
+// marcados como publicados somente depois da entrega, então podem ser entregues mais de uma vez.
+// Quando a entrega de um evento falha, os eventos seguintes do mesmo cliente ficam para o
+// próximo ciclo, preservando a ordem por cliente. Um evento que falha OUTBOX_MAX_ATTEMPTS vezes
+// vai para as dead letters, para não segurar o cliente para sempre, e os seguintes seguem no
+// próximo ciclo.
+func (o *outboxService) Relay(ctx context.Context) (int, error) {
+	logger := pkgs.LoggerFromContext(ctx).With(
+		slog.String("service", "outbox"),
+		slog.String("method", "Relay"),
+	)
+
+	var published int
+	err := o.uow.Do(ctx, func(ctx context.Context) error {
+		locked, err := o.or.LockRelay(ctx)
+		if err != nil {
+			return fmt.Errorf("lock outbox relay: %w", err)
+		}
+
+		if !locked {
+			return nil
+		}
+
+		events, err := o.or.GetPendingEvents(ctx, o.batchSize)
+		if err != nil {
+			return fmt.Errorf("get pending outbox events: %w", err)
+		}
+
+		blocked := make(map[string]bool)
+		ids := make([]string, 0, len(events))
+
+		for _, event := range events {
+			if blocked[event.AggregateID] {
+				continue
+			}
+
+			if err := o.pub.Publish(ctx, event.ToEventMessage()); err != nil {
+				// Os eventos seguintes do cliente ficam para o próximo ciclo mesmo quando este vai
+				// para as dead letters, para que nenhum seja publicado antes dele
+				blocked[event.AggregateID] = true
+
+				if event.Attempts+1 >= o.maxAttempts {
+					logger.Error("outbox event moved to dead letters", "event", event.ID, "type", event.Type, "attempts", event.Attempts+1, "error", err)
+
+					if err := o.or.MarkDead(ctx, event.ID, err.Error(), time.Now().UTC()); err != nil {
+						return fmt.Errorf("mark outbox event %s as dead: %w", event.ID, err)
+					}
+
+					continue
+				}
+
+				logger.Warn("error to publish event", "event", event.ID, "type", event.Type, "error", err)
+
+				if err := o.or.MarkFailed(ctx, event.ID, err.Error()); err != nil {
+					return fmt.Errorf("mark outbox event %s as failed: %w", event.ID, err)
+				}
+
+				continue
+			}
+
+			ids = append(ids, event.ID)
+		}
+
+		if err := o.or.MarkPublished(ctx, ids, time.Now().UTC()); err != nil {
+			return fmt.Errorf("mark outbox events as published: %w", err)
+		}
+
+		published = len(ids)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return published, nil
+}
+
+func (o *outboxService) PurgeFinishedEvents(ctx context.Context) (int64, error) {
+	purged, err := o.or.PurgeFinishedEvents(ctx, time.Now().UTC().Add(-o.retention))
+	if err != nil {
+		return 0, fmt.Errorf("purge finished outbox events: %w", err)
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/g-villarinho/nubank-challenge/mocks"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOutboxService_Emit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should write the events to the outbox in order", func(t *testing.T) {
+		outboxRepo := new(mocks.OutboxRepositoryMock)
+		svc := &outboxService{or: outboxRepo}
+
+		outboxRepo.
+			On("CreateEvents", ctx, mock.MatchedBy(func(events []*models.OutboxEvent) bool {
+				return len(events) == 2 &&
+					events[0].Type == models.EventClientCreated &&
+					events[0].AggregateID == "client-123" &&
+					string(events[0].Payload) == `{"client":{"id":"client-123","name":"Gabriel","version":1,"created_at":"0001-01-01T00:00:00Z"}}` &&
+					events[1].Type == models.EventContactAdded &&
+					events[1].AggregateID == "client-123"
+			})).
+			Return(nil)
+
+		err := svc.Emit(ctx,
+			models.ClientCreated{Client: &models.ClientSnapshot{ID: "client-123", Name: "Gabriel", Version: 1}},
+			models.ContactAdded{Contact: &models.ContactResponse{ID: "contact-1", ClientID: "client-123"}},
+		)
+
+		assert.NoError(t, err)
+		outboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error if the outbox cannot be written", func(t *testing.T) {
+		outboxRepo := new(mocks.OutboxRepositoryMock)
+		svc := &outboxService{or: outboxRepo}
+
+		outboxRepo.On("CreateEvents", ctx, mock.Anything).Return(errors.New("db failure"))
+
+		err := svc.Emit(ctx, models.ClientDeleted{ClientID: "client-123"})
+
+		assert.EqualError(t, err, "create outbox events: db failure")
+	})
+}
+
+func TestOutboxService_Relay(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should publish pending events and mark them as published", func(t *testing.T) {
+		outboxRepo := new(mocks.OutboxRepositoryMock)
+		publisher := new(mocks.PublisherMock)
+		svc := &outboxService{batchSize: 10, maxAttempts: 5, uow: newUnitOfWorkMock(), or: outboxRepo, pub: publisher}
+
+		outboxRepo.On("LockRelay", ctx).Return(true, nil)
+		outboxRepo.On("GetPendingEvents", ctx, 10).Return([]*models.OutboxEvent{
+			{ID: "event-1", Sequence: 1, AggregateID: "client-123"},
+			{ID: "event-2", Sequence: 2, AggregateID: "client-456"},
+		}, nil)
+		publisher.On("Publish", ctx, mock.Anything).Return(nil)
+		outboxRepo.On("MarkPublished", ctx, []string{"event-1", "event-2"}, mock.AnythingOfType("time.Time")).Return(nil)
+
+		published, err := svc.Relay(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, published)
+		publisher.AssertNumberOfCalls(t, "Publish", 2)
+		outboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("should hold the next events of a client whose event failed", func(t *testing.T) {
+		outboxRepo := new(mocks.OutboxRepositoryMock)
+		publisher := new(mocks.PublisherMock)
+		svc := &outboxService{batchSize: 10, maxAttempts: 5, uow: newUnitOfWorkMock(), or: outboxRepo, pub: publisher}
+
+		outboxRepo.On("LockRelay", ctx).Return(true, nil)
+		outboxRepo.On("GetPendingEvents", ctx, 10).Return([]*models.OutboxEvent{
+			{ID: "event-1", Sequence: 1, AggregateID: "client-123"},
+			{ID: "event-2", Sequence: 2, AggregateID: "client-456"},
+			{ID: "event-3", Sequence: 3, AggregateID: "client-123"},
+		}, nil)
+		publisher.
+			On("Publish", ctx, mock.MatchedBy(func(m *models.EventMessage) bool { return m.ID == "event-1" })).
+			Return(errors.New("broker unavailable"))
+		publisher.
+			On("Publish", ctx, mock.MatchedBy(func(m *models.EventMessage) bool { return m.ID == "event-2" })).
+			Return(nil)
+		outboxRepo.On("MarkFailed", ctx, "event-1", "broker unavailable").Return(nil)
+		outboxRepo.On("MarkPublished", ctx, []string{"event-2"}, mock.AnythingOfType("time.Time")).Return(nil)
+
+		published, err := svc.Relay(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, published)
+		publisher.AssertNotCalled(t, "Publish", ctx, mock.MatchedBy(func(m *models.EventMessage) bool { return m.ID == "event-3" }))
+		outboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("should move an event to the dead letters after the last attempt", func(t *testing.T) {
+		outboxRepo := new(mocks.OutboxRepositoryMock)
+		publisher := new(mocks.PublisherMock)
+		svc := &outboxService{batchSize: 10, maxAttempts: 5, uow: newUnitOfWorkMock(), or: outboxRepo, pub: publisher}
+
+		outboxRepo.On("LockRelay", ctx).Return(true, nil)
+		outboxRepo.On("GetPendingEvents", ctx, 10).Return([]*models.OutboxEvent{
+			{ID: "event-1", Sequence: 1, AggregateID: "client-123", Attempts: 4},
+			{ID: "event-2", Sequence: 2, AggregateID: "client-456"},
+		}, nil)
+		publisher.
+			On("Publish", ctx, mock.MatchedBy(func(m *models.EventMessage) bool { return m.ID == "event-1" })).
+			Return(errors.New("payload rejected"))
+		publisher.
+			On("Publish", ctx, mock.MatchedBy(func(m *models.EventMessage) bool { return m.ID == "event-2" })).
+			Return(nil)
+		outboxRepo.On("MarkDead", ctx, "event-1", "payload rejected", mock.AnythingOfType("time.Time")).Return(nil)
+		outboxRepo.On("MarkPublished", ctx, []string{"event-2"}, mock.AnythingOfType("time.Time")).Return(nil)
+
+		published, err := svc.Relay(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, published)
+		outboxRepo.AssertNotCalled(t, "MarkFailed", mock.Anything, mock.Anything, mock.Anything)
+		outboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("should hold the next events of a client whose event moved to the dead letters", func(t *testing.T) {
+		outboxRepo := new(mocks.OutboxRepositoryMock)
+		publisher := new(mocks.PublisherMock)
+		svc := &outboxService{batchSize: 10, maxAttempts: 5, uow: newUnitOfWorkMock(), or: outboxRepo, pub: publisher}
+
+		outboxRepo.On("LockRelay", ctx).Return(true, nil)
+		outboxRepo.On("GetPendingEvents", ctx, 10).Return([]*models.OutboxEvent{
+			{ID: "event-1", Sequence: 1, AggregateID: "client-123", Attempts: 4},
+			{ID: "event-2", Sequence: 2, AggregateID: "client-123"},
+		}, nil)
+		publisher.
+			On("Publish", ctx, mock.MatchedBy(func(m *models.EventMessage) bool { return m.ID == "event-1" })).
+			Return(errors.New("payload rejected"))
+		outboxRepo.On("MarkDead", ctx, "event-1", "payload rejected", mock.AnythingOfType("time.Time")).Return(nil)
+		outboxRepo.On("MarkPublished", ctx, []string{}, mock.AnythingOfType("time.Time")).Return(nil)
+
+		published, err := svc.Relay(ctx)
+
+		assert.NoError(t, err)
+		assert.Zero(t, published)
+		publisher.AssertNotCalled(t, "Publish", ctx, mock.MatchedBy(func(m *models.EventMessage) bool { return m.ID == "event-2" }))
+		outboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("should skip the batch when another relay holds the lock", func(t *testing.T) {
+		outboxRepo := new(mocks.OutboxRepositoryMock)
+		svc := &outboxService{batchSize: 10, maxAttempts: 5, uow: newUnitOfWorkMock(), or: outboxRepo}
+
+		outboxRepo.On("LockRelay", ctx).Return(false, nil)
+
+		published, err := svc.Relay(ctx)
+
+		assert.NoError(t, err)
+		assert.Zero(t, published)
+		outboxRepo.AssertNotCalled(t, "GetPendingEvents", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error if events cannot be marked as published", func(t *testing.T) {
+		outboxRepo := new(mocks.OutboxRepositoryMock)
+		publisher := new(mocks.PublisherMock)
+		svc := &outboxService{batchSize: 10, maxAttempts: 5, uow: newUnitOfWorkMock(), or: outboxRepo, pub: publisher}
+
+		outboxRepo.On("LockRelay", ctx).Return(true, nil)
+		outboxRepo.On("GetPendingEvents", ctx, 10).Return([]*models.OutboxEvent{{ID: "event-1", AggregateID: "client-123"}}, nil)
+		publisher.On("Publish", ctx, mock.Anything).Return(nil)
+		outboxRepo.On("MarkPublished", ctx, mock.Anything, mock.Anything).Return(errors.New("db failure"))
+
+		published, err := svc.Relay(ctx)
+
+		assert.Zero(t, published)
+		assert.EqualError(t, err, "mark outbox events as published: db failure")
+	})
+}
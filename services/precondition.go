@@ -0,0 +1,15 @@
+package services
+
+import "github.com/g-villarinho/nubank-challenge/models"
+
+// AnyVersion representa o If-Match: *, que aceita qualquer versão atual do recurso
+const AnyVersion int64 = 0
+
+// checkVersion compara a versão enviada no If-Match com a versão atual do recurso
+func checkVersion(expected int64, current int64) error {
+	if expected != AnyVersion && expected != current {
+		return models.ErrPreconditionFailed
+	}
+
+	return nil
+}
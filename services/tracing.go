@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/tracing"
+)
+
+// tracedClientService abre um span por chamada ao ClientService, entre o span da requisição e
+// os spans das consultas, para separar o tempo gasto no serviço do gasto no Postgres
+type tracedClientService struct {
+	next ClientService
+}
+
+// tracedContactService faz o mesmo que o tracedClientService para o ContactService
+type tracedContactService struct {
+	next ContactService
+}
+
+func (t *tracedClientService) CreateClient(ctx context.Context, name string, contacts []*models.Contact) (*models.ClientResponse, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.CreateClient")
+	result, err := t.next.CreateClient(ctx, name, contacts)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (t *tracedClientService) GetClientsWithContact(ctx context.Context, query models.ListClientsQuery) (*models.ClientPageResponse, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.GetClientsWithContact")
+	result, err := t.next.GetClientsWithContact(ctx, query)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (t *tracedClientService) GetClientContactsByID(ctx context.Context, id string) ([]models.ContactResponse, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.GetClientContactsByID")
+	result, err := t.next.GetClientContactsByID(ctx, id)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (t *tracedClientService) GetClient(ctx context.Context, id string, includeContacts bool) (*models.ClientResponse, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.GetClient")
+	result, err := t.next.GetClient(ctx, id, includeContacts)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (t *tracedClientService) UpdateClient(ctx context.Context, id string, version int64, name string) (*models.ClientResponse, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.UpdateClient")
+	result, err := t.next.UpdateClient(ctx, id, version, name)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (t *tracedClientService) PatchClient(ctx context.Context, id string, version int64, patch []byte) (*models.ClientResponse, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.PatchClient")
+	result, err := t.next.PatchClient(ctx, id, version, patch)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (t *tracedClientService) DeleteClient(ctx context.Context, id string, version int64) error {
+	ctx, span := tracing.Start(ctx, "ClientService.DeleteClient")
+	err := t.next.DeleteClient(ctx, id, version)
+	tracing.End(span, err)
+
+	return err
+}
+
+func (t *tracedClientService) RestoreClient(ctx context.Context, id string) (*models.ClientResponse, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.RestoreClient")
+	result, err := t.next.RestoreClient(ctx, id)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (t *tracedClientService) GetDeletedClients(ctx context.Context) ([]models.ClientResponse, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.GetDeletedClients")
+	result, err := t.next.GetDeletedClients(ctx)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (t *tracedClientService) PurgeDeletedClients(ctx context.Context, retention time.Duration) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.PurgeDeletedClients")
+	result, err := t.next.PurgeDeletedClients(ctx, retention)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (t *tracedContactService) CreateContact(ctx context.Context, phone string, email string, clientId string) (*models.ContactResponse, error) {
+	ctx, span := tracing.Start(ctx, "ContactService.CreateContact")
+	result, err := t.next.CreateContact(ctx, phone, email, clientId)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (t *tracedContactService) GetContactByID(ctx context.Context, id string) (*models.ContactResponse, error) {
+	ctx, span := tracing.Start(ctx, "ContactService.GetContactByID")
+	result, err := t.next.GetContactByID(ctx, id)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (t *tracedContactService) UpdateContact(ctx context.Context, id string, version int64, phone string, email string) (*models.ContactResponse, error) {
+	ctx, span := tracing.Start(ctx, "ContactService.UpdateContact")
+	result, err := t.next.UpdateContact(ctx, id, version, phone, email)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (t *tracedContactService) PatchContact(ctx context.Context, id string, version int64, patch []byte) (*models.ContactResponse, error) {
+	ctx, span := tracing.Start(ctx, "ContactService.PatchContact")
+	result, err := t.next.PatchContact(ctx, id, version, patch)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (t *tracedContactService) DeleteContact(ctx context.Context, id string, version int64) error {
+	ctx, span := tracing.Start(ctx, "ContactService.DeleteContact")
+	err := t.next.DeleteContact(ctx, id, version)
+	tracing.End(span, err)
+
+	return err
+}
+
+func (t *tracedContactService) TransferContact(ctx context.Context, id string, clientId string) (*models.ContactResponse, error) {
+	ctx, span := tracing.Start(ctx, "ContactService.TransferContact")
+	result, err := t.next.TransferContact(ctx, id, clientId)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (t *tracedContactService) SearchContacts(ctx context.Context, email string, phone string) ([]models.ContactResponse, error) {
+	ctx, span := tracing.Start(ctx, "ContactService.SearchContacts")
+	result, err := t.next.SearchContacts(ctx, email, phone)
+	tracing.End(span, err)
+
+	return result, err
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/g-villarinho/nubank-challenge/mocks"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracedContactService(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	t.Run("should call the service inside a span named after the method", func(t *testing.T) {
+		contactService := new(mocks.ContactServiceMock)
+		svc := &tracedContactService{next: contactService}
+
+		var spanContext trace.SpanContext
+		contactService.
+			On("GetContactByID", mock.Anything, "contact-1").
+			Run(func(args mock.Arguments) {
+				spanContext = trace.SpanContextFromContext(args.Get(0).(context.Context))
+			}).
+			Return(&models.ContactResponse{ID: "contact-1"}, nil)
+
+		result, err := svc.GetContactByID(context.Background(), "contact-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "contact-1", result.ID)
+		spans := recorder.Ended()
+		require.NotEmpty(t, spans)
+		span := spans[len(spans)-1]
+		assert.Equal(t, "ContactService.GetContactByID", span.Name())
+		assert.Equal(t, span.SpanContext().SpanID(), spanContext.SpanID())
+		assert.Equal(t, codes.Unset, span.Status().Code)
+	})
+
+	t.Run("should record the error returned by the service", func(t *testing.T) {
+		contactService := new(mocks.ContactServiceMock)
+		svc := &tracedContactService{next: contactService}
+
+		contactService.On("DeleteContact", mock.Anything, "contact-1", int64(2)).Return(models.ErrPreconditionFailed)
+
+		err := svc.DeleteContact(context.Background(), "contact-1", 2)
+
+		assert.ErrorIs(t, err, models.ErrPreconditionFailed)
+		spans := recorder.Ended()
+		require.NotEmpty(t, spans)
+		span := spans[len(spans)-1]
+		assert.Equal(t, "ContactService.DeleteContact", span.Name())
+		assert.Equal(t, codes.Error, span.Status().Code)
+	})
+}
@@ -0,0 +1,459 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/configs"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/repositories"
+	"github.com/g-villarinho/nubank-challenge/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// errWebhookAddressForbidden é devolvido ao discar para um endereço interno, para que uma
+// inscrição não sirva de ponte para a rede da API
+var errWebhookAddressForbidden = errors.New("webhook address is not allowed")
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, payload models.CreateWebhookPayload) (*models.WebhookResponse, error)
+	GetWebhookByID(ctx context.Context, id string) (*models.WebhookResponse, error)
+	GetWebhooks(ctx context.Context) ([]models.WebhookResponse, error)
+	UpdateWebhook(ctx context.Context, id string, payload models.UpdateWebhookPayload) (*models.WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	GetDeliveries(ctx context.Context, id string, status string, limit int) ([]models.WebhookDeliveryResponse, error)
+	GetDeadLetters(ctx context.Context, limit int) ([]models.WebhookDeliveryResponse, error)
+	Redeliver(ctx context.Context, deliveryID string) (*models.WebhookDeliveryResponse, error)
+	Dispatch(ctx context.Context) (int, error)
+	PurgeFinishedDeliveries(ctx context.Context) (int64, error)
+}
+
+type webhookService struct {
+	di          *pkgs.Di
+	client      *http.Client
+	maxAttempts int
+	retryBase   time.Duration
+	retryMax    time.Duration
+	batchSize   int
+	retention   time.Duration
+	uow         repositories.UnitOfWork
+	wr          repositories.WebhookRepository
+}
+
+func NewWebhookService(di *pkgs.Di) (WebhookService, error) {
+	unitOfWork, err := pkgs.Invoke[repositories.UnitOfWork](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke repositories.unit_of_work: %w", err)
+	}
+
+	webhookRepository, err := pkgs.Invoke[repositories.WebhookRepository](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke repositories.webhook: %w", err)
+	}
+
+	return &webhookService{
+		di:          di,
+		client:      newWebhookClient(time.Duration(configs.Env.Webhook.TimeoutSeconds) * time.Second),
+		maxAttempts: configs.Env.Webhook.MaxAttempts,
+		retryBase:   time.Duration(configs.Env.Webhook.RetryBaseSeconds) * time.Second,
+		retryMax:    time.Duration(configs.Env.Webhook.RetryMaxSeconds) * time.Second,
+		batchSize:   configs.Env.Webhook.BatchSize,
+		retention:   time.Duration(configs.Env.Outbox.RetentionHours) * time.Hour,
+		uow:         unitOfWork,
+		wr:          webhookRepository,
+	}, nil
+}
+
+func (w *webhookService) CreateWebhook(ctx context.Context, payload models.CreateWebhookPayload) (*models.WebhookResponse, error) {
+	subscription := &models.WebhookSubscription{
+		URL:    payload.URL,
+		Secret: payload.Secret,
+		Active: payload.Active == nil || *payload.Active,
+	}
+	subscription.SetEvents(payload.EventTypes)
+
+	if err := w.wr.CreateSubscription(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("create webhook subscription: %w", err)
+	}
+
+	return subscription.ToWebhookResponse(), nil
+}
+
+func (w *webhookService) GetWebhookByID(ctx context.Context, id string) (*models.WebhookResponse, error) {
+	subscription, err := w.getSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription.ToWebhookResponse(), nil
+}
+
+func (w *webhookService) GetWebhooks(ctx context.Context) ([]models.WebhookResponse, error) {
+	subscriptions, err := w.wr.GetSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook subscriptions: %w", err)
+	}
+
+	response := make([]models.WebhookResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		response = append(response, *subscription.ToWebhookResponse())
+	}
+
+	return response, nil
+}
+
+func (w *webhookService) UpdateWebhook(ctx context.Context, id string, payload models.UpdateWebhookPayload) (*models.WebhookResponse, error) {
+	subscription, err := w.getSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.URL = payload.URL
+	subscription.Active = *payload.Active
+	subscription.SetEvents(payload.EventTypes)
+
+	if payload.Secret != "" {
+		subscription.Secret = payload.Secret
+	}
+
+	if err := w.wr.UpdateSubscription(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("update webhook subscription %s: %w", id, err)
+	}
+
+	return subscription.ToWebhookResponse(), nil
+}
+
+func (w *webhookService) DeleteWebhook(ctx context.Context, id string) error {
+	if _, err := w.getSubscription(ctx, id); err != nil {
+		return err
+	}
+
+	if err := w.wr.DeleteSubscription(ctx, id); err != nil {
+		return fmt.Errorf("delete webhook subscription %s: %w", id, err)
+	}
+
+	return nil
+}
+
+func (w *webhookService) GetDeliveries(ctx context.Context, id string, status string, limit int) ([]models.WebhookDeliveryResponse, error) {
+	if _, err := w.getSubscription(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return w.getDeliveries(ctx, models.WebhookDeliveryListOptions{SubscriptionID: id, Status: status, Limit: limit})
+}
+
+// GetDeadLetters retorna as entregas que esgotaram as tentativas, de todas as inscrições
+func (w *webhookService) GetDeadLetters(ctx context.Context, limit int) ([]models.WebhookDeliveryResponse, error) {
+	return w.getDeliveries(ctx, models.WebhookDeliveryListOptions{Status: models.WebhookDeliveryDead, Limit: limit})
+}
+
+// Redeliver agenda uma nova entrega imediata com um novo ciclo de tentativas, sem zerar a
+// contagem, que continua numerando o log de tentativas. Entregas ainda pendentes não podem ser
+// reagendadas.
+func (w *webhookService) Redeliver(ctx context.Context, deliveryID string) (*models.WebhookDeliveryResponse, error) {
+	delivery, err := w.wr.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook delivery by id %s: %w", deliveryID, err)
+	}
+
+	if delivery == nil {
+		return nil, models.ErrWebhookDeliveryNotFound
+	}
+
+	if delivery.Status == models.WebhookDeliveryPending {
+		return nil, fmt.Errorf("%w: delivery %s is still pending", models.ErrConflict, deliveryID)
+	}
+
+	delivery.Status = models.WebhookDeliveryPending
+	delivery.AttemptsBeforeRedelivery = delivery.Attempts
+	delivery.NextAttemptAt = time.Now().UTC()
+	delivery.DeliveredAt.Valid = false
+
+	if err := w.wr.UpdateDelivery(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("redeliver webhook delivery %s: %w", deliveryID, err)
+	}
+
+	return delivery.ToWebhookDeliveryResponse(), nil
+}
+
+// Dispatch envia um lote de entregas vencidas e retorna quantas foram tentadas. As entregas
+// são reservadas por tempo suficiente para o envio, que acontece fora da transação; uma
+// resposta 2xx conclui a entrega e qualquer outro resultado agenda uma nova tentativa com
+// backoff exponencial, até que WEBHOOK_MAX_ATTEMPTS mande a entrega para as dead letters. Uma
+// entrega cujo resultado não pôde ser gravado não interrompe o lote; ela volta a ser reservada
+// quando a reserva expirar.
+func (w *webhookService) Dispatch(ctx context.Context) (int, error) {
+	logger := pkgs.LoggerFromContext(ctx).With(
+		slog.String("service", "webhook"),
+		slog.String("method", "Dispatch"),
+	)
+
+	var deliveries []*models.WebhookDelivery
+	err := w.uow.Do(ctx, func(ctx context.Context) error {
+		now := time.Now().UTC()
+		claimed, err := w.wr.ClaimDueDeliveries(ctx, now, now.Add(w.lease()), w.batchSize)
+		if err != nil {
+			return fmt.Errorf("claim due webhook deliveries: %w", err)
+		}
+
+		deliveries = claimed
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		if err := w.deliver(ctx, delivery); err != nil {
+			logger.Error("error to deliver webhook", "delivery", delivery.ID, "error", err)
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// deliver envia a entrega uma vez e grava o resultado junto com o log da tentativa
+func (w *webhookService) deliver(ctx context.Context, delivery *models.WebhookDelivery) error {
+	logger := pkgs.LoggerFromContext(ctx).With(
+		slog.String("service", "webhook"),
+		slog.String("method", "deliver"),
+	)
+
+	startedAt := time.Now()
+	statusCode, sendErr := w.send(ctx, delivery)
+
+	delivery.Attempts++
+	attempt := &models.WebhookAttempt{
+		DeliveryID: delivery.ID,
+		TenantID:   delivery.TenantID,
+		Attempt:    delivery.Attempts,
+		StatusCode: statusCode,
+		DurationMs: time.Since(startedAt).Milliseconds(),
+	}
+
+	delivery.LastStatusCode = statusCode
+	delivery.LastError = ""
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = models.WebhookDeliveryDelivered
+		delivery.DeliveredAt.Time = time.Now().UTC()
+		delivery.DeliveredAt.Valid = true
+	case delivery.Attempts-delivery.AttemptsBeforeRedelivery >= w.maxAttempts:
+		logger.Warn("webhook delivery moved to dead letters", "delivery", delivery.ID, "attempts", delivery.Attempts, "error", sendErr)
+		delivery.Status = models.WebhookDeliveryDead
+	default:
+		delivery.NextAttemptAt = time.Now().UTC().Add(w.backoff(delivery.Attempts - delivery.AttemptsBeforeRedelivery))
+	}
+
+	if sendErr != nil {
+		delivery.LastError = sendErr.Error()
+		attempt.Error = sendErr.Error()
+	}
+
+	return w.uow.Do(ctx, func(ctx context.Context) error {
+		if err := w.wr.CreateAttempt(ctx, attempt); err != nil {
+			return fmt.Errorf("create attempt of webhook delivery %s: %w", delivery.ID, err)
+		}
+
+		if err := w.wr.UpdateDelivery(ctx, delivery); err != nil {
+			return fmt.Errorf("update webhook delivery %s: %w", delivery.ID, err)
+		}
+
+		return nil
+	})
+}
+
+// send faz o POST assinado para a URL da inscrição dentro de um span, levando o traceparent
+// para que o parceiro possa continuar o trace
+func (w *webhookService) send(ctx context.Context, delivery *models.WebhookDelivery) (int, error) {
+	ctx, span := tracing.Start(ctx, "POST webhook",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(http.MethodPost),
+			attribute.String("webhook.subscription_id", delivery.SubscriptionID),
+			attribute.String("webhook.delivery_id", delivery.ID),
+		),
+	)
+
+	statusCode, err := w.post(ctx, delivery)
+	if statusCode != 0 {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(statusCode))
+	}
+	tracing.End(span, err)
+
+	return statusCode, err
+}
+
+// post envia a entrega. O corpo é assinado junto com o timestamp, no formato de
+// pkgs.SignPayload, para que o parceiro possa recusar reenvios antigos.
+func (w *webhookService) post(ctx context.Context, delivery *models.WebhookDelivery) (int, error) {
+	timestamp := time.Now().UTC()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+
+	tracing.Inject(ctx, request.Header)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(models.HeaderWebhookID, delivery.EventID)
+	request.Header.Set(models.HeaderWebhookTimestamp, strconv.FormatInt(timestamp.Unix(), 10))
+	request.Header.Set(models.HeaderWebhookSignature, pkgs.SignPayload(delivery.Subscription.Secret, timestamp, delivery.Payload))
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	// O corpo da resposta não é guardado: ele apareceria no log de tentativas e em dead letters
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
+
+// newWebhookClient cria o cliente das entregas. O endereço é conferido na discagem, já resolvido,
+// para que um DNS que muda de resposta não leve a requisição para a rede interna, e os
+// redirecionamentos não são seguidos: a resposta 3xx conta como falha da tentativa.
+func newWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: denyInternalAddress}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Com um proxy a discagem seria para ele, e o endereço do parceiro não seria conferido
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// deniedWebhookPrefixes são as faixas que uma entrega não pode alcançar: as redes locais,
+// privadas, compartilhadas e reservadas dos registros de endereços especiais do IANA, o
+// multicast e os prefixos IPv6 que embutem um endereço IPv4
+var deniedWebhookPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // esta rede
+	netip.MustParsePrefix("10.0.0.0/8"),      // privada
+	netip.MustParsePrefix("100.64.0.0/10"),   // espaço compartilhado (CGNAT)
+	netip.MustParsePrefix("127.0.0.0/8"),     // loopback
+	netip.MustParsePrefix("169.254.0.0/16"),  // link-local, inclusive o metadata das nuvens
+	netip.MustParsePrefix("172.16.0.0/12"),   // privada
+	netip.MustParsePrefix("192.0.0.0/24"),    // atribuições de protocolo do IETF
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentação (TEST-NET-1)
+	netip.MustParsePrefix("192.88.99.0/24"),  // relay anycast do 6to4
+	netip.MustParsePrefix("192.168.0.0/16"),  // privada
+	netip.MustParsePrefix("198.18.0.0/15"),   // testes de desempenho
+	netip.MustParsePrefix("198.51.100.0/24"), // documentação (TEST-NET-2)
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentação (TEST-NET-3)
+	netip.MustParsePrefix("224.0.0.0/4"),     // multicast
+	netip.MustParsePrefix("240.0.0.0/4"),     // reservada e broadcast
+	netip.MustParsePrefix("::/96"),           // não especificado, loopback e IPv4-compatíveis
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // NAT64 local
+	netip.MustParsePrefix("100::/64"),        // descarte
+	netip.MustParsePrefix("2001::/32"),       // Teredo
+	netip.MustParsePrefix("2001:db8::/32"),   // documentação
+	netip.MustParsePrefix("2002::/16"),       // 6to4
+	netip.MustParsePrefix("fc00::/7"),        // unique local
+	netip.MustParsePrefix("fe80::/10"),       // link-local
+	netip.MustParsePrefix("fec0::/10"),       // site-local
+	netip.MustParsePrefix("ff00::/8"),        // multicast
+}
+
+// denyInternalAddress recusa os endereços de deniedWebhookPrefixes. Um IPv6 mapeado
+// (::ffff:a.b.c.d) é conferido como o IPv4 que representa.
+func denyInternalAddress(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("split webhook address: %w", err)
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("parse webhook address: %w", err)
+	}
+
+	// Um prefixo nunca contém um endereço com zona, então a zona é descartada antes da conferência
+	ip = ip.Unmap().WithZone("")
+	for _, prefix := range deniedWebhookPrefixes {
+		if prefix.Contains(ip) {
+			return fmt.Errorf("%w: %s", errWebhookAddressForbidden, ip)
+		}
+	}
+
+	return nil
+}
+
+// backoff retorna a espera antes da próxima tentativa: base * 2^(tentativas - 1), limitada ao máximo
+func (w *webhookService) backoff(attempts int) time.Duration {
+	delay := w.retryBase
+	for i := 1; i < attempts && delay < w.retryMax; i++ {
+		delay *= 2
+	}
+
+	return min(delay, w.retryMax)
+}
+
+// lease é o tempo de reserva de um lote: o bastante para enviar todas as entregas mesmo que
+// cada uma esgote o timeout da requisição
+func (w *webhookService) lease() time.Duration {
+	return time.Duration(w.batchSize+1) * w.client.Timeout
+}
+
+func (w *webhookService) getDeliveries(ctx context.Context, opts models.WebhookDeliveryListOptions) ([]models.WebhookDeliveryResponse, error) {
+	deliveries, err := w.wr.GetDeliveries(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook deliveries: %w", err)
+	}
+
+	response := make([]models.WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		response = append(response, *delivery.ToWebhookDeliveryResponse())
+	}
+
+	return response, nil
+}
+
+func (w *webhookService) getSubscription(ctx context.Context, id string) (*models.WebhookSubscription, error) {
+	subscription, err := w.wr.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook subscription by id %s: %w", id, err)
+	}
+
+	if subscription == nil {
+		return nil, models.ErrWebhookNotFound
+	}
+
+	return subscription, nil
+}
+
+// PurgeFinishedDeliveries remove as entregas concluídas há mais tempo que a retenção dos eventos
+// do outbox, já que elas guardam uma cópia do evento
+func (w *webhookService) PurgeFinishedDeliveries(ctx context.Context) (int64, error) {
+	purged, err := w.wr.PurgeFinishedDeliveries(ctx, time.Now().UTC().Add(-w.retention))
+	if err != nil {
+		return 0, fmt.Errorf("purge finished webhook deliveries: %w", err)
+	}
+
+	return purged, nil
+}
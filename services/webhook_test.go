@@ -0,0 +1,541 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/mocks"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const webhookSecret = "whsec_5f2b7c9d1e3a4b6c"
+
+func newWebhookService(webhookRepo *mocks.WebhookRepositoryMock) *webhookService {
+	// Os receptores de teste escutam em loopback, então o transporte padrão substitui o que
+	// recusa endereços internos
+	client := newWebhookClient(time.Second)
+	client.Transport = http.DefaultTransport
+
+	return &webhookService{
+		client:      client,
+		maxAttempts: 3,
+		retryBase:   30 * time.Second,
+		retryMax:    time.Hour,
+		batchSize:   10,
+		uow:         newUnitOfWorkMock(),
+		wr:          webhookRepo,
+	}
+}
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should create an active subscription without exposing the secret", func(t *testing.T) {
+		webhookRepo := new(mocks.WebhookRepositoryMock)
+		svc := newWebhookService(webhookRepo)
+
+		webhookRepo.
+			On("CreateSubscription", ctx, mock.MatchedBy(func(s *models.WebhookSubscription) bool {
+				return s.URL == "https://partner.example.com/webhooks" &&
+					s.EventTypes == "ClientCreated,ContactAdded" &&
+					s.Secret == webhookSecret &&
+					s.Active
+			})).
+			Run(func(args mock.Arguments) {
+				args.Get(1).(*models.WebhookSubscription).ID = "webhook-1"
+			}).
+			Return(nil)
+
+		response, err := svc.CreateWebhook(ctx, models.CreateWebhookPayload{
+			URL:        "https://partner.example.com/webhooks",
+			EventTypes: []string{models.EventContactAdded, models.EventClientCreated, models.EventContactAdded},
+			Secret:     webhookSecret,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "webhook-1", response.ID)
+		assert.Equal(t, []string{models.EventClientCreated, models.EventContactAdded}, response.EventTypes)
+		webhookRepo.AssertExpectations(t)
+	})
+}
+
+func TestWebhookService_UpdateWebhook(t *testing.T) {
+	ctx := context.Background()
+	active := false
+
+	t.Run("should keep the current secret when none is given", func(t *testing.T) {
+		webhookRepo := new(mocks.WebhookRepositoryMock)
+		svc := newWebhookService(webhookRepo)
+
+		webhookRepo.On("GetSubscriptionByID", ctx, "webhook-1").Return(&models.WebhookSubscription{
+			ID: "webhook-1", URL: "https://old.example.com", EventTypes: "ClientCreated", Secret: webhookSecret, Active: true,
+		}, nil)
+		webhookRepo.
+			On("UpdateSubscription", ctx, mock.MatchedBy(func(s *models.WebhookSubscription) bool {
+				return s.URL == "https://partner.example.com/webhooks" &&
+					s.EventTypes == "ClientDeleted" &&
+					s.Secret == webhookSecret &&
+					!s.Active
+			})).
+			Return(nil)
+
+		response, err := svc.UpdateWebhook(ctx, "webhook-1", models.UpdateWebhookPayload{
+			URL:        "https://partner.example.com/webhooks",
+			EventTypes: []string{models.EventClientDeleted},
+			Active:     &active,
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, response.Active)
+		webhookRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return not found if the subscription does not exist", func(t *testing.T) {
+		webhookRepo := new(mocks.WebhookRepositoryMock)
+		svc := newWebhookService(webhookRepo)
+
+		webhookRepo.On("GetSubscriptionByID", ctx, "webhook-1").Return(nil, nil)
+
+		response, err := svc.UpdateWebhook(ctx, "webhook-1", models.UpdateWebhookPayload{Active: &active})
+
+		assert.ErrorIs(t, err, models.ErrWebhookNotFound)
+		assert.Nil(t, response)
+	})
+}
+
+func TestWebhookService_Redeliver(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should schedule a dead delivery with a fresh attempt budget", func(t *testing.T) {
+		webhookRepo := new(mocks.WebhookRepositoryMock)
+		svc := newWebhookService(webhookRepo)
+
+		webhookRepo.On("GetDeliveryByID", ctx, "delivery-1").Return(&models.WebhookDelivery{
+			ID: "delivery-1", Status: models.WebhookDeliveryDead, Attempts: 3,
+		}, nil)
+		webhookRepo.
+			On("UpdateDelivery", ctx, mock.MatchedBy(func(d *models.WebhookDelivery) bool {
+				return d.Status == models.WebhookDeliveryPending &&
+					d.Attempts == 3 &&
+					d.AttemptsBeforeRedelivery == 3 &&
+					!d.NextAttemptAt.IsZero()
+			})).
+			Return(nil)
+
+		response, err := svc.Redeliver(ctx, "delivery-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.WebhookDeliveryPending, response.Status)
+		webhookRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return conflict if the delivery is still pending", func(t *testing.T) {
+		webhookRepo := new(mocks.WebhookRepositoryMock)
+		svc := newWebhookService(webhookRepo)
+
+		webhookRepo.On("GetDeliveryByID", ctx, "delivery-1").Return(&models.WebhookDelivery{
+			ID: "delivery-1", Status: models.WebhookDeliveryPending,
+		}, nil)
+
+		response, err := svc.Redeliver(ctx, "delivery-1")
+
+		assert.ErrorIs(t, err, models.ErrConflict)
+		assert.Nil(t, response)
+		webhookRepo.AssertNotCalled(t, "UpdateDelivery", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return not found if the delivery does not exist", func(t *testing.T) {
+		webhookRepo := new(mocks.WebhookRepositoryMock)
+		svc := newWebhookService(webhookRepo)
+
+		webhookRepo.On("GetDeliveryByID", ctx, "delivery-1").Return(nil, nil)
+
+		_, err := svc.Redeliver(ctx, "delivery-1")
+
+		assert.ErrorIs(t, err, models.ErrWebhookDeliveryNotFound)
+	})
+}
+
+func TestWebhookService_Dispatch(t *testing.T) {
+	ctx := context.Background()
+	payload := []byte(`{"id":"event-1","type":"ClientCreated"}`)
+
+	newDelivery := func(url string, attempts int) *models.WebhookDelivery {
+		return &models.WebhookDelivery{
+			ID:             "delivery-1",
+			SubscriptionID: "webhook-1",
+			Subscription:   models.WebhookSubscription{ID: "webhook-1", URL: url, Secret: webhookSecret, Active: true},
+			EventID:        "event-1",
+			TenantID:       "acme",
+			EventType:      models.EventClientCreated,
+			Payload:        payload,
+			Status:         models.WebhookDeliveryPending,
+			Attempts:       attempts,
+		}
+	}
+
+	t.Run("should send a signed request and mark the delivery as delivered", func(t *testing.T) {
+		var verifyErr error
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			verifyErr = pkgs.VerifySignature(webhookSecret, r.Header.Get(models.HeaderWebhookSignature), body, time.Minute)
+
+			assert.Equal(t, "event-1", r.Header.Get(models.HeaderWebhookID))
+			assert.NotEmpty(t, r.Header.Get(models.HeaderWebhookTimestamp))
+			assert.JSONEq(t, string(payload), string(body))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		webhookRepo := new(mocks.WebhookRepositoryMock)
+		svc := newWebhookService(webhookRepo)
+
+		webhookRepo.
+			On("ClaimDueDeliveries", ctx, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), 10).
+			Return([]*models.WebhookDelivery{newDelivery(receiver.URL, 0)}, nil)
+		webhookRepo.
+			On("CreateAttempt", ctx, mock.MatchedBy(func(a *models.WebhookAttempt) bool {
+				return a.DeliveryID == "delivery-1" && a.TenantID == "acme" && a.Attempt == 1 && a.StatusCode == http.StatusNoContent && a.Error == ""
+			})).
+			Return(nil)
+		webhookRepo.
+			On("UpdateDelivery", ctx, mock.MatchedBy(func(d *models.WebhookDelivery) bool {
+				return d.Status == models.WebhookDeliveryDelivered && d.Attempts == 1 && d.DeliveredAt.Valid
+			})).
+			Return(nil)
+
+		dispatched, err := svc.Dispatch(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, dispatched)
+		assert.NoError(t, verifyErr)
+		webhookRepo.AssertExpectations(t)
+	})
+
+	t.Run("should schedule a retry with exponential backoff on failure", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}))
+		defer receiver.Close()
+
+		webhookRepo := new(mocks.WebhookRepositoryMock)
+		svc := newWebhookService(webhookRepo)
+		startedAt := time.Now().UTC()
+
+		webhookRepo.
+			On("ClaimDueDeliveries", ctx, mock.Anything, mock.Anything, 10).
+			Return([]*models.WebhookDelivery{newDelivery(receiver.URL, 1)}, nil)
+		webhookRepo.
+			On("CreateAttempt", ctx, mock.MatchedBy(func(a *models.WebhookAttempt) bool {
+				return a.Attempt == 2 && a.StatusCode == http.StatusServiceUnavailable && a.Error == "unexpected status 503"
+			})).
+			Return(nil)
+		webhookRepo.
+			On("UpdateDelivery", ctx, mock.MatchedBy(func(d *models.WebhookDelivery) bool {
+				return d.Status == models.WebhookDeliveryPending &&
+					d.Attempts == 2 &&
+					d.LastStatusCode == http.StatusServiceUnavailable &&
+					d.LastError == "unexpected status 503" &&
+					!d.NextAttemptAt.Before(startedAt.Add(time.Minute))
+			})).
+			Return(nil)
+
+		dispatched, err := svc.Dispatch(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, dispatched)
+		webhookRepo.AssertExpectations(t)
+	})
+
+	t.Run("should move the delivery to dead letters after the last attempt", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer receiver.Close()
+
+		webhookRepo := new(mocks.WebhookRepositoryMock)
+		svc := newWebhookService(webhookRepo)
+
+		webhookRepo.
+			On("ClaimDueDeliveries", ctx, mock.Anything, mock.Anything, 10).
+			Return([]*models.WebhookDelivery{newDelivery(receiver.URL, 2)}, nil)
+		webhookRepo.On("CreateAttempt", ctx, mock.Anything).Return(nil)
+		webhookRepo.
+			On("UpdateDelivery", ctx, mock.MatchedBy(func(d *models.WebhookDelivery) bool {
+				return d.Status == models.WebhookDeliveryDead && d.Attempts == 3
+			})).
+			Return(nil)
+
+		_, err := svc.Dispatch(ctx)
+
+		assert.NoError(t, err)
+		webhookRepo.AssertExpectations(t)
+	})
+
+	t.Run("should count a redelivered delivery's attempts from the redelivery", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer receiver.Close()
+
+		webhookRepo := new(mocks.WebhookRepositoryMock)
+		svc := newWebhookService(webhookRepo)
+
+		delivery := newDelivery(receiver.URL, 3)
+		delivery.AttemptsBeforeRedelivery = 3
+
+		webhookRepo.
+			On("ClaimDueDeliveries", ctx, mock.Anything, mock.Anything, 10).
+			Return([]*models.WebhookDelivery{delivery}, nil)
+		webhookRepo.
+			On("CreateAttempt", ctx, mock.MatchedBy(func(a *models.WebhookAttempt) bool {
+				return a.Attempt == 4
+			})).
+			Return(nil)
+		webhookRepo.
+			On("UpdateDelivery", ctx, mock.MatchedBy(func(d *models.WebhookDelivery) bool {
+				return d.Status == models.WebhookDeliveryPending && d.Attempts == 4
+			})).
+			Return(nil)
+
+		_, err := svc.Dispatch(ctx)
+
+		assert.NoError(t, err)
+		webhookRepo.AssertExpectations(t)
+	})
+
+	t.Run("should keep delivering the batch when one result cannot be saved", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		webhookRepo := new(mocks.WebhookRepositoryMock)
+		svc := newWebhookService(webhookRepo)
+
+		first := newDelivery(receiver.URL, 0)
+		second := newDelivery(receiver.URL, 0)
+		second.ID = "delivery-2"
+
+		webhookRepo.
+			On("ClaimDueDeliveries", ctx, mock.Anything, mock.Anything, 10).
+			Return([]*models.WebhookDelivery{first, second}, nil)
+		webhookRepo.
+			On("CreateAttempt", ctx, mock.MatchedBy(func(a *models.WebhookAttempt) bool { return a.DeliveryID == "delivery-1" })).
+			Return(errors.New("db failure"))
+		webhookRepo.
+			On("CreateAttempt", ctx, mock.MatchedBy(func(a *models.WebhookAttempt) bool { return a.DeliveryID == "delivery-2" })).
+			Return(nil)
+		webhookRepo.
+			On("UpdateDelivery", ctx, mock.MatchedBy(func(d *models.WebhookDelivery) bool { return d.ID == "delivery-2" })).
+			Return(nil)
+
+		dispatched, err := svc.Dispatch(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, dispatched)
+		webhookRepo.AssertExpectations(t)
+	})
+
+	t.Run("should send the trace context of the delivery span to the receiver", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+		previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+		t.Cleanup(func() {
+			otel.SetTracerProvider(previousProvider)
+			otel.SetTextMapPropagator(previousPropagator)
+		})
+
+		var traceparent string
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			traceparent = r.Header.Get("traceparent")
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		webhookRepo := new(mocks.WebhookRepositoryMock)
+		svc := newWebhookService(webhookRepo)
+
+		webhookRepo.
+			On("ClaimDueDeliveries", ctx, mock.Anything, mock.Anything, 10).
+			Return([]*models.WebhookDelivery{newDelivery(receiver.URL, 0)}, nil)
+		webhookRepo.On("CreateAttempt", ctx, mock.Anything).Return(nil)
+		webhookRepo.On("UpdateDelivery", ctx, mock.Anything).Return(nil)
+
+		_, err := svc.Dispatch(ctx)
+
+		assert.NoError(t, err)
+		spans := recorder.Ended()
+		if assert.Len(t, spans, 1) {
+			span := spans[0]
+			assert.Equal(t, "POST webhook", span.Name())
+			assert.Equal(t, "00-"+span.SpanContext().TraceID().String()+"-"+span.SpanContext().SpanID().String()+"-01", traceparent)
+		}
+	})
+
+	t.Run("should not follow redirects", func(t *testing.T) {
+		var redirected bool
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			redirected = true
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer target.Close()
+
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+		}))
+		defer receiver.Close()
+
+		webhookRepo := new(mocks.WebhookRepositoryMock)
+		svc := newWebhookService(webhookRepo)
+
+		webhookRepo.
+			On("ClaimDueDeliveries", ctx, mock.Anything, mock.Anything, 10).
+			Return([]*models.WebhookDelivery{newDelivery(receiver.URL, 0)}, nil)
+		webhookRepo.
+			On("CreateAttempt", ctx, mock.MatchedBy(func(a *models.WebhookAttempt) bool {
+				return a.StatusCode == http.StatusTemporaryRedirect && a.Error == "unexpected status 307"
+			})).
+			Return(nil)
+		webhookRepo.
+			On("UpdateDelivery", ctx, mock.MatchedBy(func(d *models.WebhookDelivery) bool {
+				return d.Status == models.WebhookDeliveryPending
+			})).
+			Return(nil)
+
+		_, err := svc.Dispatch(ctx)
+
+		assert.NoError(t, err)
+		assert.False(t, redirected)
+		webhookRepo.AssertExpectations(t)
+	})
+
+	t.Run("should refuse to deliver to an internal address", func(t *testing.T) {
+		var received bool
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = true
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		webhookRepo := new(mocks.WebhookRepositoryMock)
+		svc := newWebhookService(webhookRepo)
+		svc.client = newWebhookClient(time.Second)
+
+		webhookRepo.
+			On("ClaimDueDeliveries", ctx, mock.Anything, mock.Anything, 10).
+			Return([]*models.WebhookDelivery{newDelivery(receiver.URL, 0)}, nil)
+		webhookRepo.
+			On("CreateAttempt", ctx, mock.MatchedBy(func(a *models.WebhookAttempt) bool {
+				return a.StatusCode == 0 && strings.Contains(a.Error, errWebhookAddressForbidden.Error())
+			})).
+			Return(nil)
+		webhookRepo.
+			On("UpdateDelivery", ctx, mock.MatchedBy(func(d *models.WebhookDelivery) bool {
+				return d.Status == models.WebhookDeliveryPending && d.LastStatusCode == 0
+			})).
+			Return(nil)
+
+		_, err := svc.Dispatch(ctx)
+
+		assert.NoError(t, err)
+		assert.False(t, received)
+		webhookRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error if deliveries cannot be claimed", func(t *testing.T) {
+		webhookRepo := new(mocks.WebhookRepositoryMock)
+		svc := newWebhookService(webhookRepo)
+
+		webhookRepo.On("ClaimDueDeliveries", ctx, mock.Anything, mock.Anything, 10).Return(nil, errors.New("db failure"))
+
+		dispatched, err := svc.Dispatch(ctx)
+
+		assert.EqualError(t, err, "claim due webhook deliveries: db failure")
+		assert.Zero(t, dispatched)
+	})
+}
+
+func TestDenyInternalAddress(t *testing.T) {
+	forbidden := map[string]string{
+		"loopback":                  "127.0.0.1:443",
+		"ipv6 loopback":             "[::1]:443",
+		"private class a":           "10.0.0.5:443",
+		"private class b":           "172.16.4.2:443",
+		"private class c":           "192.168.1.10:443",
+		"ipv6 unique local":         "[fd00::1]:443",
+		"link-local":                "169.254.169.254:80",
+		"ipv6 link-local":           "[fe80::1]:443",
+		"unspecified":               "0.0.0.0:443",
+		"ipv6 unspecified":          "[::]:443",
+		"ipv4-mapped ipv6 loopback": "[::ffff:127.0.0.1]:443",
+		"ipv4-mapped ipv6 private":  "[::ffff:10.0.0.5]:443",
+		"ipv4-mapped ipv6 cgnat":    "[::ffff:100.64.0.1]:443",
+		"this network":              "0.1.2.3:443",
+		"cgnat":                     "100.100.100.200:80",
+		"benchmarking":              "198.18.0.10:443",
+		"multicast":                 "224.0.0.251:443",
+		"broadcast":                 "255.255.255.255:443",
+		"ipv6 multicast":            "[ff02::1]:443",
+		"ipv6 link-local with zone": "[fe80::1%eth0]:443",
+		"nat64 private":             "[64:ff9b::a00:5]:443",
+		"6to4 private":              "[2002:a00:5::1]:443",
+	}
+
+	for name, address := range forbidden {
+		t.Run("should refuse "+name, func(t *testing.T) {
+			err := denyInternalAddress("tcp", address, nil)
+
+			assert.ErrorIs(t, err, errWebhookAddressForbidden)
+		})
+	}
+
+	t.Run("should allow a public address", func(t *testing.T) {
+		assert.NoError(t, denyInternalAddress("tcp", "93.184.215.14:443", nil))
+		assert.NoError(t, denyInternalAddress("tcp6", "[2606:2800:21f:cb07:6820:80da:af6b:8b2c]:443", nil))
+	})
+}
+
+func TestWebhookService_backoff(t *testing.T) {
+	svc := &webhookService{retryBase: 30 * time.Second, retryMax: 5 * time.Minute}
+
+	assert.Equal(t, 30*time.Second, svc.backoff(1))
+	assert.Equal(t, time.Minute, svc.backoff(2))
+	assert.Equal(t, 4*time.Minute, svc.backoff(4))
+	assert.Equal(t, 5*time.Minute, svc.backoff(5))
+	assert.Equal(t, 5*time.Minute, svc.backoff(30))
+}
+
+func TestWebhookService_PurgeFinishedDeliveries(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should remove the deliveries finished before the outbox retention", func(t *testing.T) {
+		webhookRepo := new(mocks.WebhookRepositoryMock)
+		service := &webhookService{retention: 168 * time.Hour, wr: webhookRepo}
+
+		webhookRepo.
+			On("PurgeFinishedDeliveries", ctx, mock.MatchedBy(func(before time.Time) bool {
+				return time.Since(before) >= 168*time.Hour && time.Since(before) < 169*time.Hour
+			})).
+			Return(int64(4), nil)
+
+		purged, err := service.PurgeFinishedDeliveries(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), purged)
+	})
+}
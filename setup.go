@@ -2,43 +2,320 @@ package main
 
 import (
 	"context"
+	"crypto/subtle"
+	"fmt"
 	"log"
+	"log/slog"
+	"net"
+	"net/netip"
+	"os"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/g-villarinho/nubank-challenge/configs"
+	"github.com/g-villarinho/nubank-challenge/dependencies"
 	"github.com/g-villarinho/nubank-challenge/handlers"
+	"github.com/g-villarinho/nubank-challenge/metrics"
+	"github.com/g-villarinho/nubank-challenge/middlewares"
+	"github.com/g-villarinho/nubank-challenge/models"
 	"github.com/g-villarinho/nubank-challenge/pkgs"
-	"github.com/g-villarinho/nubank-challenge/repositories"
 	"github.com/g-villarinho/nubank-challenge/services"
 	"github.com/g-villarinho/nubank-challenge/storages"
+	"github.com/g-villarinho/nubank-challenge/workers"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 	"gorm.io/gorm"
 )
 
+// setupLogger registra o logger padrão, que remove emails e telefones de todos os registros.
+// Em DEV os logs saem em texto; nos demais ambientes, em JSON.
+func setupLogger() {
+	var handler slog.Handler = slog.NewJSONHandler(os.Stdout, nil)
+	if configs.Env.Env == "DEV" {
+		handler = slog.NewTextHandler(os.Stdout, nil)
+	}
+
+	slog.SetDefault(slog.New(pkgs.NewRedactHandler(handler)))
+}
+
+// jobsDB é o nome do *gorm.DB conectado com POSTGRES_JOBS_USER, usado apenas pelos workers
+const jobsDB = "jobs"
+
 func initDependencies(ctx context.Context, di *pkgs.Di) {
 	db, err := storages.NewPostgresStorage(ctx)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	if err := storages.CheckRowLevelSecurity(ctx, db); err != nil {
+		log.Fatal(err)
+	}
+
 	pkgs.Provide(di, func(di *pkgs.Di) (*gorm.DB, error) {
 		return db, nil
 	})
 
-	// Handlers
-	pkgs.Provide(di, handlers.NewClientHandler)
-	pkgs.Provide(di, handlers.NewContactHandler)
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	di.RegisterCloser("postgres", sqlDB)
+
+	jobs, err := storages.NewPostgresJobsStorage(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	pkgs.ProvideNamed(di, jobsDB, func(di *pkgs.Di) (*gorm.DB, error) {
+		return jobs, nil
+	})
+
+	jobsSQLDB, err := jobs.DB()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	di.RegisterCloser("postgres-jobs", jobsSQLDB)
+
+	dependencies.Provide(di)
+
+	m, err := pkgs.Invoke[*metrics.Metrics](di)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := m.RegisterDB(sqlDB, configs.Env.Postgres.DBName); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// setupWorkers registra nos hooks do container o início dos workers em segundo plano e a
+// parada deles. A parada impede novos ciclos e espera o ciclo em andamento de cada um, que é
+// interrompido se passar do prazo do desligamento, para que o pool de conexões com o Postgres só
+// seja fechado depois que nenhum ciclo o usa.
+//
+// Os workers atendem todos os tenants, então são montados num Clone do container em que o
+// *gorm.DB é o pool de POSTGRES_JOBS_USER. As métricas continuam as da API.
+func setupWorkers(e *echo.Echo, di *pkgs.Di) {
+	jobs, err := pkgs.InvokeNamed[*gorm.DB](di, jobsDB)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	m, err := pkgs.Invoke[*metrics.Metrics](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	workersDi := di.Clone()
+	pkgs.OverrideValue(workersDi, jobs)
+	pkgs.OverrideValue(workersDi, m)
+
+	outboxRelay, err := pkgs.Invoke[workers.OutboxRelay](workersDi)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	webhookDispatcher, err := pkgs.Invoke[workers.WebhookDispatcher](workersDi)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+
+	di.OnStart("workers", func(context.Context) error {
+		for _, run := range []func(ctx context.Context, stop <-chan struct{}){outboxRelay.Run, webhookDispatcher.Run} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				run(ctx, stop)
+			}()
+		}
+
+		return nil
+	})
+
+	di.OnShutdown("workers", func(shutdownCtx context.Context) error {
+		close(stop)
 
-	// Services
-	pkgs.Provide(di, services.NewClientService)
-	pkgs.Provide(di, services.NewContactService)
+		// No prazo do desligamento o ciclo em andamento é cancelado, e as consultas dele terminam
+		// antes de o pool ser fechado
+		release := context.AfterFunc(shutdownCtx, cancel)
+		defer release()
 
-	// Repositories
-	pkgs.Provide(di, repositories.NewClientRepository)
-	pkgs.Provide(di, repositories.NewContactRepository)
+		wg.Wait()
+		cancel()
+
+		return shutdownCtx.Err()
+	})
+}
+
+// shutdown desliga a API quando o processo recebe o sinal de parada. Primeiro marca a instância
+// como em desligamento, para que o /readyz passe a responder 503, e espera HEALTH_DRAIN_SECONDS
+// para que o balanceador deixe de enviar requisições. Depois para de aceitar conexões e espera,
+// até SHUTDOWN_TIMEOUT_SECONDS, as requisições em andamento. Por fim executa os hooks de
+// desligamento do container, no mesmo prazo: os workers terminam o ciclo em andamento, o pool de
+// conexões com o Postgres é fechado e os spans pendentes são exportados.
+func shutdown(e *echo.Echo, di *pkgs.Di) {
+	healthcheckService, err := pkgs.Invoke[services.HealthcheckService](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	drain := time.Duration(configs.Env.Health.DrainSeconds) * time.Second
+	timeout := time.Duration(configs.Env.Shutdown.TimeoutSeconds) * time.Second
+
+	slog.Info("shutting down, draining traffic", slog.Duration("drain", drain), slog.Duration("timeout", timeout))
+	healthcheckService.Drain()
+	time.Sleep(drain)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := e.Shutdown(ctx); err != nil {
+		slog.Error("error to shutdown server", "error", err)
+	}
+
+	if err := di.Shutdown(ctx); err != nil {
+		slog.Error("error to shutdown dependencies", "error", err)
+	}
+
+	slog.Info("shutdown completed")
 }
 
 func setupRoutes(e *echo.Echo, di *pkgs.Di) {
+	setupMiddlewares(e, di)
+	setupMetricsRoutes(e, di)
+	setupHealthRoutes(e, di)
 	setupClientRoutes(e, di)
 	setupContactRoutes(e, di)
+	setupAuditRoutes(e, di)
+	setupWebhookRoutes(e, di)
+}
+
+func setupMiddlewares(e *echo.Echo, di *pkgs.Di) {
+	httpTracing, err := pkgs.Invoke[middlewares.TracingMiddleware](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	requestLogger, err := pkgs.Invoke[middlewares.RequestLoggerMiddleware](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	httpMetrics, err := pkgs.Invoke[middlewares.MetricsMiddleware](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	requestContext, err := pkgs.Invoke[middlewares.RequestContextMiddleware](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	ipExtractor, err := newIPExtractor(configs.Env.HTTP.TrustedProxies)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	e.IPExtractor = ipExtractor
+
+	e.Use(httpTracing.Handle)
+	e.Use(requestLogger.Handle)
+	e.Use(httpMetrics.Handle)
+	e.Use(requestContext.Handle)
+}
+
+// newIPExtractor escolhe de onde vem o IP do limite anônimo. Sem proxies confiáveis o IP é o da
+// conexão, porque X-Forwarded-For e X-Real-IP são escolhidos pelo cliente e dariam um balde novo
+// a cada requisição. Atrás do balanceador, o X-Forwarded-For só é lido a partir dos saltos que
+// vieram dos proxies informados.
+func newIPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	var ranges []echo.TrustOption
+	for _, proxy := range trustedProxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(proxy)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(proxy)
+			if addrErr != nil {
+				return nil, fmt.Errorf("parse trusted proxy %q: %w", proxy, addrErr)
+			}
+
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+
+		ranges = append(ranges, echo.TrustIPRange(&net.IPNet{
+			IP:   prefix.Masked().Addr().AsSlice(),
+			Mask: net.CIDRMask(prefix.Bits(), prefix.Addr().BitLen()),
+		}))
+	}
+
+	if len(ranges) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	// Só os proxies informados são confiáveis, e não as redes internas que o echo aceita por padrão
+	options := append([]echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}, ranges...)
+
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
+// setupMetricsRoutes expõe as métricas apenas ao coletor, que se autentica com METRICS_TOKEN no
+// header Authorization (Bearer). Sem o token configurado, a rota não é registrada.
+func setupMetricsRoutes(e *echo.Echo, di *pkgs.Di) {
+	if configs.Env.Metrics.Token == "" {
+		slog.Warn("metrics token is not configured, /metrics is disabled")
+		return
+	}
+
+	m, err := pkgs.Invoke[*metrics.Metrics](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	rateLimit, err := pkgs.Invoke[middlewares.RateLimitMiddleware](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	anon := rateLimit.Limit(models.RateLimitGroupAnon)
+
+	token := []byte(configs.Env.Metrics.Token)
+	collector := middleware.KeyAuthWithConfig(middleware.KeyAuthConfig{
+		Validator: func(key string, ectx echo.Context) (bool, error) {
+			return subtle.ConstantTimeCompare([]byte(key), token) == 1, nil
+		},
+		ErrorHandler: func(err error, ectx echo.Context) error {
+			return models.ErrUnauthorized
+		},
+	})
+
+	e.GET("/metrics", echo.WrapHandler(m.Handler()), anon, collector)
+}
+
+// setupHealthRoutes registra as sondas do orquestrador e do balanceador, sem autenticação nem
+// limite de requisições
+func setupHealthRoutes(e *echo.Echo, di *pkgs.Di) {
+	healthCheckHandler, err := pkgs.Invoke[handlers.HealthCheckHandler](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	e.GET("/healthz", healthCheckHandler.Liveness)
+	e.GET("/readyz", healthCheckHandler.Readiness)
 }
 
 func setupClientRoutes(e *echo.Echo, di *pkgs.Di) {
@@ -47,9 +324,34 @@ func setupClientRoutes(e *echo.Echo, di *pkgs.Di) {
 		e.Logger.Fatal(err)
 	}
 
-	e.POST("/clients", clientHandler.CreateClient)
-	e.GET("/clients", clientHandler.GetClientsWithContact)
-	e.GET("/clients/:clientId/contacts", clientHandler.GetClientContactsByID)
+	auth, err := pkgs.Invoke[middlewares.AuthMiddleware](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	rateLimit, err := pkgs.Invoke[middlewares.RateLimitMiddleware](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	anon := rateLimit.Limit(models.RateLimitGroupAnon)
+	read := rateLimit.Limit(models.RateLimitGroupRead)
+	write := rateLimit.Limit(models.RateLimitGroupWrite)
+
+	idempotency, err := pkgs.Invoke[middlewares.IdempotencyMiddleware](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	e.POST("/clients", clientHandler.CreateClient, anon, auth.Require(models.ScopeClientsWrite), write, idempotency.Handle)
+	e.GET("/clients", clientHandler.GetClientsWithContact, anon, auth.Require(models.ScopeClientsRead), read)
+	e.GET("/clients/deleted", clientHandler.GetDeletedClients, anon, auth.Require(models.ScopeClientsRead), read)
+	e.GET("/clients/:clientId/contacts", clientHandler.GetClientContactsByID, anon, auth.Require(models.ScopeClientsRead, models.ScopeContactsRead), read)
+	e.GET("/clients/:clientId", clientHandler.GetClient, anon, auth.Require(models.ScopeClientsRead), read)
+	e.PUT("/clients/:clientId", clientHandler.UpdateClient, anon, auth.Require(models.ScopeClientsWrite), write)
+	e.PATCH("/clients/:clientId", clientHandler.PatchClient, anon, auth.Require(models.ScopeClientsWrite), write)
+	e.DELETE("/clients/:clientId", clientHandler.DeleteClient, anon, auth.Require(models.ScopeClientsWrite), write)
+	e.POST("/clients/:clientId/restore", clientHandler.RestoreClient, anon, auth.Require(models.ScopeClientsWrite), write)
 }
 
 func setupContactRoutes(e *echo.Echo, di *pkgs.Di) {
@@ -58,5 +360,82 @@ func setupContactRoutes(e *echo.Echo, di *pkgs.Di) {
 		e.Logger.Fatal(err)
 	}
 
-	e.POST("/contacts", contactHandler.CreateContact)
+	auth, err := pkgs.Invoke[middlewares.AuthMiddleware](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	rateLimit, err := pkgs.Invoke[middlewares.RateLimitMiddleware](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	anon := rateLimit.Limit(models.RateLimitGroupAnon)
+	read := rateLimit.Limit(models.RateLimitGroupRead)
+	write := rateLimit.Limit(models.RateLimitGroupWrite)
+
+	idempotency, err := pkgs.Invoke[middlewares.IdempotencyMiddleware](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	e.POST("/contacts", contactHandler.CreateContact, anon, auth.Require(models.ScopeContactsWrite), write, idempotency.Handle)
+	e.GET("/contacts", contactHandler.SearchContacts, anon, auth.Require(models.ScopeContactsRead), read)
+	e.GET("/contacts/:contactId", contactHandler.GetContact, anon, auth.Require(models.ScopeContactsRead), read)
+	e.PUT("/contacts/:contactId", contactHandler.UpdateContact, anon, auth.Require(models.ScopeContactsWrite), write)
+	e.PATCH("/contacts/:contactId", contactHandler.PatchContact, anon, auth.Require(models.ScopeContactsWrite), write)
+	e.DELETE("/contacts/:contactId", contactHandler.DeleteContact, anon, auth.Require(models.ScopeContactsWrite), write)
+	e.POST("/contacts/:contactId/transfer", contactHandler.TransferContact, anon, auth.Require(models.ScopeContactsWrite), write)
+}
+
+func setupAuditRoutes(e *echo.Echo, di *pkgs.Di) {
+	auditHandler, err := pkgs.Invoke[handlers.AuditHandler](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	auth, err := pkgs.Invoke[middlewares.AuthMiddleware](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	rateLimit, err := pkgs.Invoke[middlewares.RateLimitMiddleware](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	anon := rateLimit.Limit(models.RateLimitGroupAnon)
+	admin := rateLimit.Limit(models.RateLimitGroupAdmin)
+
+	e.GET("/audit", auditHandler.GetAuditLogs, anon, auth.Require(models.ScopeAuditRead), admin)
+	e.GET("/clients/:clientId/history", auditHandler.GetClientHistory, anon, auth.Require(models.ScopeAuditRead), admin)
+}
+
+func setupWebhookRoutes(e *echo.Echo, di *pkgs.Di) {
+	webhookHandler, err := pkgs.Invoke[handlers.WebhookHandler](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	auth, err := pkgs.Invoke[middlewares.AuthMiddleware](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	rateLimit, err := pkgs.Invoke[middlewares.RateLimitMiddleware](di)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
+	anon := rateLimit.Limit(models.RateLimitGroupAnon)
+	admin := rateLimit.Limit(models.RateLimitGroupAdmin)
+
+	e.POST("/webhooks", webhookHandler.CreateWebhook, anon, auth.Require(models.ScopeWebhooksManage), admin)
+	e.GET("/webhooks", webhookHandler.GetWebhooks, anon, auth.Require(models.ScopeWebhooksManage), admin)
+	e.GET("/webhooks/dead-letters", webhookHandler.GetDeadLetters, anon, auth.Require(models.ScopeWebhooksManage), admin)
+	e.POST("/webhooks/deliveries/:deliveryId/redeliver", webhookHandler.Redeliver, anon, auth.Require(models.ScopeWebhooksManage), admin)
+	e.GET("/webhooks/:webhookId", webhookHandler.GetWebhookByID, anon, auth.Require(models.ScopeWebhooksManage), admin)
+	e.PUT("/webhooks/:webhookId", webhookHandler.UpdateWebhook, anon, auth.Require(models.ScopeWebhooksManage), admin)
+	e.DELETE("/webhooks/:webhookId", webhookHandler.DeleteWebhook, anon, auth.Require(models.ScopeWebhooksManage), admin)
+	e.GET("/webhooks/:webhookId/deliveries", webhookHandler.GetDeliveries, anon, auth.Require(models.ScopeWebhooksManage), admin)
 }
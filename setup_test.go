@@ -0,0 +1,274 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/g-villarinho/nubank-challenge/configs"
+	"github.com/g-villarinho/nubank-challenge/dependencies"
+	"github.com/g-villarinho/nubank-challenge/handlers"
+	"github.com/g-villarinho/nubank-challenge/limiters"
+	"github.com/g-villarinho/nubank-challenge/mocks"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/publishers"
+	"github.com/g-villarinho/nubank-challenge/repositories"
+	"github.com/g-villarinho/nubank-challenge/services"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDi monta o grafo de dependencies.Provide sobre um *gorm.DB do sqlmock, com a
+// configuração padrão e os baldes e eventos em memória
+func newTestDi(t *testing.T) (*pkgs.Di, sqlmock.Sqlmock) {
+	t.Helper()
+
+	previous := configs.Env
+	t.Cleanup(func() { configs.Env = previous })
+
+	configs.Env = models.Environment{
+		Outbox:  models.Outbox{Publisher: publishers.PublisherMemory, PollIntervalMs: 1000, BatchSize: 100},
+		Webhook: models.Webhook{MaxAttempts: 8, TimeoutSeconds: 10, PollIntervalMs: 1000, BatchSize: 20},
+		RateLimit: models.RateLimit{
+			Enabled:        true,
+			Store:          limiters.StoreMemory,
+			ReadPerMinute:  600,
+			ReadBurst:      100,
+			WritePerMinute: 120,
+			WriteBurst:     20,
+			AdminPerMinute: 60,
+			AdminBurst:     10,
+			AnonPerMinute:  1200,
+			AnonBurst:      200,
+		},
+		Health: models.Health{CheckTimeoutMs: 1000},
+	}
+
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	di := pkgs.NewDi()
+	pkgs.Provide(di, func(di *pkgs.Di) (*gorm.DB, error) {
+		return db, nil
+	})
+	dependencies.Provide(di)
+
+	return di, mock
+}
+
+func TestProvideDependencies(t *testing.T) {
+	t.Run("should build every service of the application", func(t *testing.T) {
+		di, _ := newTestDi(t)
+
+		err := di.Validate()
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should check postgres through the real graph", func(t *testing.T) {
+		di, sqlMock := newTestDi(t)
+
+		sqlMock.ExpectExec(regexp.QuoteMeta("SELECT 1")).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		e := echo.New()
+		setupRoutes(e, di)
+
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"postgres":{"status":"up"`)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("should serve the metrics only to the collector", func(t *testing.T) {
+		di, _ := newTestDi(t)
+		configs.Env.Metrics.Token = "scrape-token"
+
+		e := echo.New()
+		e.HTTPErrorHandler = handlers.HTTPErrorHandler
+		setupRoutes(e, di)
+
+		codes := make([]int, 0, 3)
+		for _, authorization := range []string{"", "Bearer wrong-token", "Bearer scrape-token"} {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, authorization)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			codes = append(codes, rec.Code)
+		}
+
+		assert.Equal(t, []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusOK}, codes)
+	})
+
+	t.Run("should limit by ip before authenticating the credential", func(t *testing.T) {
+		base, _ := newTestDi(t)
+		configs.Env.RateLimit.AnonBurst = 1
+		di := base.Clone()
+
+		apiKeyService := new(mocks.APIKeyServiceMock)
+		pkgs.OverrideValue[services.APIKeyService](di, apiKeyService)
+
+		apiKeyService.On("Authenticate", mock.Anything, "wrong-key").Return(nil, models.ErrUnauthorized).Once()
+
+		e := echo.New()
+		e.HTTPErrorHandler = handlers.HTTPErrorHandler
+		setupRoutes(e, di)
+
+		codes := make([]int, 0, 2)
+		for range 2 {
+			req := httptest.NewRequest(http.MethodGet, "/clients", nil)
+			req.Header.Set("X-API-Key", "wrong-key")
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			codes = append(codes, rec.Code)
+		}
+
+		assert.Equal(t, []int{http.StatusUnauthorized, http.StatusTooManyRequests}, codes)
+		apiKeyService.AssertExpectations(t)
+	})
+
+	t.Run("should read the forwarded ip only from a trusted proxy", func(t *testing.T) {
+		tests := []struct {
+			name           string
+			trustedProxies []string
+			expected       []int
+		}{
+			{
+				name:           "trusted hop",
+				trustedProxies: []string{"192.0.2.0/24"},
+				expected:       []int{http.StatusUnauthorized, http.StatusUnauthorized},
+			},
+			{
+				name:           "untrusted hop",
+				trustedProxies: []string{"198.51.100.7"},
+				expected:       []int{http.StatusUnauthorized, http.StatusTooManyRequests},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				base, _ := newTestDi(t)
+				configs.Env.RateLimit.AnonBurst = 1
+				configs.Env.HTTP.TrustedProxies = tt.trustedProxies
+				di := base.Clone()
+
+				apiKeyService := new(mocks.APIKeyServiceMock)
+				pkgs.OverrideValue[services.APIKeyService](di, apiKeyService)
+
+				apiKeyService.On("Authenticate", mock.Anything, "wrong-key").Return(nil, models.ErrUnauthorized)
+
+				e := echo.New()
+				e.HTTPErrorHandler = handlers.HTTPErrorHandler
+				setupRoutes(e, di)
+
+				// A conexão vem sempre de 192.0.2.1, o endereço do httptest
+				codes := make([]int, 0, 2)
+				for _, forwardedFor := range []string{"203.0.113.10", "203.0.113.11"} {
+					req := httptest.NewRequest(http.MethodGet, "/clients", nil)
+					req.Header.Set("X-API-Key", "wrong-key")
+					req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+					rec := httptest.NewRecorder()
+					e.ServeHTTP(rec, req)
+					codes = append(codes, rec.Code)
+				}
+
+				assert.Equal(t, tt.expected, codes)
+			})
+		}
+	})
+
+	t.Run("should not charge the ip bucket for a verified credential", func(t *testing.T) {
+		base, _ := newTestDi(t)
+		configs.Env.RateLimit.AnonBurst = 1
+		di := base.Clone()
+
+		apiKeyService := new(mocks.APIKeyServiceMock)
+		pkgs.OverrideValue[services.APIKeyService](di, apiKeyService)
+
+		apiKeyService.On("Authenticate", mock.Anything, "test-key").Return(&models.Principal{
+			Method:   models.AuthMethodAPIKey,
+			ID:       "key-1",
+			Subject:  "backoffice",
+			Scopes:   []string{models.ScopeClientsRead},
+			TenantID: models.DefaultTenant,
+		}, nil)
+		apiKeyService.On("Authenticate", mock.Anything, "wrong-key").Return(nil, models.ErrUnauthorized)
+
+		e := echo.New()
+		e.HTTPErrorHandler = handlers.HTTPErrorHandler
+		setupRoutes(e, di)
+
+		// Sem o escopo de auditoria a requisição para no 403, depois de a credencial ser verificada
+		codes := make([]int, 0, 4)
+		for _, key := range []string{"test-key", "test-key", "wrong-key", "wrong-key"} {
+			req := httptest.NewRequest(http.MethodGet, "/audit", nil)
+			req.Header.Set("X-API-Key", key)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			codes = append(codes, rec.Code)
+		}
+
+		assert.Equal(t, []int{http.StatusForbidden, http.StatusForbidden, http.StatusUnauthorized, http.StatusTooManyRequests}, codes)
+	})
+
+	t.Run("should serve a route with the real graph and fake repositories", func(t *testing.T) {
+		base, _ := newTestDi(t)
+		di := base.Clone()
+
+		apiKeyService := new(mocks.APIKeyServiceMock)
+		clientRepo := new(mocks.ClientRepositoryMock)
+		contactRepo := new(mocks.ContactRepositoryMock)
+
+		pkgs.OverrideValue[services.APIKeyService](di, apiKeyService)
+		pkgs.OverrideValue[repositories.ClientRepository](di, clientRepo)
+		pkgs.OverrideValue[repositories.ContactRepository](di, contactRepo)
+
+		apiKeyService.On("Authenticate", mock.Anything, "test-key").Return(&models.Principal{
+			Method:   models.AuthMethodAPIKey,
+			ID:       "key-1",
+			Subject:  "backoffice",
+			Scopes:   []string{models.ScopeClientsRead, models.ScopeContactsRead},
+			TenantID: models.DefaultTenant,
+		}, nil)
+		clientRepo.On("GetClientByID", mock.Anything, "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f").
+			Return(&models.Client{ID: "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", Name: "Gabriel"}, nil)
+		contactRepo.On("GetContactsByClientID", mock.Anything, "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f").
+			Return([]*models.Contact{{ID: "contact-1", ClientID: "6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f", Email: "gabriel@gmail.com"}}, nil)
+
+		require.NoError(t, di.Validate())
+
+		e := echo.New()
+		e.Validator = pkgs.NewValidator()
+		setupRoutes(e, di)
+
+		req := httptest.NewRequest(http.MethodGet, "/clients/6f1b9c2e-8a4d-4f3b-9c7e-1d2a3b4c5e6f/contacts", nil)
+		req.Header.Set("X-API-Key", "test-key")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"id":"contact-1"`)
+		apiKeyService.AssertExpectations(t)
+		clientRepo.AssertExpectations(t)
+		contactRepo.AssertExpectations(t)
+	})
+}
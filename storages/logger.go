@@ -0,0 +1,78 @@
+package storages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// slowQueryThreshold é a duração a partir da qual uma consulta é registrada como lenta
+const slowQueryThreshold = 200 * time.Millisecond
+
+// gormLogger envia os logs do GORM ao logger da requisição, para que carreguem o requestId e
+// passem pela redação de dados pessoais, já que o SQL registrado inclui os valores dos parâmetros
+type gormLogger struct {
+	level logger.LogLevel
+}
+
+func newGormLogger() logger.Interface {
+	return &gormLogger{level: logger.Warn}
+}
+
+func (g *gormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return &gormLogger{level: level}
+}
+
+func (g *gormLogger) Info(ctx context.Context, msg string, args ...any) {
+	if g.level >= logger.Info {
+		pkgs.LoggerFromContext(ctx).InfoContext(ctx, fmt.Sprintf(msg, args...), slog.String("component", "gorm"))
+	}
+}
+
+func (g *gormLogger) Warn(ctx context.Context, msg string, args ...any) {
+	if g.level >= logger.Warn {
+		pkgs.LoggerFromContext(ctx).WarnContext(ctx, fmt.Sprintf(msg, args...), slog.String("component", "gorm"))
+	}
+}
+
+func (g *gormLogger) Error(ctx context.Context, msg string, args ...any) {
+	if g.level >= logger.Error {
+		pkgs.LoggerFromContext(ctx).ErrorContext(ctx, fmt.Sprintf(msg, args...), slog.String("component", "gorm"))
+	}
+}
+
+// Trace registra as consultas que falharam e as lentas. Não encontrar o registro faz parte do
+// fluxo normal dos repositórios e não é registrado.
+func (g *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if g.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && g.level >= logger.Error:
+		sql, rows := fc()
+		pkgs.LoggerFromContext(ctx).ErrorContext(ctx, "database query failed",
+			slog.String("component", "gorm"),
+			slog.String("sql", sql),
+			slog.Int64("rows", rows),
+			slog.Duration("elapsed", elapsed),
+			slog.Any("error", err),
+		)
+	case elapsed > slowQueryThreshold && g.level >= logger.Warn:
+		sql, rows := fc()
+		pkgs.LoggerFromContext(ctx).WarnContext(ctx, "slow database query",
+			slog.String("component", "gorm"),
+			slog.String("sql", sql),
+			slog.Int64("rows", rows),
+			slog.Duration("elapsed", elapsed),
+		)
+	}
+}
@@ -6,21 +6,61 @@ import (
 	"time"
 
 	"github.com/g-villarinho/nubank-challenge/configs"
+	"github.com/g-villarinho/nubank-challenge/tracing"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
+// NewPostgresStorage conecta com o usuário da aplicação, sujeito às políticas de row-level security
 func NewPostgresStorage(ctx context.Context) (*gorm.DB, error) {
-	dsn := getDSN()
+	return open(ctx, getDSN(configs.Env.Postgres.User, configs.Env.Postgres.Password))
+}
+
+// NewPostgresOwnerStorage conecta com o dono das tabelas. Deve ser usado apenas pelas migrations.
+func NewPostgresOwnerStorage(ctx context.Context) (*gorm.DB, error) {
+	return open(ctx, getDSN(configs.Env.Postgres.OwnerUser, configs.Env.Postgres.OwnerPassword))
+}
+
+// NewPostgresJobsStorage conecta com o usuário das rotinas internas, que ignora as políticas de
+// row-level security para alcançar todos os tenants. Não deve ser usado para atender requisições.
+func NewPostgresJobsStorage(ctx context.Context) (*gorm.DB, error) {
+	return open(ctx, getDSN(configs.Env.Postgres.JobsUser, configs.Env.Postgres.JobsPassword))
+}
+
+// CheckRowLevelSecurity falha quando o usuário da conexão é superusuário ou tem BYPASSRLS, pois
+// ele ignoraria as políticas que isolam os tenants.
+func CheckRowLevelSecurity(ctx context.Context, db *gorm.DB) error {
+	var bypass bool
+
+	err := db.WithContext(ctx).
+		Raw("SELECT rolsuper OR rolbypassrls FROM pg_roles WHERE rolname = current_user").
+		Scan(&bypass).Error
+	if err != nil {
+		return fmt.Errorf("check postgres role: %w", err)
+	}
+
+	if bypass {
+		return fmt.Errorf("postgres user %s bypasses row level security", configs.Env.Postgres.User)
+	}
+
+	return nil
+}
 
+func open(ctx context.Context, dsn string) (*gorm.DB, error) {
 	db, err := gorm.Open(postgres.New(postgres.Config{
 		DSN: dsn,
-	}), &gorm.Config{})
+	}), &gorm.Config{
+		Logger: newGormLogger(),
+	})
 
 	if err != nil {
 		return nil, err
 	}
 
+	if err := db.Use(tracing.GormPlugin{}); err != nil {
+		return nil, err
+	}
+
 	slqDB, err := db.DB()
 	if err != nil {
 		return nil, err
@@ -38,13 +78,13 @@ func NewPostgresStorage(ctx context.Context) (*gorm.DB, error) {
 	return db, nil
 }
 
-func getDSN() string {
+func getDSN(user string, password string) string {
 	return fmt.Sprintf("host=%s port=%d user=%s dbname=%s password=%s sslmode=%s",
 		configs.Env.Postgres.Host,
 		configs.Env.Postgres.Port,
-		configs.Env.Postgres.User,
+		user,
 		configs.Env.Postgres.DBName,
-		configs.Env.Postgres.Password,
+		password,
 		configs.Env.Postgres.DBSSLMode,
 	)
 }
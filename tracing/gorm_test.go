@@ -0,0 +1,120 @@
+package tracing
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type gormTestRecord struct {
+	ID   string
+	Name string
+}
+
+// newSpanRecorder troca o TracerProvider global por um que guarda os spans em memória
+func newSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	return recorder
+}
+
+func newTracedMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(GormPlugin{}))
+
+	return db, mock
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+
+	return attribute.Value{}
+}
+
+func TestGormPlugin(t *testing.T) {
+	t.Run("should record a span per statement under the span of the context without the parameter values", func(t *testing.T) {
+		recorder := newSpanRecorder(t)
+		db, mock := newTracedMockDB(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "gorm_test_records" WHERE name = $1`)).
+			WithArgs("Gabriel").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("1", "Gabriel"))
+
+		ctx, parent := Start(context.Background(), "parent")
+		var records []gormTestRecord
+		err := db.WithContext(ctx).Where("name = ?", "Gabriel").Find(&records).Error
+		parent.End()
+
+		assert.NoError(t, err)
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+
+		span := spans[0]
+		assert.Equal(t, "gorm.query", span.Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Equal(t, `SELECT * FROM "gorm_test_records" WHERE name = $1`, spanAttribute(span, "db.query.text").AsString())
+		assert.Equal(t, "gorm_test_records", spanAttribute(span, "db.collection.name").AsString())
+		assert.Equal(t, codes.Unset, span.Status().Code)
+	})
+
+	t.Run("should mark the span when the statement fails", func(t *testing.T) {
+		recorder := newSpanRecorder(t)
+		db, mock := newTracedMockDB(t)
+
+		mock.ExpectExec(regexp.QuoteMeta(`SELECT set_config($1, $2, true)`)).WillReturnError(assert.AnError)
+
+		err := db.Exec("SELECT set_config(?, ?, true)", "app.tenant_id", "default").Error
+
+		assert.ErrorIs(t, err, assert.AnError)
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "gorm.raw", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+	})
+
+	t.Run("should not mark the span when the record is not found", func(t *testing.T) {
+		recorder := newSpanRecorder(t)
+		db, mock := newTracedMockDB(t)
+
+		mock.ExpectQuery(`SELECT \* FROM "gorm_test_records"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+		var record gormTestRecord
+		err := db.First(&record, "id = ?", "1").Error
+
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	})
+}
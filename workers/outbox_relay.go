@@ -0,0 +1,58 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/configs"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/services"
+)
+
+type OutboxRelay interface {
+	Run(ctx context.Context, stop <-chan struct{})
+}
+
+type outboxRelay struct {
+	di       *pkgs.Di
+	interval time.Duration
+	ob       services.OutboxService
+}
+
+func NewOutboxRelay(di *pkgs.Di) (OutboxRelay, error) {
+	outboxService, err := pkgs.Invoke[services.OutboxService](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke services.outbox: %w", err)
+	}
+
+	return &outboxRelay{
+		di:       di,
+		interval: time.Duration(configs.Env.Outbox.PollIntervalMs) * time.Millisecond,
+		ob:       outboxService,
+	}, nil
+}
+
+// Run publica os eventos pendentes do outbox até stop ser fechado. Sem eventos pendentes, consulta
+// o outbox de novo a cada OUTBOX_POLL_INTERVAL_MS. Cancelar ctx interrompe o ciclo em andamento.
+//
+// Exemplo:
+//
+// go relay.Run(ctx, stop)
+func (o *outboxRelay) Run(ctx context.Context, stop <-chan struct{}) {
+	logger := slog.With(
+		slog.String("worker", "outbox_relay"),
+	)
+
+	// Os eventos de todos os tenants passam pelo mesmo relay
+	poll(pkgs.WithTenant(ctx, models.AllTenants), stop, o.interval, func(ctx context.Context) (int, error) {
+		published, err := o.ob.Relay(ctx)
+		if err != nil {
+			logger.Error("error to relay outbox events", "error", err)
+		}
+
+		return published, err
+	})
+}
@@ -0,0 +1,28 @@
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOutboxRelay_Run(t *testing.T) {
+	t.Run("should keep relaying until stopped", func(t *testing.T) {
+		outboxService := new(mocks.OutboxServiceMock)
+		relay := &outboxRelay{interval: time.Hour, ob: outboxService}
+
+		stop := make(chan struct{})
+
+		outboxService.On("Relay", mock.Anything).Return(2, nil).Once()
+		outboxService.On("Relay", mock.Anything).Return(0, nil).Once().Run(func(mock.Arguments) {
+			close(stop)
+		})
+
+		relay.Run(context.Background(), stop)
+
+		outboxService.AssertExpectations(t)
+	})
+}
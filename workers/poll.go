@@ -0,0 +1,33 @@
+package workers
+
+import (
+	"context"
+	"time"
+)
+
+// poll executa cycle até stop ser fechado. Enquanto o ciclo anterior processar algum item o laço
+// continua sem esperar; caso contrário, ou quando o ciclo falha, aguarda interval. Fechar stop não
+// interrompe o ciclo em andamento, para que o que já foi enviado seja registrado e não seja
+// enviado de novo; o ciclo só é interrompido quando ctx é cancelado, no prazo do desligamento.
+func poll(ctx context.Context, stop <-chan struct{}, interval time.Duration, cycle func(ctx context.Context) (int, error)) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		processed, err := cycle(ctx)
+		if processed > 0 && err == nil {
+			timer.Reset(0)
+			continue
+		}
+
+		timer.Reset(interval)
+	}
+}
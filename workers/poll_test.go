@@ -0,0 +1,81 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoll(t *testing.T) {
+	t.Run("should keep polling while items are processed and stop when asked", func(t *testing.T) {
+		stop := make(chan struct{})
+
+		results := []int{3, 1, 0}
+		var calls int
+
+		done := make(chan struct{})
+		go func() {
+			poll(context.Background(), stop, time.Hour, func(ctx context.Context) (int, error) {
+				processed := results[calls]
+				calls++
+				if processed == 0 {
+					close(stop)
+				}
+
+				return processed, nil
+			})
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("poll did not stop after stop was closed")
+		}
+
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("should wait for the interval after a failed cycle", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		var calls int
+		poll(ctx, make(chan struct{}), time.Hour, func(ctx context.Context) (int, error) {
+			calls++
+			return 5, errors.New("db failure")
+		})
+
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should finish the cycle in progress when asked to stop", func(t *testing.T) {
+		stop := make(chan struct{})
+
+		var cycleErr error
+		poll(context.Background(), stop, time.Hour, func(ctx context.Context) (int, error) {
+			close(stop)
+			cycleErr = ctx.Err()
+			return 0, nil
+		})
+
+		assert.NoError(t, cycleErr)
+	})
+
+	t.Run("should cancel the cycle in progress when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var cycleErr error
+		poll(ctx, make(chan struct{}), time.Hour, func(ctx context.Context) (int, error) {
+			cancel()
+			cycleErr = ctx.Err()
+			return 0, nil
+		})
+
+		assert.ErrorIs(t, cycleErr, context.Canceled)
+	})
+}
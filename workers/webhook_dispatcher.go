@@ -0,0 +1,58 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/configs"
+	"github.com/g-villarinho/nubank-challenge/models"
+	"github.com/g-villarinho/nubank-challenge/pkgs"
+	"github.com/g-villarinho/nubank-challenge/services"
+)
+
+type WebhookDispatcher interface {
+	Run(ctx context.Context, stop <-chan struct{})
+}
+
+type webhookDispatcher struct {
+	di       *pkgs.Di
+	interval time.Duration
+	ws       services.WebhookService
+}
+
+func NewWebhookDispatcher(di *pkgs.Di) (WebhookDispatcher, error) {
+	webhookService, err := pkgs.Invoke[services.WebhookService](di)
+	if err != nil {
+		return nil, fmt.Errorf("invoke services.webhook: %w", err)
+	}
+
+	return &webhookDispatcher{
+		di:       di,
+		interval: time.Duration(configs.Env.Webhook.PollIntervalMs) * time.Millisecond,
+		ws:       webhookService,
+	}, nil
+}
+
+// Run envia as entregas de webhook vencidas até stop ser fechado. Sem entregas vencidas, consulta
+// de novo a cada WEBHOOK_POLL_INTERVAL_MS. Cancelar ctx interrompe o ciclo em andamento.
+//
+// Exemplo:
+//
+// go dispatcher.Run(ctx, stop)
+func (w *webhookDispatcher) Run(ctx context.Context, stop <-chan struct{}) {
+	logger := slog.With(
+		slog.String("worker", "webhook_dispatcher"),
+	)
+
+	// As entregas de todos os tenants passam pelo mesmo dispatcher
+	poll(pkgs.WithTenant(ctx, models.AllTenants), stop, w.interval, func(ctx context.Context) (int, error) {
+		dispatched, err := w.ws.Dispatch(ctx)
+		if err != nil {
+			logger.Error("error to dispatch webhook deliveries", "error", err)
+		}
+
+		return dispatched, err
+	})
+}
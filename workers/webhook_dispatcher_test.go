@@ -0,0 +1,28 @@
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/nubank-challenge/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookDispatcher_Run(t *testing.T) {
+	t.Run("should keep dispatching until stopped", func(t *testing.T) {
+		webhookService := new(mocks.WebhookServiceMock)
+		dispatcher := &webhookDispatcher{interval: time.Hour, ws: webhookService}
+
+		stop := make(chan struct{})
+
+		webhookService.On("Dispatch", mock.Anything).Return(2, nil).Once()
+		webhookService.On("Dispatch", mock.Anything).Return(0, nil).Once().Run(func(mock.Arguments) {
+			close(stop)
+		})
+
+		dispatcher.Run(context.Background(), stop)
+
+		webhookService.AssertExpectations(t)
+	})
+}